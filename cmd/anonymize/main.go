@@ -0,0 +1,81 @@
+// Command anonymize scrubs the personal data of former employees whose retention
+// period (PRIVACY_RETENTION_YEARS after their termination date) has ended.
+// Pass --employee to honour an erasure request for a single former employee
+// regardless of the retention period.
+package main
+
+import (
+	"flag"
+	"log"
+
+	"hr-backend/internal/config"
+	"hr-backend/internal/database"
+	"hr-backend/internal/fieldcrypt"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"hr-backend/internal/services"
+	"hr-backend/internal/storage"
+)
+
+func main() {
+	employeeID := flag.Uint("employee", 0, "Anonymize only this former employee, ignoring the retention period")
+	dryRun := flag.Bool("dry-run", false, "Only list the employees that would be anonymized")
+	flag.Parse()
+
+	cfg := config.Load()
+
+	if err := fieldcrypt.Init(cfg.Encryption.Keys, cfg.Encryption.BlindIndexKey); err != nil {
+		log.Fatalf("Failed to initialize field encryption: %v", err)
+	}
+
+	if err := database.Connect(&cfg.Database); err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	if err := database.Migrate(); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	db := database.GetDB()
+	privacyService := services.NewPrivacyService(repositories.NewEmployeeRepository(db), storage.NewLocalStorage(cfg.Storage.UploadDir), cfg, db)
+
+	var employees []models.Employee
+	if *employeeID != 0 {
+		var employee models.Employee
+		if err := db.Unscoped().First(&employee, *employeeID).Error; err != nil {
+			log.Fatalf("Employee %d not found: %v", *employeeID, err)
+		}
+		employees = append(employees, employee)
+	} else {
+		var err error
+		employees, err = privacyService.FindAnonymizable()
+		if err != nil {
+			log.Fatalf("Failed to find employees past retention: %v", err)
+		}
+	}
+
+	var anonymized int
+	for _, employee := range employees {
+		if *dryRun {
+			log.Printf("Would anonymize employee %d (%s, left %s)", employee.ID, employee.EmployeeCode, terminationDate(employee))
+			continue
+		}
+		if err := privacyService.AnonymizeEmployee(employee.ID); err != nil {
+			log.Fatalf("Failed to anonymize employee %d: %v", employee.ID, err)
+		}
+		anonymized++
+	}
+
+	if *dryRun {
+		log.Printf("%d employees would be anonymized", len(employees))
+		return
+	}
+	log.Printf("Anonymized %d employees", anonymized)
+}
+
+func terminationDate(employee models.Employee) string {
+	if employee.TerminationDate == nil {
+		return "unknown"
+	}
+	return employee.TerminationDate.Format("2006-01-02")
+}
@@ -3,13 +3,18 @@ package main
 import (
 	"flag"
 	"log"
+	"time"
+	_ "time/tzdata"
 
 	"hr-backend/internal/config"
 	"hr-backend/internal/database"
+	"hr-backend/internal/fieldcrypt"
 	"hr-backend/internal/handlers"
 	"hr-backend/internal/middleware"
 	"hr-backend/internal/repositories"
+	"hr-backend/internal/scheduler"
 	"hr-backend/internal/services"
+	"hr-backend/internal/storage"
 	"hr-backend/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -27,6 +32,23 @@ func main() {
 	// Initialize JWT
 	utils.InitJWT(cfg.JWT.Secret)
 
+	// Initialize field encryption; fixed development keys must be asked for explicitly
+	if cfg.Encryption.DevelopmentKeys {
+		if cfg.Server.GinMode == gin.ReleaseMode {
+			log.Fatal("ENCRYPTION_DEV_KEYS cannot be used in release mode")
+		}
+		log.Println("Field encryption is using development keys; stored personal data is not protected")
+		fieldcrypt.InitDevelopment()
+	} else if err := fieldcrypt.Init(cfg.Encryption.Keys, cfg.Encryption.BlindIndexKey); err != nil {
+		log.Fatalf("Failed to initialize field encryption: %v (set ENCRYPTION_KEYS and BLIND_INDEX_KEY, or ENCRYPTION_DEV_KEYS=true for local development)", err)
+	}
+
+	// Load the company time zone; the zoneinfo database is embedded as the runtime image has none
+	defaultZone, err := time.LoadLocation(cfg.Timezone.Default)
+	if err != nil {
+		log.Fatalf("Invalid default time zone: %v", err)
+	}
+
 	// Connect to database
 	if err := database.Connect(&cfg.Database); err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
@@ -34,6 +56,7 @@ func main() {
 
 	// Run migrations in background to allow server to start quickly
 	// This prevents Leapcell health check timeout
+	migrated := make(chan struct{})
 	go func() {
 		// Reset database if flag is provided
 		if *resetFlag {
@@ -58,6 +81,7 @@ func main() {
 			}
 		}
 		log.Println("Database initialization completed!")
+		close(migrated)
 	}()
 
 
@@ -70,14 +94,55 @@ func main() {
 	attendanceRepo := repositories.NewAttendanceRepository(db)
 	leaveRepo := repositories.NewLeaveRepository(db)
 	payrollRepo := repositories.NewPayrollRepository(db)
+	customFieldRepo := repositories.NewCustomFieldRepository(db)
+	notificationRepo := repositories.NewNotificationRepository(db)
+	onboardingRepo := repositories.NewOnboardingRepository(db)
+	terminationRepo := repositories.NewTerminationRepository(db)
+	employmentRepo := repositories.NewEmploymentRepository(db)
+	profileChangeRepo := repositories.NewProfileChangeRepository(db)
+	trashRepo := repositories.NewTrashRepository(db)
+	competencyRepo := repositories.NewCompetencyRepository(db)
+	assetRepo := repositories.NewAssetRepository(db)
+	shiftRepo := repositories.NewShiftRepository(db)
+	holidayRepo := repositories.NewHolidayRepository(db)
+	locationRepo := repositories.NewWorkLocationRepository(db)
+	deviceRepo := repositories.NewDeviceRepository(db)
+	correctionRepo := repositories.NewAttendanceCorrectionRepository(db)
+	overtimeRepo := repositories.NewOvertimeRepository(db)
+	remoteWorkRepo := repositories.NewRemoteWorkRepository(db)
+	timesheetRepo := repositories.NewTimesheetRepository(db)
+	kioskRepo := repositories.NewKioskRepository(db)
+
+	// Uploaded files live on the local filesystem
+	fileStorage := storage.NewLocalStorage(cfg.Storage.UploadDir)
 
 	// Initialize services
 	authService := services.NewAuthService(userRepo, cfg)
-	employeeService := services.NewEmployeeService(employeeRepo, userRepo, db)
-	deptService := services.NewDepartmentService(deptRepo)
-	attendanceService := services.NewAttendanceService(attendanceRepo, employeeRepo)
-	leaveService := services.NewLeaveService(leaveRepo, employeeRepo)
-	payrollService := services.NewPayrollService(payrollRepo, employeeRepo, db)
+	customFieldService := services.NewCustomFieldService(customFieldRepo)
+	notificationService := services.NewNotificationService(notificationRepo, userRepo)
+	onboardingService := services.NewOnboardingService(onboardingRepo, employeeRepo, notificationService, cfg)
+	timezoneService := services.NewTimezoneService(locationRepo, employeeRepo, defaultZone)
+	employeeService := services.NewEmployeeService(employeeRepo, userRepo, attendanceRepo, leaveRepo, customFieldService, onboardingService, timezoneService, db)
+	profileChangeService := services.NewProfileChangeService(profileChangeRepo, employeeRepo, employeeService, notificationService)
+	deptService := services.NewDepartmentService(deptRepo, employeeRepo)
+	holidayService := services.NewHolidayService(holidayRepo, locationRepo, employeeRepo, cfg)
+	locationService := services.NewWorkLocationService(locationRepo, holidayRepo)
+	shiftService := services.NewShiftService(shiftRepo, employeeRepo, holidayService)
+	attendanceService := services.NewAttendanceService(attendanceRepo, employeeRepo, leaveRepo, locationRepo, overtimeRepo, remoteWorkRepo, shiftService, timezoneService, fileStorage, cfg)
+	overtimeService := services.NewOvertimeService(overtimeRepo, attendanceRepo, employeeRepo, attendanceService, shiftService, notificationService, timezoneService, cfg)
+	remoteWorkService := services.NewRemoteWorkService(remoteWorkRepo, employeeRepo, attendanceService, shiftService, notificationService, timezoneService)
+	kioskService := services.NewKioskService(kioskRepo, employeeRepo, locationRepo, attendanceService, cfg)
+	timesheetService := services.NewTimesheetService(timesheetRepo, attendanceRepo, employeeRepo, notificationService, timezoneService, cfg)
+	correctionService := services.NewAttendanceCorrectionService(correctionRepo, attendanceRepo, employeeRepo, attendanceService, notificationService, timezoneService, fileStorage, cfg)
+	deviceService := services.NewDeviceService(deviceRepo, employeeRepo, locationRepo, attendanceService, shiftService, timezoneService, cfg)
+	leaveService := services.NewLeaveService(leaveRepo, employeeRepo, shiftService)
+	payrollService := services.NewPayrollService(payrollRepo, employeeRepo, overtimeService, db)
+	employmentService := services.NewEmploymentService(employmentRepo, employeeRepo, notificationService, cfg, db)
+	terminationService := services.NewTerminationService(terminationRepo, employeeRepo, assetRepo, payrollRepo, leaveService, overtimeService, notificationService, db)
+	privacyService := services.NewPrivacyService(employeeRepo, fileStorage, cfg, db)
+	trashService := services.NewTrashService(trashRepo, employeeRepo, deptRepo, fileStorage, cfg)
+	competencyService := services.NewCompetencyService(competencyRepo, employeeRepo, notificationService, fileStorage, cfg)
+	assetService := services.NewAssetService(assetRepo, employeeRepo, terminationRepo, db)
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authService)
@@ -86,6 +151,40 @@ func main() {
 	attendanceHandler := handlers.NewAttendanceHandler(attendanceService)
 	leaveHandler := handlers.NewLeaveHandler(leaveService)
 	payrollHandler := handlers.NewPayrollHandler(payrollService)
+	customFieldHandler := handlers.NewCustomFieldHandler(customFieldService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	onboardingHandler := handlers.NewOnboardingHandler(onboardingService)
+	terminationHandler := handlers.NewTerminationHandler(terminationService)
+	employmentHandler := handlers.NewEmploymentHandler(employmentService)
+	profileChangeHandler := handlers.NewProfileChangeHandler(profileChangeService)
+	privacyHandler := handlers.NewPrivacyHandler(privacyService)
+	trashHandler := handlers.NewTrashHandler(trashService)
+	competencyHandler := handlers.NewCompetencyHandler(competencyService)
+	assetHandler := handlers.NewAssetHandler(assetService)
+	shiftHandler := handlers.NewShiftHandler(shiftService)
+	holidayHandler := handlers.NewHolidayHandler(holidayService)
+	locationHandler := handlers.NewWorkLocationHandler(locationService)
+	deviceHandler := handlers.NewDeviceHandler(deviceService)
+	correctionHandler := handlers.NewAttendanceCorrectionHandler(correctionService)
+	overtimeHandler := handlers.NewOvertimeHandler(overtimeService)
+	remoteWorkHandler := handlers.NewRemoteWorkHandler(remoteWorkService)
+	timesheetHandler := handlers.NewTimesheetHandler(timesheetService)
+	kioskHandler := handlers.NewKioskHandler(kioskService)
+
+	// Background jobs start once migrations have finished
+	jobs := scheduler.New()
+	jobs.Every("onboarding-reminders", cfg.Scheduler.Interval, onboardingService.SendReminders)
+	jobs.Every("due-terminations", cfg.Scheduler.Interval, terminationService.ProcessDueTerminations)
+	jobs.Every("employment-reminders", cfg.Scheduler.Interval, employmentService.SendReminders)
+	jobs.Every("trash-purge", cfg.Scheduler.Interval, trashService.PurgeExpired)
+	jobs.Every("certification-reminders", cfg.Scheduler.Interval, competencyService.SendExpiryReminders)
+	jobs.Every("absence-marking", cfg.Scheduler.Interval, attendanceService.MarkAbsences)
+	if cfg.Scheduler.Enabled {
+		go func() {
+			<-migrated
+			jobs.Start()
+		}()
+	}
 
 	// Setup Gin router
 	gin.SetMode(cfg.Server.GinMode)
@@ -111,6 +210,14 @@ func main() {
 			auth.POST("/masuk", authHandler.Login)
 		}
 
+		// Kiosk screens sign in with their own credential
+		kioskTerminal := v1.Group("/terminal-kiosk")
+		kioskTerminal.Use(middleware.KioskMiddleware(kioskService.Authenticate))
+		{
+			kioskTerminal.GET("/kode-qr", kioskHandler.GetQRCode)
+			kioskTerminal.POST("/pin", kioskHandler.PinPunch)
+		}
+
 		// Protected routes
 		protected := v1.Group("")
 		protected.Use(middleware.AuthMiddleware())
@@ -133,15 +240,136 @@ func main() {
 				departments.DELETE("/:id", middleware.RoleMiddleware("admin"), deptHandler.DeleteDepartment)
 			}
 
+			// Custom field routes
+			customFields := protected.Group("/kolom-kustom")
+			{
+				customFields.POST("", middleware.RoleMiddleware("admin"), customFieldHandler.CreateDefinition)
+				customFields.GET("", customFieldHandler.GetDefinitions)
+				customFields.PUT("/:id", middleware.RoleMiddleware("admin"), customFieldHandler.UpdateDefinition)
+				customFields.DELETE("/:id", middleware.RoleMiddleware("admin"), customFieldHandler.DeleteDefinition)
+			}
+
 			// Employee routes
 			employees := protected.Group("/karyawan")
 			{
 				employees.POST("", middleware.RoleMiddleware("admin", "hr_manager"), employeeHandler.CreateEmployee)
 				employees.GET("", employeeHandler.GetEmployees)
+				employees.GET("/cari", employeeHandler.SuggestEmployees)
 				employees.GET("/buat-kode", middleware.RoleMiddleware("admin", "hr_manager"), employeeHandler.GenerateEmployeeCode)
+				employees.GET("/ekspor", middleware.RoleMiddleware("admin", "hr_manager"), employeeHandler.ExportEmployees)
+				employees.GET("/berakhir", middleware.RoleMiddleware("admin", "hr_manager"), employmentHandler.GetExpiring)
 				employees.GET("/:id", employeeHandler.GetEmployeeByID)
 				employees.PUT("/:id", middleware.RoleMiddleware("admin", "hr_manager"), employeeHandler.UpdateEmployee)
 				employees.DELETE("/:id", middleware.RoleMiddleware("admin"), employeeHandler.DeleteEmployee)
+				employees.GET("/:id/orientasi", onboardingHandler.GetEmployeeOnboarding)
+				employees.POST("/:id/orientasi", middleware.RoleMiddleware("admin", "hr_manager"), onboardingHandler.StartOnboarding)
+				employees.POST("/:id/orientasi/tugas", middleware.RoleMiddleware("admin", "hr_manager"), onboardingHandler.AddTask)
+				employees.POST("/:id/pemberhentian", middleware.RoleMiddleware("admin", "hr_manager"), terminationHandler.TerminateEmployee)
+				employees.PUT("/:id/status", middleware.RoleMiddleware("admin", "hr_manager"), employmentHandler.ChangeStatus)
+				employees.GET("/:id/riwayat-status", middleware.RoleMiddleware("admin", "hr_manager"), employmentHandler.GetStatusHistory)
+				employees.PUT("/:id/masa-percobaan", middleware.RoleMiddleware("admin", "hr_manager"), employmentHandler.ExtendProbation)
+				employees.GET("/:id/kontrak", middleware.RoleMiddleware("admin", "hr_manager"), employmentHandler.GetContractRenewals)
+				employees.POST("/:id/kontrak/perpanjang", middleware.RoleMiddleware("admin", "hr_manager"), employmentHandler.RenewContract)
+				employees.GET("/:id/data-pribadi", middleware.RoleMiddleware("admin"), privacyHandler.ExportPersonalData)
+				employees.GET("/:id/keahlian", competencyHandler.GetSkills)
+				employees.POST("/:id/keahlian", middleware.RoleMiddleware("admin", "hr_manager"), competencyHandler.AddSkill)
+				employees.PUT("/:id/keahlian/:skill_id", middleware.RoleMiddleware("admin", "hr_manager"), competencyHandler.UpdateSkill)
+				employees.DELETE("/:id/keahlian/:skill_id", middleware.RoleMiddleware("admin", "hr_manager"), competencyHandler.DeleteSkill)
+				employees.GET("/:id/sertifikasi", competencyHandler.GetEmployeeCertifications)
+				employees.POST("/:id/sertifikasi", middleware.RoleMiddleware("admin", "hr_manager"), competencyHandler.AddCertification)
+				employees.GET("/:id/aset", middleware.RoleMiddleware("admin", "hr_manager"), assetHandler.GetEmployeeAssets)
+			}
+
+			// Self-service profile change routes
+			myProfile := protected.Group("/profil-saya")
+			{
+				myProfile.GET("/perubahan", profileChangeHandler.GetMyRequests)
+				myProfile.POST("/perubahan", profileChangeHandler.SubmitChange)
+				myProfile.DELETE("/perubahan/:id", profileChangeHandler.CancelRequest)
+			}
+
+			// Profile change review routes
+			profileChanges := protected.Group("/perubahan-profil")
+			profileChanges.Use(middleware.RoleMiddleware("admin", "hr_manager"))
+			{
+				profileChanges.GET("", profileChangeHandler.GetRequests)
+				profileChanges.GET("/:id", profileChangeHandler.GetRequestByID)
+				profileChanges.PUT("/:id/setujui", profileChangeHandler.ApproveRequest)
+				profileChanges.PUT("/:id/tolak", profileChangeHandler.RejectRequest)
+			}
+
+			// Skill search routes
+			protected.GET("/keahlian", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), competencyHandler.SearchSkills)
+
+			// Certification routes
+			certifications := protected.Group("/sertifikasi")
+			certifications.Use(middleware.RoleMiddleware("admin", "hr_manager", "department_manager"))
+			{
+				certifications.GET("", competencyHandler.SearchCertifications)
+				certifications.GET("/:id", competencyHandler.GetCertificationByID)
+				certifications.PUT("/:id", middleware.RoleMiddleware("admin", "hr_manager"), competencyHandler.UpdateCertification)
+				certifications.DELETE("/:id", middleware.RoleMiddleware("admin", "hr_manager"), competencyHandler.DeleteCertification)
+				certifications.POST("/:id/berkas", middleware.RoleMiddleware("admin", "hr_manager"), competencyHandler.UploadFile)
+				certifications.GET("/:id/berkas/:file_id", competencyHandler.DownloadFile)
+				certifications.DELETE("/:id/berkas/:file_id", middleware.RoleMiddleware("admin", "hr_manager"), competencyHandler.DeleteFile)
+			}
+
+			// Asset routes
+			assets := protected.Group("/aset")
+			assets.Use(middleware.RoleMiddleware("admin", "hr_manager"))
+			{
+				assets.POST("", assetHandler.CreateAsset)
+				assets.GET("", assetHandler.GetAssets)
+				assets.GET("/karyawan-nonaktif", assetHandler.GetAssetsHeldByInactive)
+				assets.GET("/:id", assetHandler.GetAssetByID)
+				assets.PUT("/:id", assetHandler.UpdateAsset)
+				assets.GET("/:id/riwayat", assetHandler.GetAssetHistory)
+				assets.POST("/:id/serahkan", assetHandler.AssignAsset)
+				assets.POST("/:id/kembalikan", assetHandler.ReturnAsset)
+			}
+
+			// Trash routes for soft-deleted employees, departments and leaves
+			trash := protected.Group("/sampah")
+			trash.Use(middleware.RoleMiddleware("admin"))
+			{
+				trash.GET("/:entity", trashHandler.GetTrash)
+				trash.POST("/:entity/:id/pulihkan", trashHandler.Restore)
+				trash.DELETE("/:entity/:id", trashHandler.Purge)
+			}
+
+			// Termination routes
+			terminations := protected.Group("/pemberhentian")
+			terminations.Use(middleware.RoleMiddleware("admin", "hr_manager"))
+			{
+				terminations.GET("", terminationHandler.GetTerminations)
+				terminations.GET("/aturan-pesangon", terminationHandler.GetSeveranceRules)
+				terminations.PUT("/aturan-pesangon/:type", terminationHandler.UpdateSeveranceRule)
+				terminations.GET("/:id", terminationHandler.GetTerminationByID)
+				terminations.POST("/:id/hitung-ulang", terminationHandler.RecalculateSettlement)
+				terminations.POST("/:id/batal", terminationHandler.CancelTermination)
+				terminations.PUT("/:id/daftar-periksa/:item_id", terminationHandler.CompleteChecklistItem)
+			}
+
+			// Onboarding routes
+			onboarding := protected.Group("/orientasi")
+			{
+				onboarding.POST("/templat", middleware.RoleMiddleware("admin", "hr_manager"), onboardingHandler.CreateTemplate)
+				onboarding.GET("/templat", middleware.RoleMiddleware("admin", "hr_manager"), onboardingHandler.GetTemplates)
+				onboarding.GET("/templat/:id", middleware.RoleMiddleware("admin", "hr_manager"), onboardingHandler.GetTemplateByID)
+				onboarding.PUT("/templat/:id", middleware.RoleMiddleware("admin", "hr_manager"), onboardingHandler.UpdateTemplate)
+				onboarding.DELETE("/templat/:id", middleware.RoleMiddleware("admin", "hr_manager"), onboardingHandler.DeleteTemplate)
+				onboarding.GET("/tugas-saya", onboardingHandler.GetMyTasks)
+				onboarding.PUT("/tugas/:id/selesai", onboardingHandler.CompleteTask)
+				onboarding.DELETE("/tugas/:id", middleware.RoleMiddleware("admin", "hr_manager"), onboardingHandler.DeleteTask)
+				onboarding.GET("/terlambat", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), onboardingHandler.GetOverdueDashboard)
+			}
+
+			// Notification routes
+			notifications := protected.Group("/notifikasi")
+			{
+				notifications.GET("", notificationHandler.GetNotifications)
+				notifications.PUT("/baca-semua", notificationHandler.MarkAllRead)
+				notifications.PUT("/:id/baca", notificationHandler.MarkRead)
 			}
 
 			// Attendance routes
@@ -149,9 +377,173 @@ func main() {
 			{
 				attendance.POST("/absen-masuk", attendanceHandler.ClockIn)
 				attendance.POST("/absen-keluar", attendanceHandler.ClockOut)
+				attendance.POST("/mulai-istirahat", attendanceHandler.StartBreak)
+				attendance.POST("/selesai-istirahat", attendanceHandler.EndBreak)
 				attendance.GET("", attendanceHandler.GetAttendance)
 				attendance.GET("/laporan", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), attendanceHandler.GetAttendanceReport)
+				attendance.GET("/anomali", middleware.RoleMiddleware("admin", "hr_manager"), attendanceHandler.GetAnomalies)
 				attendance.POST("/manual", middleware.RoleMiddleware("admin", "hr_manager"), attendanceHandler.CreateManualAttendance)
+				attendance.POST("/tandai-absen", middleware.RoleMiddleware("admin", "hr_manager"), attendanceHandler.MarkAbsences)
+				attendance.GET("/tinjauan", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), attendanceHandler.GetPendingReviews)
+				attendance.PUT("/:id/tinjau", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), attendanceHandler.ReviewAttendance)
+				attendance.GET("/:id/foto/:punch", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), attendanceHandler.GetPhoto)
+				attendance.GET("/:id/riwayat", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), attendanceHandler.GetPunchEvents)
+			}
+
+			// Attendance correction routes
+			corrections := protected.Group("/koreksi-kehadiran")
+			{
+				corrections.POST("", correctionHandler.SubmitCorrection)
+				corrections.GET("/saya", correctionHandler.GetMyCorrections)
+				corrections.DELETE("/:id", correctionHandler.CancelCorrection)
+				corrections.GET("", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), correctionHandler.GetCorrections)
+				corrections.GET("/:id", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), correctionHandler.GetCorrectionByID)
+				corrections.GET("/:id/bukti", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), correctionHandler.GetEvidence)
+				corrections.PUT("/:id/setujui", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), correctionHandler.ApproveCorrection)
+				corrections.PUT("/:id/tolak", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), correctionHandler.RejectCorrection)
+			}
+
+			// Overtime routes
+			overtime := protected.Group("/lembur")
+			{
+				overtime.POST("", overtimeHandler.SubmitRequest)
+				overtime.GET("/saya", overtimeHandler.GetMyRequests)
+				overtime.DELETE("/:id", overtimeHandler.CancelRequest)
+				overtime.GET("", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), overtimeHandler.GetRequests)
+				overtime.GET("/rekap", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), overtimeHandler.GetOvertimeSummary)
+				overtime.GET("/:id", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), overtimeHandler.GetRequestByID)
+				overtime.PUT("/:id/setujui", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), overtimeHandler.ApproveRequest)
+				overtime.PUT("/:id/tolak", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), overtimeHandler.RejectRequest)
+			}
+
+			// Remote work routes
+			remoteWork := protected.Group("/kerja-jarak-jauh")
+			{
+				remoteWork.POST("", remoteWorkHandler.SubmitRequest)
+				remoteWork.GET("/saya", remoteWorkHandler.GetMyRequests)
+				remoteWork.GET("/kalender", remoteWorkHandler.GetCalendar)
+				remoteWork.GET("/kebijakan", remoteWorkHandler.GetPolicies)
+				remoteWork.POST("/kebijakan", middleware.RoleMiddleware("admin", "hr_manager"), remoteWorkHandler.CreatePolicy)
+				remoteWork.PUT("/kebijakan/:id", middleware.RoleMiddleware("admin", "hr_manager"), remoteWorkHandler.UpdatePolicy)
+				remoteWork.DELETE("/kebijakan/:id", middleware.RoleMiddleware("admin", "hr_manager"), remoteWorkHandler.DeletePolicy)
+				remoteWork.DELETE("/:id", remoteWorkHandler.CancelRequest)
+				remoteWork.GET("", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), remoteWorkHandler.GetRequests)
+				remoteWork.GET("/:id", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), remoteWorkHandler.GetRequestByID)
+				remoteWork.PUT("/:id/setujui", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), remoteWorkHandler.ApproveRequest)
+				remoteWork.PUT("/:id/tolak", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), remoteWorkHandler.RejectRequest)
+			}
+
+			// Kiosk routes
+			kiosks := protected.Group("/kiosk")
+			{
+				kiosks.POST("/pindai", kioskHandler.Scan)
+				kiosks.PUT("/pin", kioskHandler.SetPin)
+				kiosks.GET("", middleware.RoleMiddleware("admin", "hr_manager"), kioskHandler.GetKiosks)
+				kiosks.GET("/:id", middleware.RoleMiddleware("admin", "hr_manager"), kioskHandler.GetKioskByID)
+				kiosks.POST("", middleware.RoleMiddleware("admin", "hr_manager"), kioskHandler.CreateKiosk)
+				kiosks.PUT("/:id", middleware.RoleMiddleware("admin", "hr_manager"), kioskHandler.UpdateKiosk)
+				kiosks.DELETE("/:id", middleware.RoleMiddleware("admin", "hr_manager"), kioskHandler.DeleteKiosk)
+				kiosks.POST("/:id/kredensial", middleware.RoleMiddleware("admin", "hr_manager"), kioskHandler.RotateCredential)
+			}
+
+			// Project routes
+			projects := protected.Group("/proyek")
+			{
+				projects.GET("", timesheetHandler.GetProjects)
+				projects.GET("/:id", timesheetHandler.GetProjectByID)
+				projects.POST("", middleware.RoleMiddleware("admin", "hr_manager"), timesheetHandler.CreateProject)
+				projects.PUT("/:id", middleware.RoleMiddleware("admin", "hr_manager"), timesheetHandler.UpdateProject)
+				projects.DELETE("/:id", middleware.RoleMiddleware("admin", "hr_manager"), timesheetHandler.DeleteProject)
+				projects.POST("/:id/tugas", middleware.RoleMiddleware("admin", "hr_manager"), timesheetHandler.AddTask)
+				projects.PUT("/:id/tugas/:task_id", middleware.RoleMiddleware("admin", "hr_manager"), timesheetHandler.UpdateTask)
+				projects.DELETE("/:id/tugas/:task_id", middleware.RoleMiddleware("admin", "hr_manager"), timesheetHandler.DeleteTask)
+			}
+
+			// Timesheet routes
+			timesheets := protected.Group("/timesheet")
+			{
+				timesheets.POST("/entri", timesheetHandler.AddEntry)
+				timesheets.PUT("/entri/:id", timesheetHandler.UpdateEntry)
+				timesheets.DELETE("/entri/:id", timesheetHandler.DeleteEntry)
+				timesheets.GET("/saya", timesheetHandler.GetMyTimesheets)
+				timesheets.GET("/saya/:id", timesheetHandler.GetMyTimesheet)
+				timesheets.PUT("/saya/:id/ajukan", timesheetHandler.SubmitTimesheet)
+				timesheets.GET("", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), timesheetHandler.GetTimesheets)
+				timesheets.GET("/laporan/utilisasi", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), timesheetHandler.GetUtilizationReport)
+				timesheets.GET("/laporan/proyek", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), timesheetHandler.GetProjectReport)
+				timesheets.GET("/:id", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), timesheetHandler.GetTimesheetByID)
+				timesheets.PUT("/:id/setujui", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), timesheetHandler.ApproveTimesheet)
+				timesheets.PUT("/:id/tolak", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), timesheetHandler.RejectTimesheet)
+			}
+
+			// Shift routes
+			shifts := protected.Group("/shift")
+			{
+				shifts.GET("", shiftHandler.GetShifts)
+				shifts.POST("", middleware.RoleMiddleware("admin", "hr_manager"), shiftHandler.CreateShift)
+				shifts.GET("/pola", shiftHandler.GetPatterns)
+				shifts.POST("/pola", middleware.RoleMiddleware("admin", "hr_manager"), shiftHandler.CreatePattern)
+				shifts.GET("/pola/:id", shiftHandler.GetPatternByID)
+				shifts.PUT("/pola/:id", middleware.RoleMiddleware("admin", "hr_manager"), shiftHandler.UpdatePattern)
+				shifts.DELETE("/pola/:id", middleware.RoleMiddleware("admin", "hr_manager"), shiftHandler.DeletePattern)
+				shifts.GET("/:id", shiftHandler.GetShiftByID)
+				shifts.PUT("/:id", middleware.RoleMiddleware("admin", "hr_manager"), shiftHandler.UpdateShift)
+				shifts.DELETE("/:id", middleware.RoleMiddleware("admin", "hr_manager"), shiftHandler.DeleteShift)
+			}
+
+			// Schedule routes
+			schedules := protected.Group("/jadwal")
+			{
+				schedules.GET("", middleware.RoleMiddleware("admin", "hr_manager", "department_manager"), shiftHandler.GetRoster)
+				schedules.GET("/saya", shiftHandler.GetMySchedule)
+				schedules.PUT("", middleware.RoleMiddleware("admin", "hr_manager"), shiftHandler.SetRoster)
+				schedules.POST("/pola", middleware.RoleMiddleware("admin", "hr_manager"), shiftHandler.ApplyPattern)
+				schedules.DELETE("/:id", middleware.RoleMiddleware("admin", "hr_manager"), shiftHandler.DeleteRosterEntry)
+			}
+
+			// Holiday calendar routes
+			calendars := protected.Group("/kalender-libur")
+			{
+				calendars.GET("", holidayHandler.GetCalendars)
+				calendars.POST("", middleware.RoleMiddleware("admin", "hr_manager"), holidayHandler.CreateCalendar)
+				calendars.GET("/:id", holidayHandler.GetCalendarByID)
+				calendars.PUT("/:id", middleware.RoleMiddleware("admin", "hr_manager"), holidayHandler.UpdateCalendar)
+				calendars.DELETE("/:id", middleware.RoleMiddleware("admin", "hr_manager"), holidayHandler.DeleteCalendar)
+				calendars.GET("/:id/hari-libur", holidayHandler.GetHolidays)
+				calendars.POST("/:id/hari-libur", middleware.RoleMiddleware("admin", "hr_manager"), holidayHandler.CreateHoliday)
+				calendars.PUT("/:id/hari-libur/:holiday_id", middleware.RoleMiddleware("admin", "hr_manager"), holidayHandler.UpdateHoliday)
+				calendars.DELETE("/:id/hari-libur/:holiday_id", middleware.RoleMiddleware("admin", "hr_manager"), holidayHandler.DeleteHoliday)
+				calendars.POST("/:id/impor", middleware.RoleMiddleware("admin", "hr_manager"), holidayHandler.ImportICalendar)
+			}
+			protected.GET("/hari-libur", holidayHandler.GetHolidaysInRange)
+
+			// Work location routes
+			locations := protected.Group("/lokasi-kerja")
+			{
+				locations.GET("", locationHandler.GetLocations)
+				locations.POST("", middleware.RoleMiddleware("admin", "hr_manager"), locationHandler.CreateLocation)
+				locations.GET("/:id", locationHandler.GetLocationByID)
+				locations.PUT("/:id", middleware.RoleMiddleware("admin", "hr_manager"), locationHandler.UpdateLocation)
+				locations.DELETE("/:id", middleware.RoleMiddleware("admin", "hr_manager"), locationHandler.DeleteLocation)
+				locations.GET("/:id/geofence", locationHandler.GetGeofences)
+				locations.POST("/:id/geofence", middleware.RoleMiddleware("admin", "hr_manager"), locationHandler.CreateGeofence)
+				locations.PUT("/:id/geofence/:geofence_id", middleware.RoleMiddleware("admin", "hr_manager"), locationHandler.UpdateGeofence)
+				locations.DELETE("/:id/geofence/:geofence_id", middleware.RoleMiddleware("admin", "hr_manager"), locationHandler.DeleteGeofence)
+			}
+
+			// Attendance device routes
+			devices := protected.Group("/perangkat-absensi")
+			{
+				devices.GET("", middleware.RoleMiddleware("admin", "hr_manager"), deviceHandler.GetDevices)
+				devices.POST("", middleware.RoleMiddleware("admin", "hr_manager"), deviceHandler.CreateDevice)
+				devices.GET("/:id", middleware.RoleMiddleware("admin", "hr_manager"), deviceHandler.GetDeviceByID)
+				devices.PUT("/:id", middleware.RoleMiddleware("admin", "hr_manager"), deviceHandler.UpdateDevice)
+				devices.DELETE("/:id", middleware.RoleMiddleware("admin", "hr_manager"), deviceHandler.DeleteDevice)
+				devices.GET("/:id/pengguna", middleware.RoleMiddleware("admin", "hr_manager"), deviceHandler.GetDeviceUsers)
+				devices.PUT("/:id/pengguna", middleware.RoleMiddleware("admin", "hr_manager"), deviceHandler.SetDeviceUsers)
+				devices.DELETE("/:id/pengguna/:user_id", middleware.RoleMiddleware("admin", "hr_manager"), deviceHandler.DeleteDeviceUser)
+				devices.GET("/:id/impor", middleware.RoleMiddleware("admin", "hr_manager"), deviceHandler.GetImports)
+				devices.POST("/:id/impor", middleware.RoleMiddleware("admin", "hr_manager"), deviceHandler.ImportPunchLog)
 			}
 
 			// Leave routes
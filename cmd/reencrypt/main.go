@@ -0,0 +1,111 @@
+// Command reencrypt rewrites the encrypted employee fields with the current key
+// version and rebuilds their blind indexes. Run it after adding a new key to
+// ENCRYPTION_KEYS or changing BLIND_INDEX_KEY, and once after upgrading to
+// encrypt values stored as plaintext. The README describes how to move data off
+// the keys earlier versions derived from JWT_SECRET.
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"log"
+
+	"hr-backend/internal/config"
+	"hr-backend/internal/database"
+	"hr-backend/internal/fieldcrypt"
+	"hr-backend/internal/models"
+)
+
+// encryptedColumns are the employee columns sealed with fieldcrypt
+var encryptedColumns = []string{"date_of_birth", "phone", "address", "national_id", "salary", "bank_account_number"}
+
+type storedEmployee struct {
+	ID                uint
+	DateOfBirth       sql.NullString
+	Phone             sql.NullString
+	Address           sql.NullString
+	NationalID        sql.NullString
+	Salary            sql.NullString
+	BankAccountNumber sql.NullString
+}
+
+func (e storedEmployee) needsReencryption() bool {
+	for _, value := range []sql.NullString{e.DateOfBirth, e.Phone, e.Address, e.NationalID, e.Salary, e.BankAccountNumber} {
+		if value.Valid && fieldcrypt.NeedsReencryption(value.String) {
+			return true
+		}
+	}
+	return false
+}
+
+func main() {
+	batchSize := flag.Int("batch", 200, "Number of employees to process per batch")
+	dryRun := flag.Bool("dry-run", false, "Only count the employees that need re-encryption")
+	flag.Parse()
+
+	cfg := config.Load()
+
+	if err := fieldcrypt.Init(cfg.Encryption.Keys, cfg.Encryption.BlindIndexKey); err != nil {
+		log.Fatalf("Failed to initialize field encryption: %v", err)
+	}
+
+	if err := database.Connect(&cfg.Database); err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	// Converts the encrypted columns to text on the first run
+	if err := database.Migrate(); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	db := database.GetDB()
+	log.Printf("Re-encrypting employee fields with key version %d", fieldcrypt.CurrentVersion())
+
+	var scanned, updated int
+	var lastID uint
+	for {
+		var batch []storedEmployee
+		err := db.Table("employees").
+			Select("id", "date_of_birth", "phone", "address", "national_id", "salary", "bank_account_number").
+			Where("id > ?", lastID).
+			Order("id ASC").
+			Limit(*batchSize).
+			Scan(&batch).Error
+		if err != nil {
+			log.Fatalf("Failed to read employees: %v", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+		lastID = batch[len(batch)-1].ID
+		scanned += len(batch)
+
+		for _, stored := range batch {
+			if !stored.needsReencryption() {
+				continue
+			}
+			updated++
+			if *dryRun {
+				continue
+			}
+
+			var employee models.Employee
+			if err := db.Unscoped().First(&employee, stored.ID).Error; err != nil {
+				log.Fatalf("Failed to load employee %d: %v", stored.ID, err)
+			}
+
+			// UpdateColumns leaves updated_at alone; the values are unchanged, only re-sealed
+			employee.RefreshBlindIndexes()
+			columns := append(append([]string{}, encryptedColumns...), "phone_index", "national_id_index")
+			if err := db.Unscoped().Model(&employee).Select(columns).UpdateColumns(&employee).Error; err != nil {
+				log.Fatalf("Failed to re-encrypt employee %d: %v", stored.ID, err)
+			}
+		}
+	}
+
+	if *dryRun {
+		log.Printf("%d of %d employees need re-encryption", updated, scanned)
+		return
+	}
+	log.Printf("Re-encrypted %d of %d employees", updated, scanned)
+}
@@ -4,18 +4,32 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Redis    RedisConfig
-	Server   ServerConfig
-	AWS      AWSConfig
-	CORS     CORSConfig
+	Database      DatabaseConfig
+	JWT           JWTConfig
+	Redis         RedisConfig
+	Server        ServerConfig
+	AWS           AWSConfig
+	CORS          CORSConfig
+	Scheduler     SchedulerConfig
+	Onboarding    OnboardingConfig
+	Employment    EmploymentConfig
+	Encryption    EncryptionConfig
+	Privacy       PrivacyConfig
+	Trash         TrashConfig
+	Storage       StorageConfig
+	Certification CertificationConfig
+	Attendance    AttendanceConfig
+	Overtime      OvertimeConfig
+	Timezone      TimezoneConfig
+	Timesheet     TimesheetConfig
+	Kiosk         KioskConfig
 }
 
 type DatabaseConfig struct {
@@ -54,6 +68,87 @@ type CORSConfig struct {
 	AllowedOrigins string
 }
 
+type SchedulerConfig struct {
+	Enabled  bool
+	Interval time.Duration
+}
+
+type OnboardingConfig struct {
+	ReminderDays int
+}
+
+// EncryptionConfig holds the keyring for field encryption ("1:<base64>,2:<base64>",
+// highest version encrypts) and the key for blind indexes, which must never change.
+// DevelopmentKeys opts a local setup into fixed, publicly known keys instead.
+type EncryptionConfig struct {
+	Keys            string
+	BlindIndexKey   string
+	DevelopmentKeys bool
+}
+
+// PrivacyConfig sets how long personal data of former employees is kept before anonymization
+type PrivacyConfig struct {
+	RetentionYears int
+}
+
+// StorageConfig sets where uploaded files are kept and how large they may be
+type StorageConfig struct {
+	UploadDir       string
+	MaxUploadSizeMB int
+}
+
+type CertificationConfig struct {
+	ReminderDays int
+}
+
+// OvertimeConfig sets how overtime is paid. WorkWeekDays (5 or 6) picks the rest
+// day and holiday multiplier tiers; the hourly wage is the monthly salary divided
+// by HourlyDivisor, which is 173 under Indonesian regulation.
+type OvertimeConfig struct {
+	WorkWeekDays  int
+	HourlyDivisor float64
+}
+
+// TimezoneConfig sets the company time zone (an IANA name such as Asia/Jakarta),
+// used for employees whose work location has no time zone of its own
+type TimezoneConfig struct {
+	Default string
+}
+
+// TimesheetConfig sets how far the hours logged on a day may differ from the
+// attendance working hours before the day is flagged when reconciling
+type TimesheetConfig struct {
+	ToleranceMinutes int
+}
+
+// KioskConfig sets how kiosk QR codes are signed and how long each is shown; a
+// code is also accepted during the interval after it. QRSecret defaults to the
+// JWT secret. A PIN locks for PinLockout after PinMaxAttempts wrong attempts.
+type KioskConfig struct {
+	QRSecret       string
+	QRInterval     time.Duration
+	PinMaxAttempts int
+	PinLockout     time.Duration
+}
+
+// AttendanceConfig sets how clock-in positions are checked. Positions reported
+// with a worse accuracy than GeofenceMaxAccuracyMeters are flagged for review.
+// The end-of-day job goes back AbsenceLookbackDays to catch days it missed.
+type AttendanceConfig struct {
+	GeofenceMaxAccuracyMeters float64
+	AbsenceLookbackDays       int
+}
+
+// TrashConfig sets how long soft-deleted records stay restorable before they are purged
+type TrashConfig struct {
+	RetentionDays int
+}
+
+type EmploymentConfig struct {
+	ProbationReminderDays int
+	ContractReminderDays  int
+}
+
 func Load() *Config {
 	// Load .env file if exists
 	if err := godotenv.Load(); err != nil {
@@ -62,6 +157,10 @@ func Load() *Config {
 
 	jwtExpiry, _ := time.ParseDuration(getEnv("JWT_EXPIRY", "15m"))
 	jwtRefreshExpiry, _ := time.ParseDuration(getEnv("JWT_REFRESH_EXPIRY", "168h"))
+	schedulerInterval, _ := time.ParseDuration(getEnv("SCHEDULER_INTERVAL", "1h"))
+	kioskQRInterval, _ := time.ParseDuration(getEnv("KIOSK_QR_INTERVAL", "30s"))
+	kioskPinLockout, _ := time.ParseDuration(getEnv("KIOSK_PIN_LOCKOUT", "15m"))
+	jwtSecret := getEnv("JWT_SECRET", "your_secret_key")
 
 	return &Config{
 		Database: DatabaseConfig{
@@ -74,7 +173,7 @@ func Load() *Config {
 			URL:      getEnv("DATABASE_URL", ""),
 		},
 		JWT: JWTConfig{
-			Secret:        getEnv("JWT_SECRET", "your_secret_key"),
+			Secret:        jwtSecret,
 			Expiry:        jwtExpiry,
 			RefreshExpiry: jwtRefreshExpiry,
 		},
@@ -94,6 +193,55 @@ func Load() *Config {
 		CORS: CORSConfig{
 			AllowedOrigins: getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:5173,http://localhost:5174"),
 		},
+		Scheduler: SchedulerConfig{
+			Enabled:  getEnvBool("SCHEDULER_ENABLED", true),
+			Interval: schedulerInterval,
+		},
+		Onboarding: OnboardingConfig{
+			ReminderDays: getEnvInt("ONBOARDING_REMINDER_DAYS", 2),
+		},
+		Employment: EmploymentConfig{
+			ProbationReminderDays: getEnvInt("PROBATION_REMINDER_DAYS", 14),
+			ContractReminderDays:  getEnvInt("CONTRACT_REMINDER_DAYS", 30),
+		},
+		Encryption: EncryptionConfig{
+			Keys:            getEnv("ENCRYPTION_KEYS", ""),
+			BlindIndexKey:   getEnv("BLIND_INDEX_KEY", ""),
+			DevelopmentKeys: getEnvBool("ENCRYPTION_DEV_KEYS", false),
+		},
+		Privacy: PrivacyConfig{
+			RetentionYears: getEnvInt("PRIVACY_RETENTION_YEARS", 5),
+		},
+		Trash: TrashConfig{
+			RetentionDays: getEnvInt("TRASH_RETENTION_DAYS", 30),
+		},
+		Storage: StorageConfig{
+			UploadDir:       getEnv("UPLOAD_DIR", "uploads"),
+			MaxUploadSizeMB: getEnvInt("UPLOAD_MAX_SIZE_MB", 10),
+		},
+		Certification: CertificationConfig{
+			ReminderDays: getEnvInt("CERTIFICATION_REMINDER_DAYS", 30),
+		},
+		Attendance: AttendanceConfig{
+			GeofenceMaxAccuracyMeters: float64(getEnvInt("GEOFENCE_MAX_ACCURACY_METERS", 100)),
+			AbsenceLookbackDays:       getEnvInt("ATTENDANCE_ABSENCE_LOOKBACK_DAYS", 3),
+		},
+		Overtime: OvertimeConfig{
+			WorkWeekDays:  getEnvInt("OVERTIME_WORK_WEEK_DAYS", 5),
+			HourlyDivisor: float64(getEnvInt("OVERTIME_HOURLY_DIVISOR", 173)),
+		},
+		Timezone: TimezoneConfig{
+			Default: getEnv("DEFAULT_TIMEZONE", "Asia/Jakarta"),
+		},
+		Timesheet: TimesheetConfig{
+			ToleranceMinutes: getEnvInt("TIMESHEET_TOLERANCE_MINUTES", 15),
+		},
+		Kiosk: KioskConfig{
+			QRSecret:       getEnv("KIOSK_QR_SECRET", jwtSecret),
+			QRInterval:     kioskQRInterval,
+			PinMaxAttempts: getEnvInt("KIOSK_PIN_MAX_ATTEMPTS", 5),
+			PinLockout:     kioskPinLockout,
+		},
 	}
 }
 
@@ -113,3 +261,17 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
@@ -6,6 +6,7 @@ import (
 
 	"hr-backend/internal/config"
 	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -41,28 +42,150 @@ func Migrate() error {
 		&models.Leave{},
 		&models.LeaveBalance{},
 		&models.Payroll{},
+		&models.CustomFieldDefinition{},
+		&models.Notification{},
+		&models.OnboardingTemplate{},
+		&models.OnboardingTemplateTask{},
+		&models.OnboardingTask{},
+		&models.Termination{},
+		&models.ExitChecklistItem{},
+		&models.SeveranceRule{},
+		&models.EmploymentStatusHistory{},
+		&models.ContractRenewal{},
+		&models.EmployeeDependent{},
+		&models.ProfileChangeRequest{},
+		&models.EmployeeSkill{},
+		&models.Certification{},
+		&models.CertificationFile{},
+		&models.Asset{},
+		&models.AssetAssignment{},
+		&models.Shift{},
+		&models.ShiftPattern{},
+		&models.ShiftPatternSlot{},
+		&models.RosterEntry{},
+		&models.HolidayCalendar{},
+		&models.Holiday{},
+		&models.WorkLocation{},
+		&models.Geofence{},
+		&models.AttendanceDevice{},
+		&models.DeviceUser{},
+		&models.DeviceImport{},
+		&models.PunchEvent{},
+		&models.AttendanceCorrection{},
+		&models.OvertimeRequest{},
+		&models.RemoteWorkPolicy{},
+		&models.RemoteWorkRequest{},
+		&models.Project{},
+		&models.ProjectTask{},
+		&models.Timesheet{},
+		&models.TimesheetEntry{},
+		&models.Kiosk{},
+		&models.KioskPin{},
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	if err := migrateEmployeeSearch(); err != nil {
+		return fmt.Errorf("failed to migrate employee search: %w", err)
+	}
+
+	if err := backfillPunchEvents(); err != nil {
+		return fmt.Errorf("failed to backfill punch events: %w", err)
+	}
+
 	log.Println("Database migrated successfully")
 	return nil
 }
 
+// migrateEmployeeSearch installs pg_trgm, builds the search indexes and
+// backfills the denormalized search document of every employee
+func migrateEmployeeSearch() error {
+	statements := []string{
+		"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+		"CREATE INDEX IF NOT EXISTS idx_employees_search_text_trgm ON employees USING gin (search_text gin_trgm_ops)",
+		"CREATE INDEX IF NOT EXISTS idx_employees_search_text_fts ON employees USING gin (to_tsvector('simple', coalesce(search_text, '')))",
+	}
+
+	for _, statement := range statements {
+		if err := DB.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	return repositories.NewEmployeeRepository(DB).RefreshAllSearchText()
+}
+
+// backfillPunchEvents turns the clock times of attendance recorded before the
+// punch log existed into events, so deriving those days again keeps their times
+func backfillPunchEvents() error {
+	return DB.Exec(`
+		INSERT INTO punch_events (created_at, employee_id, work_date, type, punched_at, source,
+			latitude, longitude, accuracy, geofence, photo, notes)
+		SELECT NOW(), a.employee_id, a.date, p.type, p.punched_at, COALESCE(NULLIF(a.source, ''), 'app'),
+			p.latitude, p.longitude, p.accuracy, COALESCE(p.geofence, ''), COALESCE(p.photo, ''), ''
+		FROM attendances a
+		CROSS JOIN LATERAL (VALUES
+			('in', a.clock_in, a.clock_in_latitude, a.clock_in_longitude, a.clock_in_accuracy, a.clock_in_geofence, a.clock_in_photo),
+			('out', a.clock_out, a.clock_out_latitude, a.clock_out_longitude, a.clock_out_accuracy, a.clock_out_geofence, a.clock_out_photo)
+		) AS p(type, punched_at, latitude, longitude, accuracy, geofence, photo)
+		WHERE a.deleted_at IS NULL AND p.punched_at IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM punch_events e WHERE e.employee_id = a.employee_id AND e.work_date = a.date)
+		ON CONFLICT DO NOTHING`).Error
+}
+
 // Reset drops all tables and recreates them
 func Reset() error {
 	log.Println("Resetting database...")
 	
 	// Drop tables in reverse order to respect foreign key constraints
 	tables := []interface{}{
+		&models.KioskPin{},
+		&models.Kiosk{},
+		&models.TimesheetEntry{},
+		&models.Timesheet{},
+		&models.ProjectTask{},
+		&models.Project{},
+		&models.RemoteWorkRequest{},
+		&models.OvertimeRequest{},
+		&models.AttendanceCorrection{},
+		&models.PunchEvent{},
+		&models.DeviceImport{},
+		&models.DeviceUser{},
+		&models.AttendanceDevice{},
+		&models.Geofence{},
+		&models.Holiday{},
+		&models.RosterEntry{},
+		&models.ShiftPatternSlot{},
+		&models.ShiftPattern{},
+		&models.AssetAssignment{},
+		&models.Asset{},
+		&models.CertificationFile{},
+		&models.Certification{},
+		&models.EmployeeSkill{},
+		&models.ProfileChangeRequest{},
+		&models.EmployeeDependent{},
+		&models.ContractRenewal{},
+		&models.EmploymentStatusHistory{},
+		&models.SeveranceRule{},
+		&models.ExitChecklistItem{},
+		&models.Termination{},
+		&models.OnboardingTask{},
+		&models.OnboardingTemplateTask{},
+		&models.OnboardingTemplate{},
+		&models.Notification{},
+		&models.CustomFieldDefinition{},
 		&models.Payroll{},
 		&models.LeaveBalance{},
 		&models.Leave{},
 		&models.Attendance{},
+		&models.Shift{},
 		&models.Employee{},
+		&models.WorkLocation{},
+		&models.HolidayCalendar{},
 		&models.Department{},
+		&models.RemoteWorkPolicy{},
 		&models.User{},
 	}
 	
@@ -2,6 +2,7 @@ package database
 
 import (
 	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
 	"log"
 	"time"
 
@@ -52,6 +53,11 @@ func Seed() error {
 		return err
 	}
 
+	// Build search documents for the seeded employees
+	if err := repositories.NewEmployeeRepository(DB).RefreshAllSearchText(); err != nil {
+		return err
+	}
+
 	log.Println("Database seeding completed successfully!")
 	return nil
 }
@@ -621,7 +627,7 @@ func seedPayroll() error {
 
 	// Create payroll for last month (paid)
 	for _, emp := range employees {
-		basicSalary := emp.Salary
+		basicSalary := float64(emp.Salary)
 		allowances := basicSalary * 0.10  // 10% tunjangan
 		tax := basicSalary * 0.05          // 5% pajak
 		deductions := basicSalary * 0.02   // 2% potongan (BPJS, dll)
@@ -646,7 +652,7 @@ func seedPayroll() error {
 
 	// Create payroll for current month (pending)
 	for _, emp := range employees {
-		basicSalary := emp.Salary
+		basicSalary := float64(emp.Salary)
 		allowances := basicSalary * 0.10
 		tax := basicSalary * 0.05
 		deductions := basicSalary * 0.02
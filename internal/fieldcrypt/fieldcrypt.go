@@ -0,0 +1,298 @@
+// Package fieldcrypt encrypts individual column values at rest.
+//
+// Every value is sealed with its own random data key (AES-256-GCM) and that data
+// key is sealed with a versioned key-encryption key from the configured keyring
+// (envelope encryption). Ciphertexts carry the key version, "enc:v<version>:<base64>",
+// so keys can be rotated by adding a new version and re-encrypting the data.
+//
+// Values without the prefix are treated as legacy plaintext and returned as is,
+// which lets existing rows be read until they are re-encrypted.
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	prefix  = "enc:v"
+	keySize = 32
+)
+
+var (
+	ErrNotConfigured = errors.New("field encryption keys are not configured")
+	ErrUnknownKey    = errors.New("ciphertext was sealed with an unknown key version")
+	ErrMalformed     = errors.New("malformed ciphertext")
+)
+
+var (
+	mu             sync.RWMutex
+	keys           map[int][]byte
+	currentVersion int
+	indexKey       []byte
+)
+
+// Init loads the keyring and the blind index key. The keyring has the form
+// "1:<base64 key>,2:<base64 key>"; the highest version encrypts new values and
+// older versions stay available for decryption.
+func Init(keyring, blindIndexKey string) error {
+	parsed, current, err := parseKeyring(keyring)
+	if err != nil {
+		return err
+	}
+
+	index, err := decodeKey(blindIndexKey)
+	if err != nil {
+		return fmt.Errorf("blind index key: %w", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	keys = parsed
+	currentVersion = current
+	indexKey = index
+	return nil
+}
+
+// developmentSeed derives the development keys. It is public, so anything
+// encrypted under those keys is effectively plaintext.
+const developmentSeed = "hr-backend-development"
+
+// InitDevelopment installs a fixed keyring so local setups can run without real
+// keys. It must never be used for data that needs protecting.
+func InitDevelopment() {
+	kek := sha256.Sum256([]byte("fieldcrypt-kek:" + developmentSeed))
+	index := sha256.Sum256([]byte("fieldcrypt-index:" + developmentSeed))
+
+	mu.Lock()
+	defer mu.Unlock()
+	keys = map[int][]byte{1: kek[:]}
+	currentVersion = 1
+	indexKey = index[:]
+}
+
+// CurrentVersion returns the key version used for new ciphertexts
+func CurrentVersion() int {
+	mu.RLock()
+	defer mu.RUnlock()
+	return currentVersion
+}
+
+// Encrypt seals plaintext under a fresh data key wrapped with the current key
+func Encrypt(plaintext string) (string, error) {
+	mu.RLock()
+	kek, version := keys[currentVersion], currentVersion
+	mu.RUnlock()
+	if kek == nil {
+		return "", ErrNotConfigured
+	}
+
+	dek := make([]byte, keySize)
+	if _, err := rand.Read(dek); err != nil {
+		return "", err
+	}
+
+	aad := []byte(strconv.Itoa(version))
+	wrapped, err := seal(kek, dek, aad)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(dek, []byte(plaintext), aad)
+	if err != nil {
+		return "", err
+	}
+
+	payload := append(wrapped, sealed...)
+	return prefix + strconv.Itoa(version) + ":" + base64.RawStdEncoding.EncodeToString(payload), nil
+}
+
+// Decrypt opens a value produced by Encrypt. Legacy plaintext is returned unchanged.
+func Decrypt(value string) (string, error) {
+	version, encoded, ok := split(value)
+	if !ok {
+		return value, nil
+	}
+
+	mu.RLock()
+	kek, configured := keys[version], keys != nil
+	mu.RUnlock()
+	if !configured {
+		return "", ErrNotConfigured
+	}
+	if kek == nil {
+		return "", ErrUnknownKey
+	}
+
+	payload, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrMalformed
+	}
+
+	wrappedSize := sealedSize(keySize)
+	if len(payload) < wrappedSize+sealedSize(0) {
+		return "", ErrMalformed
+	}
+
+	aad := []byte(strconv.Itoa(version))
+	dek, err := open(kek, payload[:wrappedSize], aad)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(dek, payload[wrappedSize:], aad)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// KeyVersion reports the key version of a ciphertext; ok is false for plaintext
+func KeyVersion(value string) (version int, ok bool) {
+	version, _, ok = split(value)
+	return version, ok
+}
+
+// NeedsReencryption reports whether a stored value is plaintext or sealed with an old key
+func NeedsReencryption(value string) bool {
+	if value == "" {
+		return false
+	}
+	version, ok := KeyVersion(value)
+	return !ok || version != CurrentVersion()
+}
+
+// BlindIndex returns a keyed hash of value that supports exact-match lookups
+// without decrypting. The domain keeps indexes of different fields unrelated.
+// Empty values have no index.
+func BlindIndex(domain, value string) string {
+	if value == "" {
+		return ""
+	}
+
+	mu.RLock()
+	key := indexKey
+	mu.RUnlock()
+	if key == nil {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(domain))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func parseKeyring(keyring string) (map[int][]byte, int, error) {
+	if strings.TrimSpace(keyring) == "" {
+		return nil, 0, ErrNotConfigured
+	}
+
+	parsed := map[int][]byte{}
+	current := 0
+	for _, entry := range strings.Split(keyring, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 2)
+		if len(parts) != 2 {
+			return nil, 0, fmt.Errorf("invalid keyring entry %q, expected <version>:<base64 key>", entry)
+		}
+
+		version, err := strconv.Atoi(parts[0])
+		if err != nil || version < 1 {
+			return nil, 0, fmt.Errorf("invalid key version %q", parts[0])
+		}
+
+		key, err := decodeKey(parts[1])
+		if err != nil {
+			return nil, 0, fmt.Errorf("key version %d: %w", version, err)
+		}
+
+		parsed[version] = key
+		if version > current {
+			current = version
+		}
+	}
+
+	return parsed, current, nil
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	if encoded == "" {
+		return nil, ErrNotConfigured
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, errors.New("key is not valid base64")
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("key must be %d bytes", keySize)
+	}
+	return key, nil
+}
+
+func split(value string) (int, string, bool) {
+	if !strings.HasPrefix(value, prefix) {
+		return 0, "", false
+	}
+	rest := value[len(prefix):]
+	sep := strings.IndexByte(rest, ':')
+	if sep < 1 {
+		return 0, "", false
+	}
+	version, err := strconv.Atoi(rest[:sep])
+	if err != nil {
+		return 0, "", false
+	}
+	return version, rest[sep+1:], true
+}
+
+// seal encrypts with AES-GCM and prepends the random nonce
+func seal(key, plaintext, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+func open(key, sealed, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, ErrMalformed
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, errors.New("ciphertext could not be authenticated")
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// sealedSize is the length of seal's output for a plaintext of n bytes
+func sealedSize(n int) int {
+	const nonceSize, tagSize = 12, 16
+	return nonceSize + n + tagSize
+}
@@ -0,0 +1,231 @@
+package fieldcrypt
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(rune(b)), keySize)))
+}
+
+func mustInit(t *testing.T, keyring string) {
+	t.Helper()
+	if err := Init(keyring, testKey('i')); err != nil {
+		t.Fatalf("Init(%q): %v", keyring, err)
+	}
+}
+
+func TestInit(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyring string
+		index   string
+		wantErr error
+		wantAny bool
+		current int
+	}{
+		{name: "single key", keyring: "1:" + testKey('a'), index: testKey('i'), current: 1},
+		{name: "highest version encrypts", keyring: "1:" + testKey('a') + ", 3:" + testKey('c') + ",2:" + testKey('b'), index: testKey('i'), current: 3},
+		{name: "empty keyring", keyring: " ", index: testKey('i'), wantErr: ErrNotConfigured},
+		{name: "missing blind index key", keyring: "1:" + testKey('a'), wantErr: ErrNotConfigured},
+		{name: "entry without version", keyring: testKey('a'), index: testKey('i'), wantAny: true},
+		{name: "version zero", keyring: "0:" + testKey('a'), index: testKey('i'), wantAny: true},
+		{name: "key not base64", keyring: "1:not base64!", index: testKey('i'), wantAny: true},
+		{name: "short key", keyring: "1:" + base64.StdEncoding.EncodeToString([]byte("short")), index: testKey('i'), wantAny: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Init(tt.keyring, tt.index)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Init() error = %v, want %v", err, tt.wantErr)
+				}
+			case tt.wantAny:
+				if err == nil {
+					t.Fatal("Init() succeeded, want an error")
+				}
+			default:
+				if err != nil {
+					t.Fatalf("Init() error = %v", err)
+				}
+				if got := CurrentVersion(); got != tt.current {
+					t.Errorf("CurrentVersion() = %d, want %d", got, tt.current)
+				}
+			}
+		})
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	mustInit(t, "1:"+testKey('a'))
+
+	for _, plaintext := range []string{"", "3171234567890001", "Jl. Sudirman No. 1, Jakarta", "ünïcødé ✓"} {
+		ciphertext, err := Encrypt(plaintext)
+		if err != nil {
+			t.Fatalf("Encrypt(%q): %v", plaintext, err)
+		}
+		if !strings.HasPrefix(ciphertext, "enc:v1:") {
+			t.Errorf("Encrypt(%q) = %q, want prefix enc:v1:", plaintext, ciphertext)
+		}
+		if plaintext != "" && strings.Contains(ciphertext, plaintext) {
+			t.Errorf("Encrypt(%q) leaks the plaintext", plaintext)
+		}
+
+		got, err := Decrypt(ciphertext)
+		if err != nil {
+			t.Fatalf("Decrypt(Encrypt(%q)): %v", plaintext, err)
+		}
+		if got != plaintext {
+			t.Errorf("Decrypt(Encrypt(%q)) = %q", plaintext, got)
+		}
+	}
+
+	first, _ := Encrypt("same")
+	second, _ := Encrypt("same")
+	if first == second {
+		t.Error("Encrypt() returned the same ciphertext twice, want a fresh data key and nonce")
+	}
+}
+
+func TestDecryptRotatedKeys(t *testing.T) {
+	mustInit(t, "1:"+testKey('a'))
+	old, err := Encrypt("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mustInit(t, "1:"+testKey('a')+",2:"+testKey('b'))
+	current, err := Encrypt("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		value     string
+		want      string
+		version   int
+		reencrypt bool
+	}{
+		{name: "old key", value: old, want: "secret", version: 1, reencrypt: true},
+		{name: "current key", value: current, want: "secret", version: 2, reencrypt: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decrypt(tt.value)
+			if err != nil || got != tt.want {
+				t.Fatalf("Decrypt() = %q, %v, want %q", got, err, tt.want)
+			}
+			if version, ok := KeyVersion(tt.value); !ok || version != tt.version {
+				t.Errorf("KeyVersion() = %d, %v, want %d", version, ok, tt.version)
+			}
+			if got := NeedsReencryption(tt.value); got != tt.reencrypt {
+				t.Errorf("NeedsReencryption() = %v, want %v", got, tt.reencrypt)
+			}
+		})
+	}
+
+	// Dropping the old key leaves its ciphertexts unreadable
+	mustInit(t, "2:"+testKey('b'))
+	if _, err := Decrypt(old); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Decrypt() with a removed key error = %v, want %v", err, ErrUnknownKey)
+	}
+}
+
+func TestDecryptInvalid(t *testing.T) {
+	mustInit(t, "1:"+testKey('a'))
+	valid, err := Encrypt("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := valid[len("enc:v1:"):]
+	tampered := []byte(payload)
+	tampered[len(tampered)-1] ^= 'A' ^ 'B'
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "legacy plaintext", value: "081234567890", want: "081234567890"},
+		{name: "empty", value: "", want: ""},
+		{name: "prefix without version", value: "enc:v:abc", want: "enc:v:abc"},
+		{name: "not base64", value: "enc:v1:***", wantErr: true},
+		{name: "truncated", value: "enc:v1:" + payload[:20], wantErr: true},
+		{name: "tampered", value: "enc:v1:" + string(tampered), wantErr: true},
+		{name: "other version label", value: "enc:v2:" + payload, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decrypt(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Decrypt(%q) = %q, want an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("Decrypt(%q) = %q, %v, want %q", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlindIndex(t *testing.T) {
+	mustInit(t, "1:"+testKey('a'))
+
+	tests := []struct {
+		name      string
+		domainA   string
+		valueA    string
+		domainB   string
+		valueB    string
+		wantEqual bool
+	}{
+		{name: "same value and domain", domainA: "phone", valueA: "6281234", domainB: "phone", valueB: "6281234", wantEqual: true},
+		{name: "different value", domainA: "phone", valueA: "6281234", domainB: "phone", valueB: "6281235"},
+		{name: "different domain", domainA: "phone", valueA: "6281234", domainB: "national_id", valueB: "6281234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := BlindIndex(tt.domainA, tt.valueA), BlindIndex(tt.domainB, tt.valueB)
+			if a == "" || b == "" {
+				t.Fatal("BlindIndex() returned an empty index for a value")
+			}
+			if (a == b) != tt.wantEqual {
+				t.Errorf("indexes equal = %v, want %v", a == b, tt.wantEqual)
+			}
+		})
+	}
+
+	if got := BlindIndex("phone", ""); got != "" {
+		t.Errorf("BlindIndex of an empty value = %q, want empty", got)
+	}
+}
+
+func TestInitDevelopmentIsFixed(t *testing.T) {
+	InitDevelopment()
+	ciphertext, err := Encrypt("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := BlindIndex("phone", "6281234")
+
+	InitDevelopment()
+	if got, err := Decrypt(ciphertext); err != nil || got != "secret" {
+		t.Errorf("Decrypt() after re-initializing = %q, %v", got, err)
+	}
+	if got := BlindIndex("phone", "6281234"); got != index {
+		t.Error("BlindIndex() changed after re-initializing development keys")
+	}
+}
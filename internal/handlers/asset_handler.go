@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssetHandler struct {
+	assetService *services.AssetService
+}
+
+func NewAssetHandler(assetService *services.AssetService) *AssetHandler {
+	return &AssetHandler{assetService: assetService}
+}
+
+func (h *AssetHandler) CreateAsset(c *gin.Context) {
+	var req models.CreateAssetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	asset, err := h.assetService.CreateAsset(&req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Asset created successfully", asset)
+}
+
+func (h *AssetHandler) GetAssets(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	assets, total, err := h.assetService.GetAssets(page, limit, c.Query("type"), c.Query("status"), c.Query("search"))
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, assets, total, page, limit)
+}
+
+func (h *AssetHandler) GetAssetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid asset ID")
+		return
+	}
+
+	asset, err := h.assetService.GetAssetByID(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Asset retrieved successfully", asset)
+}
+
+func (h *AssetHandler) UpdateAsset(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid asset ID")
+		return
+	}
+
+	var req models.UpdateAssetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	asset, err := h.assetService.UpdateAsset(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Asset updated successfully", asset)
+}
+
+func (h *AssetHandler) GetAssetHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid asset ID")
+		return
+	}
+
+	history, err := h.assetService.GetAssetHistory(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Asset history retrieved successfully", history)
+}
+
+func (h *AssetHandler) AssignAsset(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid asset ID")
+		return
+	}
+
+	var req models.AssignAssetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	asset, err := h.assetService.AssignAsset(uint(id), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ASSIGN_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Asset assigned successfully", asset)
+}
+
+func (h *AssetHandler) ReturnAsset(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid asset ID")
+		return
+	}
+
+	var req models.ReturnAssetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	asset, err := h.assetService.ReturnAsset(uint(id), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "RETURN_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Asset return recorded successfully", asset)
+}
+
+func (h *AssetHandler) GetEmployeeAssets(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	assets, err := h.assetService.GetEmployeeAssets(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Employee assets retrieved successfully", assets)
+}
+
+func (h *AssetHandler) GetAssetsHeldByInactive(c *gin.Context) {
+	assets, err := h.assetService.GetAssetsHeldByInactive()
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Assets held by inactive employees retrieved successfully", assets)
+}
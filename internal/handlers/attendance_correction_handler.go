@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AttendanceCorrectionHandler struct {
+	correctionService *services.AttendanceCorrectionService
+}
+
+func NewAttendanceCorrectionHandler(correctionService *services.AttendanceCorrectionService) *AttendanceCorrectionHandler {
+	return &AttendanceCorrectionHandler{correctionService: correctionService}
+}
+
+// SubmitCorrection accepts JSON, or a multipart form when evidence is attached as "evidence"
+func (h *AttendanceCorrectionHandler) SubmitCorrection(c *gin.Context) {
+	var req models.SubmitAttendanceCorrectionRequest
+	if err := c.ShouldBind(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	evidence, _ := c.FormFile("evidence")
+	userID, _ := c.Get("user_id")
+	correction, err := h.correctionService.SubmitCorrection(userID.(uint), &req, evidence)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "SUBMIT_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Attendance correction submitted successfully", correction)
+}
+
+func (h *AttendanceCorrectionHandler) GetMyCorrections(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	corrections, err := h.correctionService.GetMyCorrections(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Attendance corrections retrieved successfully", corrections)
+}
+
+func (h *AttendanceCorrectionHandler) CancelCorrection(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid correction ID")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	if err := h.correctionService.CancelCorrection(uint(id), userID.(uint)); err != nil {
+		utils.ErrorResponse(c, 400, "CANCEL_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Attendance correction cancelled successfully", nil)
+}
+
+func (h *AttendanceCorrectionHandler) GetCorrections(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	corrections, total, err := h.correctionService.GetCorrections(c.Query("status"), optionalUintQuery(c, "department_id"), page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, corrections, total, page, limit)
+}
+
+func (h *AttendanceCorrectionHandler) GetCorrectionByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid correction ID")
+		return
+	}
+
+	correction, err := h.correctionService.GetCorrectionByID(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Attendance correction retrieved successfully", correction)
+}
+
+func (h *AttendanceCorrectionHandler) GetEvidence(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid correction ID")
+		return
+	}
+
+	content, contentType, fileName, err := h.correctionService.GetEvidence(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename="+strconv.Quote(fileName))
+	c.Header("Content-Length", strconv.Itoa(len(content)))
+	c.Data(200, contentType, content)
+}
+
+func (h *AttendanceCorrectionHandler) ApproveCorrection(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid correction ID")
+		return
+	}
+
+	var req models.ReviewAttendanceCorrectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	correction, err := h.correctionService.ApproveCorrection(uint(id), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "APPROVAL_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Attendance correction approved successfully", correction)
+}
+
+func (h *AttendanceCorrectionHandler) RejectCorrection(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid correction ID")
+		return
+	}
+
+	var req models.ReviewAttendanceCorrectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	correction, err := h.correctionService.RejectCorrection(uint(id), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "REJECT_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Attendance correction rejected successfully", correction)
+}
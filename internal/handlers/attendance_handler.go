@@ -18,15 +18,18 @@ func NewAttendanceHandler(attendanceService *services.AttendanceService) *Attend
 	return &AttendanceHandler{attendanceService: attendanceService}
 }
 
+// ClockIn accepts JSON, or a multipart form when a selfie is attached as "photo"
 func (h *AttendanceHandler) ClockIn(c *gin.Context) {
 	var req models.ClockInRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := c.ShouldBind(&req); err != nil {
 		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
 		return
 	}
 
-	attendance, err := h.attendanceService.ClockIn(&req)
+	photo, _ := c.FormFile("photo")
+	userID, _ := c.Get("user_id")
+	attendance, err := h.attendanceService.ClockIn(userID.(uint), &req, photo)
 	if err != nil {
 		utils.ErrorResponse(c, 400, "CLOCK_IN_FAILED", err.Error())
 		return
@@ -38,12 +41,14 @@ func (h *AttendanceHandler) ClockIn(c *gin.Context) {
 func (h *AttendanceHandler) ClockOut(c *gin.Context) {
 	var req models.ClockOutRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := c.ShouldBind(&req); err != nil {
 		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
 		return
 	}
 
-	attendance, err := h.attendanceService.ClockOut(&req)
+	photo, _ := c.FormFile("photo")
+	userID, _ := c.Get("user_id")
+	attendance, err := h.attendanceService.ClockOut(userID.(uint), &req, photo)
 	if err != nil {
 		utils.ErrorResponse(c, 400, "CLOCK_OUT_FAILED", err.Error())
 		return
@@ -52,6 +57,28 @@ func (h *AttendanceHandler) ClockOut(c *gin.Context) {
 	utils.SuccessResponse(c, 200, "Clocked out successfully", attendance)
 }
 
+func (h *AttendanceHandler) StartBreak(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	attendance, err := h.attendanceService.StartBreak(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "BREAK_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Break started successfully", attendance)
+}
+
+func (h *AttendanceHandler) EndBreak(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	attendance, err := h.attendanceService.EndBreak(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "BREAK_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Break ended successfully", attendance)
+}
+
 func (h *AttendanceHandler) GetAttendance(c *gin.Context) {
 	employeeIDStr := c.Query("employee_id")
 	monthStr := c.DefaultQuery("month", strconv.Itoa(int(time.Now().Month())))
@@ -91,13 +118,62 @@ func (h *AttendanceHandler) GetAttendanceReport(c *gin.Context) {
 		return
 	}
 
-	attendances, err := h.attendanceService.GetAttendanceReport(startDate, endDate)
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	includeDays, _ := strconv.ParseBool(c.Query("include_days"))
+
+	filter := models.AttendanceReportFilter{
+		StartDate:    startDate,
+		EndDate:      endDate,
+		DepartmentID: optionalUintQuery(c, "department_id"),
+		Page:         page,
+		Limit:        limit,
+		IncludeDays:  includeDays,
+	}
+
+	reports, total, err := h.attendanceService.GetAttendanceReport(filter)
 	if err != nil {
-		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		utils.ErrorResponse(c, 400, "FETCH_FAILED", err.Error())
 		return
 	}
 
-	utils.SuccessResponse(c, 200, "Attendance report retrieved successfully", attendances)
+	utils.PaginatedSuccessResponse(c, reports, total, filter.Page, filter.Limit)
+}
+
+// GetAnomalies lists suspicious attendance records in the range, most suspicious first
+func (h *AttendanceHandler) GetAnomalies(c *gin.Context) {
+	startDate, err := time.Parse("2006-01-02", c.Query("start_date"))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_DATE", "Invalid start date format")
+		return
+	}
+
+	endDate, err := time.Parse("2006-01-02", c.Query("end_date"))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_DATE", "Invalid end date format")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	minScore, _ := strconv.Atoi(c.Query("min_score"))
+
+	filter := models.AttendanceAnomalyFilter{
+		StartDate:    startDate,
+		EndDate:      endDate,
+		DepartmentID: optionalUintQuery(c, "department_id"),
+		MinScore:     minScore,
+		Page:         page,
+		Limit:        limit,
+	}
+
+	anomalies, total, err := h.attendanceService.GetAttendanceAnomalies(filter)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, anomalies, total, filter.Page, filter.Limit)
 }
 
 func (h *AttendanceHandler) CreateManualAttendance(c *gin.Context) {
@@ -108,7 +184,8 @@ func (h *AttendanceHandler) CreateManualAttendance(c *gin.Context) {
 		return
 	}
 
-	result, err := h.attendanceService.CreateManualAttendance(&attendance)
+	userID, _ := c.Get("user_id")
+	result, err := h.attendanceService.CreateManualAttendance(&attendance, userID.(uint))
 	if err != nil {
 		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
 		return
@@ -116,3 +193,100 @@ func (h *AttendanceHandler) CreateManualAttendance(c *gin.Context) {
 
 	utils.SuccessResponse(c, 201, "Manual attendance created successfully", result)
 }
+
+func (h *AttendanceHandler) GetPendingReviews(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	attendances, total, err := h.attendanceService.GetPendingReviews(optionalUintQuery(c, "department_id"), page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, attendances, total, page, limit)
+}
+
+func (h *AttendanceHandler) ReviewAttendance(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid attendance ID")
+		return
+	}
+
+	var req models.ReviewAttendanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	reviewerID, _ := c.Get("user_id")
+	attendance, err := h.attendanceService.ReviewAttendance(uint(id), reviewerID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "REVIEW_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Attendance reviewed successfully", attendance)
+}
+
+// GetPhoto serves the clock-in ("masuk") or clock-out ("keluar") selfie
+func (h *AttendanceHandler) GetPhoto(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid attendance ID")
+		return
+	}
+
+	var clockOut bool
+	switch c.Param("punch") {
+	case "masuk":
+	case "keluar":
+		clockOut = true
+	default:
+		utils.ErrorResponse(c, 404, "NOT_FOUND", "Unknown punch type")
+		return
+	}
+
+	content, contentType, err := h.attendanceService.GetPhoto(uint(id), clockOut)
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	c.Header("Content-Length", strconv.Itoa(len(content)))
+	c.Data(200, contentType, content)
+}
+
+func (h *AttendanceHandler) GetPunchEvents(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid attendance ID")
+		return
+	}
+
+	events, err := h.attendanceService.GetPunchEvents(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Punch events retrieved successfully", events)
+}
+
+// MarkAbsences runs the end-of-day job again for a past date
+func (h *AttendanceHandler) MarkAbsences(c *gin.Context) {
+	var req models.MarkAbsencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	result, err := h.attendanceService.MarkAbsencesOn(req.Date.Time)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "MARK_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Absences marked successfully", result)
+}
@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CompetencyHandler struct {
+	competencyService *services.CompetencyService
+}
+
+func NewCompetencyHandler(competencyService *services.CompetencyService) *CompetencyHandler {
+	return &CompetencyHandler{competencyService: competencyService}
+}
+
+func (h *CompetencyHandler) GetSkills(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	skills, err := h.competencyService.GetSkills(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Skills retrieved successfully", skills)
+}
+
+func (h *CompetencyHandler) AddSkill(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	var req models.SkillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	skill, err := h.competencyService.AddSkill(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Skill added successfully", skill)
+}
+
+func (h *CompetencyHandler) UpdateSkill(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	skillID, err := strconv.ParseUint(c.Param("skill_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid skill ID")
+		return
+	}
+
+	var req models.SkillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	skill, err := h.competencyService.UpdateSkill(uint(id), uint(skillID), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Skill updated successfully", skill)
+}
+
+func (h *CompetencyHandler) DeleteSkill(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	skillID, err := strconv.ParseUint(c.Param("skill_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid skill ID")
+		return
+	}
+
+	if err := h.competencyService.DeleteSkill(uint(id), uint(skillID)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Skill deleted successfully", nil)
+}
+
+func (h *CompetencyHandler) SearchSkills(c *gin.Context) {
+	skills, err := h.competencyService.SearchSkills(c.Query("name"), c.Query("min_level"), optionalUintQuery(c, "department_id"))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Skills retrieved successfully", skills)
+}
+
+func (h *CompetencyHandler) GetEmployeeCertifications(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	certifications, err := h.competencyService.GetCertifications(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Certifications retrieved successfully", certifications)
+}
+
+func (h *CompetencyHandler) AddCertification(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	var req models.CertificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	certification, err := h.competencyService.AddCertification(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Certification added successfully", certification)
+}
+
+// SearchCertifications filters by name, employee_id, department_id, valid_on (YYYY-MM-DD)
+// and expiring_before (YYYY-MM-DD)
+func (h *CompetencyHandler) SearchCertifications(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	filter := models.CertificationFilter{
+		Name:         c.Query("name"),
+		EmployeeID:   optionalUintQuery(c, "employee_id"),
+		DepartmentID: optionalUintQuery(c, "department_id"),
+	}
+
+	if validOn := c.Query("valid_on"); validOn != "" {
+		date, err := time.Parse("2006-01-02", validOn)
+		if err != nil {
+			utils.ErrorResponse(c, 400, "INVALID_DATE", "Invalid valid_on date format")
+			return
+		}
+		filter.ValidOn = &date
+	}
+
+	if expiringBefore := c.Query("expiring_before"); expiringBefore != "" {
+		date, err := time.Parse("2006-01-02", expiringBefore)
+		if err != nil {
+			utils.ErrorResponse(c, 400, "INVALID_DATE", "Invalid expiring_before date format")
+			return
+		}
+		filter.ExpiringBefore = &date
+	}
+
+	certifications, total, err := h.competencyService.SearchCertifications(filter, page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, certifications, total, page, limit)
+}
+
+func (h *CompetencyHandler) GetCertificationByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid certification ID")
+		return
+	}
+
+	certification, err := h.competencyService.GetCertificationByID(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Certification retrieved successfully", certification)
+}
+
+func (h *CompetencyHandler) UpdateCertification(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid certification ID")
+		return
+	}
+
+	var req models.CertificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	certification, err := h.competencyService.UpdateCertification(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Certification updated successfully", certification)
+}
+
+func (h *CompetencyHandler) DeleteCertification(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid certification ID")
+		return
+	}
+
+	if err := h.competencyService.DeleteCertification(uint(id)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Certification deleted successfully", nil)
+}
+
+func (h *CompetencyHandler) UploadFile(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid certification ID")
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", "A file is required")
+		return
+	}
+
+	file, err := h.competencyService.UploadFile(uint(id), header)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPLOAD_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "File uploaded successfully", file)
+}
+
+func (h *CompetencyHandler) DownloadFile(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid certification ID")
+		return
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("file_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid file ID")
+		return
+	}
+
+	file, content, err := h.competencyService.GetFile(uint(id), uint(fileID))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename="+strconv.Quote(file.FileName))
+	c.Header("Content-Length", strconv.Itoa(len(content)))
+
+	c.Data(200, file.ContentType, content)
+}
+
+func (h *CompetencyHandler) DeleteFile(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid certification ID")
+		return
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("file_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid file ID")
+		return
+	}
+
+	if err := h.competencyService.DeleteFile(uint(id), uint(fileID)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "File deleted successfully", nil)
+}
+
+// optionalUintQuery returns the query parameter as an ID, or nil when it is absent or invalid
+func optionalUintQuery(c *gin.Context, key string) *uint {
+	value, err := strconv.ParseUint(c.Query(key), 10, 32)
+	if err != nil {
+		return nil
+	}
+	id := uint(value)
+	return &id
+}
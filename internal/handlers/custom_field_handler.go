@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CustomFieldHandler struct {
+	customFieldService *services.CustomFieldService
+}
+
+func NewCustomFieldHandler(customFieldService *services.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{customFieldService: customFieldService}
+}
+
+func (h *CustomFieldHandler) CreateDefinition(c *gin.Context) {
+	var req models.CreateCustomFieldRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	definition, err := h.customFieldService.CreateDefinition(&req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Custom field created successfully", definition)
+}
+
+func (h *CustomFieldHandler) GetDefinitions(c *gin.Context) {
+	entityType := c.DefaultQuery("entity_type", models.CustomFieldEntityEmployee)
+	role, _ := c.Get("role")
+
+	definitions, err := h.customFieldService.GetDefinitions(entityType, role.(string))
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Custom fields retrieved successfully", definitions)
+}
+
+func (h *CustomFieldHandler) UpdateDefinition(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid custom field ID")
+		return
+	}
+
+	var req models.UpdateCustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	definition, err := h.customFieldService.UpdateDefinition(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Custom field updated successfully", definition)
+}
+
+func (h *CustomFieldHandler) DeleteDefinition(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid custom field ID")
+		return
+	}
+
+	if err := h.customFieldService.DeleteDefinition(uint(id)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Custom field deleted successfully", nil)
+}
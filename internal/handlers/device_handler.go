@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeviceHandler struct {
+	deviceService *services.DeviceService
+}
+
+func NewDeviceHandler(deviceService *services.DeviceService) *DeviceHandler {
+	return &DeviceHandler{deviceService: deviceService}
+}
+
+func (h *DeviceHandler) GetDevices(c *gin.Context) {
+	devices, err := h.deviceService.GetDevices()
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Devices retrieved successfully", devices)
+}
+
+func (h *DeviceHandler) GetDeviceByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid device ID")
+		return
+	}
+
+	device, err := h.deviceService.GetDeviceByID(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Device retrieved successfully", device)
+}
+
+func (h *DeviceHandler) CreateDevice(c *gin.Context) {
+	var req models.AttendanceDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	device, err := h.deviceService.CreateDevice(&req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Device created successfully", device)
+}
+
+func (h *DeviceHandler) UpdateDevice(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid device ID")
+		return
+	}
+
+	var req models.AttendanceDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	device, err := h.deviceService.UpdateDevice(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Device updated successfully", device)
+}
+
+func (h *DeviceHandler) DeleteDevice(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid device ID")
+		return
+	}
+
+	if err := h.deviceService.DeleteDevice(uint(id)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Device deleted successfully", nil)
+}
+
+func (h *DeviceHandler) GetDeviceUsers(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid device ID")
+		return
+	}
+
+	users, err := h.deviceService.GetDeviceUsers(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Device users retrieved successfully", users)
+}
+
+func (h *DeviceHandler) SetDeviceUsers(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid device ID")
+		return
+	}
+
+	var req models.SetDeviceUsersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	users, err := h.deviceService.SetDeviceUsers(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Device users updated successfully", users)
+}
+
+func (h *DeviceHandler) DeleteDeviceUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid device ID")
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid device user ID")
+		return
+	}
+
+	if err := h.deviceService.DeleteDeviceUser(uint(id), uint(userID)); err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Device user deleted successfully", nil)
+}
+
+func (h *DeviceHandler) GetImports(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid device ID")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	imports, total, err := h.deviceService.GetImports(uint(id), page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, imports, total, page, limit)
+}
+
+// ImportPunchLog takes the CSV or TXT punch log exported by the device as "file"
+func (h *DeviceHandler) ImportPunchLog(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid device ID")
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", "A file is required")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+
+	result, err := h.deviceService.ImportPunchLog(uint(id), userID.(uint), header)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "IMPORT_FAILED", err.Error())
+		return
+	}
+
+	message := "Punch log imported successfully"
+	if result.AlreadyImported {
+		message = "Punch log was already imported"
+	}
+	utils.SuccessResponse(c, 200, message, result)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"hr-backend/internal/models"
 	"hr-backend/internal/services"
 	"hr-backend/internal/utils"
@@ -48,7 +49,13 @@ func (h *EmployeeHandler) GetEmployees(c *gin.Context) {
 		}
 	}
 
-	employees, total, err := h.employeeService.GetEmployees(page, limit, departmentID, status, search)
+	role, _ := c.Get("role")
+	userID, _ := c.Get("user_id")
+	employees, total, err := h.employeeService.GetEmployees(page, limit, departmentID, status, search, c.QueryMap("cf"), role.(string), userID.(uint))
+	if errors.Is(err, services.ErrInvalidCustomFieldFilter) {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
 	if err != nil {
 		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
 		return
@@ -57,6 +64,41 @@ func (h *EmployeeHandler) GetEmployees(c *gin.Context) {
 	utils.PaginatedSuccessResponse(c, employees, total, page, limit)
 }
 
+func (h *EmployeeHandler) SuggestEmployees(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	suggestions, err := h.employeeService.SuggestEmployees(c.Query("q"), limit)
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Employee suggestions retrieved successfully", suggestions)
+}
+
+func (h *EmployeeHandler) ExportEmployees(c *gin.Context) {
+	status := c.Query("status")
+	search := c.Query("search")
+
+	var departmentID *uint
+	if deptIDStr := c.Query("department_id"); deptIDStr != "" {
+		if id, err := strconv.ParseUint(deptIDStr, 10, 32); err == nil {
+			uid := uint(id)
+			departmentID = &uid
+		}
+	}
+
+	role, _ := c.Get("role")
+	csvBytes, err := h.employeeService.ExportEmployees(departmentID, status, search, c.QueryMap("cf"), role.(string))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "EXPORT_FAILED", err.Error())
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=karyawan.csv")
+	c.Data(200, "text/csv; charset=utf-8", csvBytes)
+}
+
 func (h *EmployeeHandler) GetEmployeeByID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -64,7 +106,9 @@ func (h *EmployeeHandler) GetEmployeeByID(c *gin.Context) {
 		return
 	}
 
-	employee, err := h.employeeService.GetEmployeeByID(uint(id))
+	role, _ := c.Get("role")
+	userID, _ := c.Get("user_id")
+	employee, err := h.employeeService.GetEmployeeByID(uint(id), role.(string), userID.(uint))
 	if err != nil {
 		utils.ErrorResponse(c, 404, "NOT_FOUND", "Employee not found")
 		return
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EmploymentHandler struct {
+	employmentService *services.EmploymentService
+}
+
+func NewEmploymentHandler(employmentService *services.EmploymentService) *EmploymentHandler {
+	return &EmploymentHandler{employmentService: employmentService}
+}
+
+func (h *EmploymentHandler) ChangeStatus(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	var req models.ChangeEmploymentStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	employee, err := h.employmentService.ChangeStatus(uint(id), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Employment status updated successfully", employee)
+}
+
+func (h *EmploymentHandler) GetStatusHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	history, err := h.employmentService.GetStatusHistory(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Employment status history retrieved successfully", history)
+}
+
+func (h *EmploymentHandler) ExtendProbation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	var req models.ExtendProbationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	employee, err := h.employmentService.ExtendProbation(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Probation extended successfully", employee)
+}
+
+func (h *EmploymentHandler) RenewContract(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	var req models.RenewContractRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	employee, err := h.employmentService.RenewContract(uint(id), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "RENEWAL_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Contract renewed successfully", employee)
+}
+
+func (h *EmploymentHandler) GetContractRenewals(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	renewals, err := h.employmentService.GetContractRenewals(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Contract renewals retrieved successfully", renewals)
+}
+
+func (h *EmploymentHandler) GetExpiring(c *gin.Context) {
+	days, _ := strconv.Atoi(c.DefaultQuery("hari", "30"))
+
+	expiring, err := h.employmentService.GetExpiring(days)
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Expiring probations and contracts retrieved successfully", expiring)
+}
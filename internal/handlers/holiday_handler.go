@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HolidayHandler struct {
+	holidayService *services.HolidayService
+}
+
+func NewHolidayHandler(holidayService *services.HolidayService) *HolidayHandler {
+	return &HolidayHandler{holidayService: holidayService}
+}
+
+func (h *HolidayHandler) GetCalendars(c *gin.Context) {
+	calendars, err := h.holidayService.GetCalendars()
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Holiday calendars retrieved successfully", calendars)
+}
+
+func (h *HolidayHandler) GetCalendarByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid calendar ID")
+		return
+	}
+
+	calendar, err := h.holidayService.GetCalendarByID(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Holiday calendar retrieved successfully", calendar)
+}
+
+func (h *HolidayHandler) CreateCalendar(c *gin.Context) {
+	var req models.HolidayCalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	calendar, err := h.holidayService.CreateCalendar(&req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Holiday calendar created successfully", calendar)
+}
+
+func (h *HolidayHandler) UpdateCalendar(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid calendar ID")
+		return
+	}
+
+	var req models.HolidayCalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	calendar, err := h.holidayService.UpdateCalendar(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Holiday calendar updated successfully", calendar)
+}
+
+func (h *HolidayHandler) DeleteCalendar(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid calendar ID")
+		return
+	}
+
+	if err := h.holidayService.DeleteCalendar(uint(id)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Holiday calendar deleted successfully", nil)
+}
+
+func (h *HolidayHandler) GetHolidays(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid calendar ID")
+		return
+	}
+
+	var year *int
+	if yearStr := c.Query("year"); yearStr != "" {
+		parsed, err := strconv.Atoi(yearStr)
+		if err != nil {
+			utils.ErrorResponse(c, 400, "INVALID_YEAR", "Invalid year")
+			return
+		}
+		year = &parsed
+	}
+
+	holidays, err := h.holidayService.GetHolidays(uint(id), year)
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Holidays retrieved successfully", holidays)
+}
+
+func (h *HolidayHandler) CreateHoliday(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid calendar ID")
+		return
+	}
+
+	var req models.HolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	holiday, err := h.holidayService.CreateHoliday(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Holiday created successfully", holiday)
+}
+
+func (h *HolidayHandler) UpdateHoliday(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid calendar ID")
+		return
+	}
+
+	holidayID, err := strconv.ParseUint(c.Param("holiday_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid holiday ID")
+		return
+	}
+
+	var req models.HolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	holiday, err := h.holidayService.UpdateHoliday(uint(id), uint(holidayID), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Holiday updated successfully", holiday)
+}
+
+func (h *HolidayHandler) DeleteHoliday(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid calendar ID")
+		return
+	}
+
+	holidayID, err := strconv.ParseUint(c.Param("holiday_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid holiday ID")
+		return
+	}
+
+	if err := h.holidayService.DeleteHoliday(uint(id), uint(holidayID)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Holiday deleted successfully", nil)
+}
+
+func (h *HolidayHandler) ImportICalendar(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid calendar ID")
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", "A file is required")
+		return
+	}
+
+	holidayType := c.PostForm("type")
+	switch holidayType {
+	case "", models.HolidayNational, models.HolidayCollectiveLeave, models.HolidayCompany:
+	default:
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", "Invalid holiday type")
+		return
+	}
+
+	result, err := h.holidayService.ImportICalendar(uint(id), header, holidayType)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "IMPORT_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Holidays imported successfully", result)
+}
+
+// GetHolidaysInRange lists holiday occurrences for leave and attendance screens
+func (h *HolidayHandler) GetHolidaysInRange(c *gin.Context) {
+	startDate, err := time.Parse("2006-01-02", c.Query("start_date"))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_DATE", "Invalid start date format")
+		return
+	}
+
+	endDate, err := time.Parse("2006-01-02", c.Query("end_date"))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_DATE", "Invalid end date format")
+		return
+	}
+
+	holidays, err := h.holidayService.GetHolidaysInRange(optionalUintQuery(c, "calendar_id"), optionalUintQuery(c, "employee_id"), startDate, endDate)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Holidays retrieved successfully", holidays)
+}
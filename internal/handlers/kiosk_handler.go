@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type KioskHandler struct {
+	kioskService *services.KioskService
+}
+
+func NewKioskHandler(kioskService *services.KioskService) *KioskHandler {
+	return &KioskHandler{kioskService: kioskService}
+}
+
+func (h *KioskHandler) GetKiosks(c *gin.Context) {
+	kiosks, err := h.kioskService.GetKiosks()
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Kiosks retrieved successfully", kiosks)
+}
+
+func (h *KioskHandler) GetKioskByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid kiosk ID")
+		return
+	}
+
+	kiosk, err := h.kioskService.GetKioskByID(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Kiosk retrieved successfully", kiosk)
+}
+
+// CreateKiosk registers a kiosk; the credential in the response is not shown again
+func (h *KioskHandler) CreateKiosk(c *gin.Context) {
+	var req models.KioskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	credential, err := h.kioskService.CreateKiosk(&req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Kiosk created successfully", credential)
+}
+
+func (h *KioskHandler) UpdateKiosk(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid kiosk ID")
+		return
+	}
+
+	var req models.KioskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	kiosk, err := h.kioskService.UpdateKiosk(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Kiosk updated successfully", kiosk)
+}
+
+func (h *KioskHandler) DeleteKiosk(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid kiosk ID")
+		return
+	}
+
+	if err := h.kioskService.DeleteKiosk(uint(id)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Kiosk deleted successfully", nil)
+}
+
+func (h *KioskHandler) RotateCredential(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid kiosk ID")
+		return
+	}
+
+	credential, err := h.kioskService.RotateCredential(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Kiosk credential issued successfully", credential)
+}
+
+// Scan punches in or out the signed-in employee with the code shown on a kiosk
+func (h *KioskHandler) Scan(c *gin.Context) {
+	var req models.KioskScanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	result, err := h.kioskService.Scan(userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "PUNCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Punch recorded successfully", result)
+}
+
+func (h *KioskHandler) SetPin(c *gin.Context) {
+	var req models.SetKioskPinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	if err := h.kioskService.SetPin(userID.(uint), &req); err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Kiosk PIN set successfully", nil)
+}
+
+// GetQRCode returns the code the signed-in kiosk displays now
+func (h *KioskHandler) GetQRCode(c *gin.Context) {
+	kioskID, _ := c.Get("kiosk_id")
+	code, err := h.kioskService.GetQRCode(kioskID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Kiosk code generated successfully", code)
+}
+
+// PinPunch punches in or out the employee who entered their PIN at the kiosk
+func (h *KioskHandler) PinPunch(c *gin.Context) {
+	var req models.KioskPinPunchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	kioskID, _ := c.Get("kiosk_id")
+	result, err := h.kioskService.PinPunch(kioskID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "PUNCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Punch recorded successfully", result)
+}
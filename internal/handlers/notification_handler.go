@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+func NewNotificationHandler(notificationService *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	unreadOnly := c.Query("unread") == "true"
+
+	userID, _ := c.Get("user_id")
+	notifications, total, err := h.notificationService.GetNotifications(userID.(uint), unreadOnly, page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, notifications, total, page, limit)
+}
+
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid notification ID")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	if err := h.notificationService.MarkRead(uint(id), userID.(uint)); err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", "Notification not found")
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Notification marked as read", nil)
+}
+
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	if err := h.notificationService.MarkAllRead(userID.(uint)); err != nil {
+		utils.ErrorResponse(c, 500, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "All notifications marked as read", nil)
+}
@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OnboardingHandler struct {
+	onboardingService *services.OnboardingService
+}
+
+func NewOnboardingHandler(onboardingService *services.OnboardingService) *OnboardingHandler {
+	return &OnboardingHandler{onboardingService: onboardingService}
+}
+
+func (h *OnboardingHandler) CreateTemplate(c *gin.Context) {
+	var req models.CreateOnboardingTemplateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	template, err := h.onboardingService.CreateTemplate(&req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Onboarding template created successfully", template)
+}
+
+func (h *OnboardingHandler) GetTemplates(c *gin.Context) {
+	templates, err := h.onboardingService.GetTemplates()
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Onboarding templates retrieved successfully", templates)
+}
+
+func (h *OnboardingHandler) GetTemplateByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid template ID")
+		return
+	}
+
+	template, err := h.onboardingService.GetTemplateByID(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", "Onboarding template not found")
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Onboarding template retrieved successfully", template)
+}
+
+func (h *OnboardingHandler) UpdateTemplate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid template ID")
+		return
+	}
+
+	var req models.UpdateOnboardingTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	template, err := h.onboardingService.UpdateTemplate(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Onboarding template updated successfully", template)
+}
+
+func (h *OnboardingHandler) DeleteTemplate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid template ID")
+		return
+	}
+
+	if err := h.onboardingService.DeleteTemplate(uint(id)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Onboarding template deleted successfully", nil)
+}
+
+func (h *OnboardingHandler) StartOnboarding(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	tasks, err := h.onboardingService.StartOnboarding(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "START_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Onboarding started successfully", tasks)
+}
+
+func (h *OnboardingHandler) AddTask(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	var req models.CreateOnboardingTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	task, err := h.onboardingService.AddTask(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Onboarding task created successfully", task)
+}
+
+func (h *OnboardingHandler) GetEmployeeOnboarding(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	progress, err := h.onboardingService.GetEmployeeOnboarding(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Onboarding progress retrieved successfully", progress)
+}
+
+func (h *OnboardingHandler) GetMyTasks(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	role, _ := c.Get("role")
+
+	tasks, err := h.onboardingService.GetMyTasks(userID.(uint), role.(string))
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Onboarding tasks retrieved successfully", tasks)
+}
+
+func (h *OnboardingHandler) CompleteTask(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid task ID")
+		return
+	}
+
+	var req models.CompleteOnboardingTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	role, _ := c.Get("role")
+	task, err := h.onboardingService.CompleteTask(uint(id), userID.(uint), role.(string), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Onboarding task completed successfully", task)
+}
+
+func (h *OnboardingHandler) DeleteTask(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid task ID")
+		return
+	}
+
+	if err := h.onboardingService.DeleteTask(uint(id)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Onboarding task deleted successfully", nil)
+}
+
+func (h *OnboardingHandler) GetOverdueDashboard(c *gin.Context) {
+	var departmentID *uint
+	if deptIDStr := c.Query("department_id"); deptIDStr != "" {
+		if id, err := strconv.ParseUint(deptIDStr, 10, 32); err == nil {
+			uid := uint(id)
+			departmentID = &uid
+		}
+	}
+
+	dashboard, err := h.onboardingService.GetOverdueDashboard(departmentID)
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Overdue onboarding tasks retrieved successfully", dashboard)
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OvertimeHandler struct {
+	overtimeService *services.OvertimeService
+}
+
+func NewOvertimeHandler(overtimeService *services.OvertimeService) *OvertimeHandler {
+	return &OvertimeHandler{overtimeService: overtimeService}
+}
+
+func (h *OvertimeHandler) SubmitRequest(c *gin.Context) {
+	var req models.SubmitOvertimeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	request, err := h.overtimeService.SubmitRequest(userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "SUBMIT_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Overtime request submitted successfully", request)
+}
+
+func (h *OvertimeHandler) GetMyRequests(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	requests, err := h.overtimeService.GetMyRequests(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Overtime requests retrieved successfully", requests)
+}
+
+func (h *OvertimeHandler) CancelRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid overtime request ID")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	if err := h.overtimeService.CancelRequest(uint(id), userID.(uint)); err != nil {
+		utils.ErrorResponse(c, 400, "CANCEL_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Overtime request cancelled successfully", nil)
+}
+
+func (h *OvertimeHandler) GetRequests(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	requests, total, err := h.overtimeService.GetRequests(c.Query("status"), optionalUintQuery(c, "employee_id"), optionalUintQuery(c, "department_id"), page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, requests, total, page, limit)
+}
+
+func (h *OvertimeHandler) GetRequestByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid overtime request ID")
+		return
+	}
+
+	request, err := h.overtimeService.GetRequestByID(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Overtime request retrieved successfully", request)
+}
+
+func (h *OvertimeHandler) ApproveRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid overtime request ID")
+		return
+	}
+
+	var req models.ReviewOvertimeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	request, err := h.overtimeService.ApproveRequest(uint(id), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "APPROVAL_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Overtime request approved successfully", request)
+}
+
+func (h *OvertimeHandler) RejectRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid overtime request ID")
+		return
+	}
+
+	var req models.ReviewOvertimeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	request, err := h.overtimeService.RejectRequest(uint(id), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "REJECT_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Overtime request rejected successfully", request)
+}
+
+// GetOvertimeSummary shows worked against approved overtime and its pay for a month
+func (h *OvertimeHandler) GetOvertimeSummary(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Query("employee_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_EMPLOYEE_ID", "Invalid employee ID")
+		return
+	}
+
+	month, _ := strconv.Atoi(c.DefaultQuery("month", strconv.Itoa(int(time.Now().Month()))))
+	year, _ := strconv.Atoi(c.DefaultQuery("year", strconv.Itoa(time.Now().Year())))
+
+	summary, err := h.overtimeService.GetOvertimeSummary(uint(employeeID), month, year)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Overtime summary retrieved successfully", summary)
+}
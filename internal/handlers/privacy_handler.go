@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PrivacyHandler struct {
+	privacyService *services.PrivacyService
+}
+
+func NewPrivacyHandler(privacyService *services.PrivacyService) *PrivacyHandler {
+	return &PrivacyHandler{privacyService: privacyService}
+}
+
+func (h *PrivacyHandler) ExportPersonalData(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	archive, filename, err := h.privacyService.ExportPersonalData(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "EXPORT_FAILED", err.Error())
+		return
+	}
+
+	// Set headers for ZIP download
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Header("Content-Length", strconv.Itoa(len(archive)))
+
+	c.Data(200, "application/zip", archive)
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProfileChangeHandler struct {
+	profileChangeService *services.ProfileChangeService
+}
+
+func NewProfileChangeHandler(profileChangeService *services.ProfileChangeService) *ProfileChangeHandler {
+	return &ProfileChangeHandler{profileChangeService: profileChangeService}
+}
+
+func (h *ProfileChangeHandler) SubmitChange(c *gin.Context) {
+	var req models.SubmitProfileChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	request, err := h.profileChangeService.SubmitChange(userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "SUBMIT_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Profile change request submitted successfully", request)
+}
+
+func (h *ProfileChangeHandler) GetMyRequests(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	requests, err := h.profileChangeService.GetMyRequests(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Profile change requests retrieved successfully", requests)
+}
+
+func (h *ProfileChangeHandler) CancelRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid request ID")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	if err := h.profileChangeService.CancelRequest(uint(id), userID.(uint)); err != nil {
+		utils.ErrorResponse(c, 400, "CANCEL_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Profile change request cancelled successfully", nil)
+}
+
+func (h *ProfileChangeHandler) GetRequests(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	status := c.Query("status")
+
+	requests, total, err := h.profileChangeService.GetRequests(status, page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, requests, total, page, limit)
+}
+
+func (h *ProfileChangeHandler) GetRequestByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid request ID")
+		return
+	}
+
+	request, err := h.profileChangeService.GetRequestByID(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", "Profile change request not found")
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Profile change request retrieved successfully", request)
+}
+
+func (h *ProfileChangeHandler) ApproveRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid request ID")
+		return
+	}
+
+	var req models.ReviewProfileChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	request, err := h.profileChangeService.ApproveRequest(uint(id), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "APPROVAL_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Profile change request approved successfully", request)
+}
+
+func (h *ProfileChangeHandler) RejectRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid request ID")
+		return
+	}
+
+	var req models.ReviewProfileChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	request, err := h.profileChangeService.RejectRequest(uint(id), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "REJECTION_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Profile change request rejected", request)
+}
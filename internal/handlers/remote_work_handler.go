@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RemoteWorkHandler struct {
+	remoteWorkService *services.RemoteWorkService
+}
+
+func NewRemoteWorkHandler(remoteWorkService *services.RemoteWorkService) *RemoteWorkHandler {
+	return &RemoteWorkHandler{remoteWorkService: remoteWorkService}
+}
+
+func (h *RemoteWorkHandler) GetPolicies(c *gin.Context) {
+	policies, err := h.remoteWorkService.GetPolicies()
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Remote work policies retrieved successfully", policies)
+}
+
+func (h *RemoteWorkHandler) CreatePolicy(c *gin.Context) {
+	var req models.RemoteWorkPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	policy, err := h.remoteWorkService.CreatePolicy(&req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Remote work policy created successfully", policy)
+}
+
+func (h *RemoteWorkHandler) UpdatePolicy(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid policy ID")
+		return
+	}
+
+	var req models.RemoteWorkPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	policy, err := h.remoteWorkService.UpdatePolicy(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Remote work policy updated successfully", policy)
+}
+
+func (h *RemoteWorkHandler) DeletePolicy(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid policy ID")
+		return
+	}
+
+	if err := h.remoteWorkService.DeletePolicy(uint(id)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Remote work policy deleted successfully", nil)
+}
+
+func (h *RemoteWorkHandler) SubmitRequest(c *gin.Context) {
+	var req models.SubmitRemoteWorkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	request, err := h.remoteWorkService.SubmitRequest(userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "SUBMIT_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Remote work request submitted successfully", request)
+}
+
+func (h *RemoteWorkHandler) GetMyRequests(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	requests, err := h.remoteWorkService.GetMyRequests(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Remote work requests retrieved successfully", requests)
+}
+
+func (h *RemoteWorkHandler) CancelRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid remote work request ID")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	if err := h.remoteWorkService.CancelRequest(uint(id), userID.(uint)); err != nil {
+		utils.ErrorResponse(c, 400, "CANCEL_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Remote work request cancelled successfully", nil)
+}
+
+func (h *RemoteWorkHandler) GetRequests(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	requests, total, err := h.remoteWorkService.GetRequests(c.Query("status"), optionalUintQuery(c, "employee_id"), optionalUintQuery(c, "department_id"), page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, requests, total, page, limit)
+}
+
+func (h *RemoteWorkHandler) GetRequestByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid remote work request ID")
+		return
+	}
+
+	request, err := h.remoteWorkService.GetRequestByID(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Remote work request retrieved successfully", request)
+}
+
+func (h *RemoteWorkHandler) ApproveRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid remote work request ID")
+		return
+	}
+
+	var req models.ReviewRemoteWorkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	request, err := h.remoteWorkService.ApproveRequest(uint(id), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "APPROVAL_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Remote work request approved successfully", request)
+}
+
+func (h *RemoteWorkHandler) RejectRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid remote work request ID")
+		return
+	}
+
+	var req models.ReviewRemoteWorkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	request, err := h.remoteWorkService.RejectRequest(uint(id), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "REJECT_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Remote work request rejected successfully", request)
+}
+
+// GetCalendar shows who works remotely on each day of a date range
+func (h *RemoteWorkHandler) GetCalendar(c *gin.Context) {
+	startDate, endDate, ok := rosterRange(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.remoteWorkService.GetCalendar(startDate, endDate, optionalUintQuery(c, "department_id"))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Remote work calendar retrieved successfully", entries)
+}
@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShiftHandler struct {
+	shiftService *services.ShiftService
+}
+
+func NewShiftHandler(shiftService *services.ShiftService) *ShiftHandler {
+	return &ShiftHandler{shiftService: shiftService}
+}
+
+func (h *ShiftHandler) GetShifts(c *gin.Context) {
+	shifts, err := h.shiftService.GetShifts()
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Shifts retrieved successfully", shifts)
+}
+
+func (h *ShiftHandler) GetShiftByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid shift ID")
+		return
+	}
+
+	shift, err := h.shiftService.GetShiftByID(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Shift retrieved successfully", shift)
+}
+
+func (h *ShiftHandler) CreateShift(c *gin.Context) {
+	var req models.ShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	shift, err := h.shiftService.CreateShift(&req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Shift created successfully", shift)
+}
+
+func (h *ShiftHandler) UpdateShift(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid shift ID")
+		return
+	}
+
+	var req models.ShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	shift, err := h.shiftService.UpdateShift(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Shift updated successfully", shift)
+}
+
+func (h *ShiftHandler) DeleteShift(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid shift ID")
+		return
+	}
+
+	if err := h.shiftService.DeleteShift(uint(id)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Shift deleted successfully", nil)
+}
+
+func (h *ShiftHandler) GetPatterns(c *gin.Context) {
+	patterns, err := h.shiftService.GetPatterns()
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Shift patterns retrieved successfully", patterns)
+}
+
+func (h *ShiftHandler) GetPatternByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid pattern ID")
+		return
+	}
+
+	pattern, err := h.shiftService.GetPatternByID(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Shift pattern retrieved successfully", pattern)
+}
+
+func (h *ShiftHandler) CreatePattern(c *gin.Context) {
+	var req models.ShiftPatternRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	pattern, err := h.shiftService.CreatePattern(&req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Shift pattern created successfully", pattern)
+}
+
+func (h *ShiftHandler) UpdatePattern(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid pattern ID")
+		return
+	}
+
+	var req models.ShiftPatternRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	pattern, err := h.shiftService.UpdatePattern(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Shift pattern updated successfully", pattern)
+}
+
+func (h *ShiftHandler) DeletePattern(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid pattern ID")
+		return
+	}
+
+	if err := h.shiftService.DeletePattern(uint(id)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Shift pattern deleted successfully", nil)
+}
+
+func (h *ShiftHandler) GetRoster(c *gin.Context) {
+	startDate, endDate, ok := rosterRange(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.shiftService.GetRoster(startDate, endDate, optionalUintQuery(c, "employee_id"), optionalUintQuery(c, "department_id"))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Roster retrieved successfully", entries)
+}
+
+func (h *ShiftHandler) GetMySchedule(c *gin.Context) {
+	startDate, endDate, ok := rosterRange(c)
+	if !ok {
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	entries, err := h.shiftService.GetMySchedule(userID.(uint), startDate, endDate)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Schedule retrieved successfully", entries)
+}
+
+func (h *ShiftHandler) SetRoster(c *gin.Context) {
+	var req models.SetRosterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	entries, err := h.shiftService.SetRoster(&req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Roster updated successfully", entries)
+}
+
+func (h *ShiftHandler) ApplyPattern(c *gin.Context) {
+	var req models.ApplyShiftPatternRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	count, err := h.shiftService.ApplyPattern(&req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Shift pattern applied successfully", gin.H{"entries": count})
+}
+
+func (h *ShiftHandler) DeleteRosterEntry(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid roster entry ID")
+		return
+	}
+
+	if err := h.shiftService.DeleteRosterEntry(uint(id)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Roster entry deleted successfully", nil)
+}
+
+// rosterRange reads start_date and end_date, writing the error response when they are invalid
+func rosterRange(c *gin.Context) (time.Time, time.Time, bool) {
+	startDate, err := time.Parse("2006-01-02", c.Query("start_date"))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_DATE", "Invalid start date format")
+		return time.Time{}, time.Time{}, false
+	}
+
+	endDate, err := time.Parse("2006-01-02", c.Query("end_date"))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_DATE", "Invalid end date format")
+		return time.Time{}, time.Time{}, false
+	}
+
+	return startDate, endDate, true
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TerminationHandler struct {
+	terminationService *services.TerminationService
+}
+
+func NewTerminationHandler(terminationService *services.TerminationService) *TerminationHandler {
+	return &TerminationHandler{terminationService: terminationService}
+}
+
+func (h *TerminationHandler) TerminateEmployee(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid employee ID")
+		return
+	}
+
+	var req models.CreateTerminationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	termination, err := h.terminationService.TerminateEmployee(uint(id), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "TERMINATION_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Termination scheduled successfully", termination)
+}
+
+func (h *TerminationHandler) GetTerminations(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	status := c.Query("status")
+
+	terminations, total, err := h.terminationService.GetTerminations(status, page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, terminations, total, page, limit)
+}
+
+func (h *TerminationHandler) GetTerminationByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid termination ID")
+		return
+	}
+
+	termination, err := h.terminationService.GetTerminationByID(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", "Termination not found")
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Termination retrieved successfully", termination)
+}
+
+func (h *TerminationHandler) RecalculateSettlement(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid termination ID")
+		return
+	}
+
+	termination, err := h.terminationService.RecalculateSettlement(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CALCULATION_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Final settlement recalculated successfully", termination)
+}
+
+func (h *TerminationHandler) CancelTermination(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid termination ID")
+		return
+	}
+
+	termination, err := h.terminationService.CancelTermination(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CANCEL_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Termination cancelled successfully", termination)
+}
+
+func (h *TerminationHandler) CompleteChecklistItem(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid termination ID")
+		return
+	}
+
+	itemID, err := strconv.ParseUint(c.Param("item_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid checklist item ID")
+		return
+	}
+
+	var req models.CompleteChecklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	item, err := h.terminationService.CompleteChecklistItem(uint(id), uint(itemID), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Checklist item completed successfully", item)
+}
+
+func (h *TerminationHandler) GetSeveranceRules(c *gin.Context) {
+	rules, err := h.terminationService.GetSeveranceRules()
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Severance rules retrieved successfully", rules)
+}
+
+func (h *TerminationHandler) UpdateSeveranceRule(c *gin.Context) {
+	var req models.UpdateSeveranceRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	rule, err := h.terminationService.UpdateSeveranceRule(c.Param("type"), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Severance rule updated successfully", rule)
+}
@@ -0,0 +1,392 @@
+package handlers
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TimesheetHandler struct {
+	timesheetService *services.TimesheetService
+}
+
+func NewTimesheetHandler(timesheetService *services.TimesheetService) *TimesheetHandler {
+	return &TimesheetHandler{timesheetService: timesheetService}
+}
+
+func (h *TimesheetHandler) GetProjects(c *gin.Context) {
+	projects, err := h.timesheetService.GetProjects(c.Query("status"), c.Query("search"))
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Projects retrieved successfully", projects)
+}
+
+func (h *TimesheetHandler) GetProjectByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid project ID")
+		return
+	}
+
+	project, err := h.timesheetService.GetProjectByID(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Project retrieved successfully", project)
+}
+
+func (h *TimesheetHandler) CreateProject(c *gin.Context) {
+	var req models.ProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	project, err := h.timesheetService.CreateProject(&req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Project created successfully", project)
+}
+
+func (h *TimesheetHandler) UpdateProject(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid project ID")
+		return
+	}
+
+	var req models.ProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	project, err := h.timesheetService.UpdateProject(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Project updated successfully", project)
+}
+
+func (h *TimesheetHandler) DeleteProject(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid project ID")
+		return
+	}
+
+	if err := h.timesheetService.DeleteProject(uint(id)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Project deleted successfully", nil)
+}
+
+func (h *TimesheetHandler) AddTask(c *gin.Context) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid project ID")
+		return
+	}
+
+	var req models.ProjectTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	task, err := h.timesheetService.AddTask(uint(projectID), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Task added successfully", task)
+}
+
+func (h *TimesheetHandler) UpdateTask(c *gin.Context) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid project ID")
+		return
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("task_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid task ID")
+		return
+	}
+
+	var req models.ProjectTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	task, err := h.timesheetService.UpdateTask(uint(projectID), uint(taskID), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Task updated successfully", task)
+}
+
+func (h *TimesheetHandler) DeleteTask(c *gin.Context) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid project ID")
+		return
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("task_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid task ID")
+		return
+	}
+
+	if err := h.timesheetService.DeleteTask(uint(projectID), uint(taskID)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Task deleted successfully", nil)
+}
+
+func (h *TimesheetHandler) AddEntry(c *gin.Context) {
+	var req models.TimesheetEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	entry, err := h.timesheetService.AddEntry(userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Hours logged successfully", entry)
+}
+
+func (h *TimesheetHandler) UpdateEntry(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid entry ID")
+		return
+	}
+
+	var req models.TimesheetEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	entry, err := h.timesheetService.UpdateEntry(userID.(uint), uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Timesheet entry updated successfully", entry)
+}
+
+func (h *TimesheetHandler) DeleteEntry(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid entry ID")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	if err := h.timesheetService.DeleteEntry(userID.(uint), uint(id)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Timesheet entry deleted successfully", nil)
+}
+
+func (h *TimesheetHandler) GetMyTimesheets(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	timesheets, err := h.timesheetService.GetMyTimesheets(userID.(uint))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Timesheets retrieved successfully", timesheets)
+}
+
+func (h *TimesheetHandler) GetMyTimesheet(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid timesheet ID")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	detail, err := h.timesheetService.GetMyTimesheet(userID.(uint), uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Timesheet retrieved successfully", detail)
+}
+
+func (h *TimesheetHandler) SubmitTimesheet(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid timesheet ID")
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	detail, err := h.timesheetService.SubmitTimesheet(userID.(uint), uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "SUBMIT_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Timesheet submitted successfully", detail)
+}
+
+func (h *TimesheetHandler) GetTimesheets(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	timesheets, total, err := h.timesheetService.GetTimesheets(c.Query("status"), optionalUintQuery(c, "employee_id"), optionalUintQuery(c, "department_id"), page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, timesheets, total, page, limit)
+}
+
+func (h *TimesheetHandler) GetTimesheetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid timesheet ID")
+		return
+	}
+
+	detail, err := h.timesheetService.GetTimesheetByID(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Timesheet retrieved successfully", detail)
+}
+
+func (h *TimesheetHandler) ApproveTimesheet(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid timesheet ID")
+		return
+	}
+
+	var req models.ReviewTimesheetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	timesheet, err := h.timesheetService.ApproveTimesheet(uint(id), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "APPROVAL_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Timesheet approved successfully", timesheet)
+}
+
+func (h *TimesheetHandler) RejectTimesheet(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid timesheet ID")
+		return
+	}
+
+	var req models.ReviewTimesheetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	timesheet, err := h.timesheetService.RejectTimesheet(uint(id), userID.(uint), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "REJECT_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Timesheet rejected successfully", timesheet)
+}
+
+// GetUtilizationReport shows logged and billable hours against working hours per employee
+func (h *TimesheetHandler) GetUtilizationReport(c *gin.Context) {
+	filter, ok := timesheetReportFilter(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.timesheetService.GetUtilizationReport(filter)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Utilization report retrieved successfully", report)
+}
+
+// GetProjectReport shows the hours and billable amount logged per project
+func (h *TimesheetHandler) GetProjectReport(c *gin.Context) {
+	filter, ok := timesheetReportFilter(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.timesheetService.GetProjectReport(filter)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Project report retrieved successfully", report)
+}
+
+// timesheetReportFilter reads the date range, department_id and project_id,
+// writing the error response when the dates are invalid
+func timesheetReportFilter(c *gin.Context) (models.TimesheetReportFilter, bool) {
+	startDate, endDate, ok := rosterRange(c)
+	if !ok {
+		return models.TimesheetReportFilter{}, false
+	}
+
+	return models.TimesheetReportFilter{
+		StartDate:    startDate,
+		EndDate:      endDate,
+		DepartmentID: optionalUintQuery(c, "department_id"),
+		ProjectID:    optionalUintQuery(c, "project_id"),
+	}, true
+}
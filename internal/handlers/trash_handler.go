@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// trashEntities maps the route segment to the trash entity
+var trashEntities = map[string]string{
+	"karyawan":   models.TrashEntityEmployee,
+	"departemen": models.TrashEntityDepartment,
+	"cuti":       models.TrashEntityLeave,
+}
+
+type TrashHandler struct {
+	trashService *services.TrashService
+}
+
+func NewTrashHandler(trashService *services.TrashService) *TrashHandler {
+	return &TrashHandler{trashService: trashService}
+}
+
+func (h *TrashHandler) GetTrash(c *gin.Context) {
+	entity, ok := trashEntities[c.Param("entity")]
+	if !ok {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", "Unknown trash entity")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	records, total, err := h.trashService.GetTrash(entity, page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, records, total, page, limit)
+}
+
+func (h *TrashHandler) Restore(c *gin.Context) {
+	entity, ok := trashEntities[c.Param("entity")]
+	if !ok {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", "Unknown trash entity")
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid ID")
+		return
+	}
+
+	if err := h.trashService.Restore(entity, uint(id)); err != nil {
+		utils.ErrorResponse(c, 400, "RESTORE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Record restored successfully", nil)
+}
+
+func (h *TrashHandler) Purge(c *gin.Context) {
+	entity, ok := trashEntities[c.Param("entity")]
+	if !ok {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", "Unknown trash entity")
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid ID")
+		return
+	}
+
+	if err := h.trashService.Purge(entity, uint(id)); err != nil {
+		utils.ErrorResponse(c, 400, "PURGE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Record permanently deleted", nil)
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/services"
+	"hr-backend/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkLocationHandler struct {
+	locationService *services.WorkLocationService
+}
+
+func NewWorkLocationHandler(locationService *services.WorkLocationService) *WorkLocationHandler {
+	return &WorkLocationHandler{locationService: locationService}
+}
+
+func (h *WorkLocationHandler) GetLocations(c *gin.Context) {
+	locations, err := h.locationService.GetLocations()
+	if err != nil {
+		utils.ErrorResponse(c, 500, "FETCH_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Work locations retrieved successfully", locations)
+}
+
+func (h *WorkLocationHandler) GetLocationByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid work location ID")
+		return
+	}
+
+	location, err := h.locationService.GetLocationByID(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Work location retrieved successfully", location)
+}
+
+func (h *WorkLocationHandler) CreateLocation(c *gin.Context) {
+	var req models.WorkLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	location, err := h.locationService.CreateLocation(&req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Work location created successfully", location)
+}
+
+func (h *WorkLocationHandler) UpdateLocation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid work location ID")
+		return
+	}
+
+	var req models.WorkLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	location, err := h.locationService.UpdateLocation(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Work location updated successfully", location)
+}
+
+func (h *WorkLocationHandler) DeleteLocation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid work location ID")
+		return
+	}
+
+	if err := h.locationService.DeleteLocation(uint(id)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Work location deleted successfully", nil)
+}
+
+func (h *WorkLocationHandler) GetGeofences(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid work location ID")
+		return
+	}
+
+	geofences, err := h.locationService.GetGeofences(uint(id))
+	if err != nil {
+		utils.ErrorResponse(c, 404, "NOT_FOUND", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Geofences retrieved successfully", geofences)
+}
+
+func (h *WorkLocationHandler) CreateGeofence(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid work location ID")
+		return
+	}
+
+	var req models.GeofenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	geofence, err := h.locationService.CreateGeofence(uint(id), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 201, "Geofence created successfully", geofence)
+}
+
+func (h *WorkLocationHandler) UpdateGeofence(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid work location ID")
+		return
+	}
+
+	geofenceID, err := strconv.ParseUint(c.Param("geofence_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid geofence ID")
+		return
+	}
+
+	var req models.GeofenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	geofence, err := h.locationService.UpdateGeofence(uint(id), uint(geofenceID), &req)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Geofence updated successfully", geofence)
+}
+
+func (h *WorkLocationHandler) DeleteGeofence(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid work location ID")
+		return
+	}
+
+	geofenceID, err := strconv.ParseUint(c.Param("geofence_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "INVALID_ID", "Invalid geofence ID")
+		return
+	}
+
+	if err := h.locationService.DeleteGeofence(uint(id), uint(geofenceID)); err != nil {
+		utils.ErrorResponse(c, 400, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, 200, "Geofence deleted successfully", nil)
+}
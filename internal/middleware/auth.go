@@ -59,3 +59,26 @@ func RoleMiddleware(allowedRoles ...string) gin.HandlerFunc {
 		c.Abort()
 	}
 }
+
+// KioskMiddleware authenticates a kiosk by the credential in the X-Kiosk-Token
+// header and stores its ID as kiosk_id
+func KioskMiddleware(authenticate func(token string) (uint, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimSpace(c.GetHeader("X-Kiosk-Token"))
+		if token == "" {
+			utils.ErrorResponse(c, 401, "UNAUTHORIZED", "Kiosk credential required")
+			c.Abort()
+			return
+		}
+
+		kioskID, err := authenticate(token)
+		if err != nil {
+			utils.ErrorResponse(c, 401, "UNAUTHORIZED", "Invalid kiosk credential")
+			c.Abort()
+			return
+		}
+
+		c.Set("kiosk_id", kioskID)
+		c.Next()
+	}
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	AssetAvailable = "available"
+	AssetAssigned  = "assigned"
+	AssetRetired   = "retired"
+	AssetLost      = "lost"
+)
+
+type Asset struct {
+	BaseModel
+	AssetTag           string     `gorm:"uniqueIndex;not null" json:"asset_tag"`
+	Type               string     `gorm:"not null;index" json:"type"`
+	Name               string     `gorm:"not null" json:"name"`
+	SerialNumber       string     `gorm:"index" json:"serial_number"`
+	PurchaseDate       *time.Time `gorm:"type:date" json:"purchase_date"`
+	PurchaseCost       float64    `json:"purchase_cost"`
+	Condition          string     `gorm:"not null" json:"condition"`
+	Status             string     `gorm:"default:'available';index" json:"status"`
+	AssignedEmployeeID *uint      `gorm:"index" json:"assigned_employee_id"`
+	AssignedEmployee   *Employee  `gorm:"foreignKey:AssignedEmployeeID" json:"assigned_employee,omitempty"`
+	AssignedAt         *time.Time `gorm:"type:date" json:"assigned_at"`
+	Notes              string     `json:"notes"`
+}
+
+// AssetAssignment records one period an asset was held by an employee
+type AssetAssignment struct {
+	BaseModel
+	AssetID      uint       `gorm:"not null;index" json:"asset_id"`
+	Asset        *Asset     `json:"asset,omitempty"`
+	EmployeeID   uint       `gorm:"not null;index" json:"employee_id"`
+	Employee     *Employee  `json:"employee,omitempty"`
+	AssignedAt   time.Time  `gorm:"type:date;not null" json:"assigned_at"`
+	AssignedBy   uint       `gorm:"not null" json:"assigned_by"`
+	ConditionOut string     `json:"condition_out"`
+	ReturnedAt   *time.Time `gorm:"type:date" json:"returned_at"`
+	ReceivedBy   *uint      `json:"received_by"`
+	ConditionIn  string     `json:"condition_in"`
+	Lost         bool       `gorm:"default:false" json:"lost"`
+	Notes        string     `json:"notes"`
+	ReturnNotes  string     `json:"return_notes"`
+}
+
+type CreateAssetRequest struct {
+	AssetTag     string        `json:"asset_tag" binding:"required"`
+	Type         string        `json:"type" binding:"required,oneof=laptop desktop monitor phone tablet id_card vehicle other"`
+	Name         string        `json:"name" binding:"required"`
+	SerialNumber string        `json:"serial_number"`
+	PurchaseDate *FlexibleDate `json:"purchase_date"`
+	PurchaseCost float64       `json:"purchase_cost" binding:"min=0"`
+	Condition    string        `json:"condition" binding:"required,oneof=new good fair poor damaged"`
+	Notes        string        `json:"notes"`
+}
+
+// UpdateAssetRequest edits the registry data. Status can only move between
+// available, retired and lost while the asset is not assigned.
+type UpdateAssetRequest struct {
+	Type         string        `json:"type" binding:"required,oneof=laptop desktop monitor phone tablet id_card vehicle other"`
+	Name         string        `json:"name" binding:"required"`
+	SerialNumber string        `json:"serial_number"`
+	PurchaseDate *FlexibleDate `json:"purchase_date"`
+	PurchaseCost float64       `json:"purchase_cost" binding:"min=0"`
+	Condition    string        `json:"condition" binding:"required,oneof=new good fair poor damaged"`
+	Status       string        `json:"status" binding:"omitempty,oneof=available retired lost"`
+	Notes        string        `json:"notes"`
+}
+
+type AssignAssetRequest struct {
+	EmployeeID uint          `json:"employee_id" binding:"required"`
+	AssignedAt *FlexibleDate `json:"assigned_at"`
+	Notes      string        `json:"notes"`
+}
+
+// ReturnAssetRequest closes the current assignment. Lost marks the asset as lost
+// instead of returning it to stock.
+type ReturnAssetRequest struct {
+	ReturnedAt *FlexibleDate `json:"returned_at"`
+	Condition  string        `json:"condition" binding:"required_unless=Lost true,omitempty,oneof=new good fair poor damaged"`
+	Lost       bool          `json:"lost"`
+	Notes      string        `json:"notes"`
+}
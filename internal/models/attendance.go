@@ -3,6 +3,8 @@ package models
 import (
 	"encoding/json"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // FlexibleDate is a custom type that can unmarshal both date-only strings and full timestamps
@@ -38,6 +40,39 @@ func (fd FlexibleDate) MarshalJSON() ([]byte, error) {
 	return json.Marshal(fd.Time)
 }
 
+// Geofence results of a clock-in or clock-out position
+const (
+	GeofenceInside        = "inside"
+	GeofenceOutside       = "outside"
+	GeofenceLowAccuracy   = "low_accuracy"
+	GeofenceNoPosition    = "no_position"
+	GeofenceNotConfigured = "not_configured"
+	// GeofenceRemote marks punches on an approved remote work day, which are not checked
+	GeofenceRemote = "remote"
+	// GeofenceKiosk marks punches made at a kiosk, whose site vouches for the position
+	GeofenceKiosk = "kiosk"
+)
+
+// Sources of an attendance record
+const (
+	AttendanceSourceApp    = "app"
+	AttendanceSourceManual = "manual"
+	AttendanceSourceDevice = "device"
+	// AttendanceSourceCorrection marks punches added by an approved correction
+	AttendanceSourceCorrection = "correction"
+	// AttendanceSourceSystem marks absences and clock-outs recorded by the end-of-day job
+	AttendanceSourceSystem = "system"
+	// AttendanceSourceKiosk marks punches made at a shared kiosk
+	AttendanceSourceKiosk = "kiosk"
+)
+
+// Review states of attendance flagged because of its position
+const (
+	AttendanceReviewPending  = "pending"
+	AttendanceReviewApproved = "approved"
+	AttendanceReviewRejected = "rejected"
+)
+
 type Attendance struct {
 	BaseModel
 	EmployeeID    uint       `gorm:"not null" json:"employee_id" binding:"required"`
@@ -46,30 +81,136 @@ type Attendance struct {
 	ClockIn       *time.Time `json:"clock_in"`
 	ClockOut      *time.Time `json:"clock_out"`
 	WorkingHours  float64    `json:"working_hours"`
+	BreakMinutes  int        `json:"break_minutes"`
 	OvertimeHours float64    `json:"overtime_hours"`
-	Status        string     `gorm:"default:'present'" json:"status"`
-	Notes         string     `json:"notes"`
+	// ApprovedOvertimeHours is the part of OvertimeHours covered by an approved request
+	ApprovedOvertimeHours float64    `json:"approved_overtime_hours"`
+	OvertimeRequestID     *uint      `json:"overtime_request_id"`
+	ShiftID               *uint      `json:"shift_id"`
+	Shift                 *Shift     `json:"shift,omitempty"`
+	ScheduledStart        *time.Time `json:"scheduled_start"`
+	ScheduledEnd          *time.Time `json:"scheduled_end"`
+	LateMinutes           int        `json:"late_minutes"`
+	EarlyLeaveMinutes     int        `json:"early_leave_minutes"`
+	DayType               string     `json:"day_type"`
+	Status                string     `gorm:"default:'present'" json:"status"`
+	Notes                 string     `json:"notes"`
+	Source                string     `gorm:"default:'app'" json:"source"`
+	// AutoClosed is set when the end-of-day job clocked out a session left open
+	AutoClosed        bool     `json:"auto_closed"`
+	ClockInLatitude   *float64 `json:"clock_in_latitude"`
+	ClockInLongitude  *float64 `json:"clock_in_longitude"`
+	ClockInAccuracy   *float64 `json:"clock_in_accuracy"`
+	ClockInGeofence   string   `json:"clock_in_geofence"`
+	ClockInPhoto      string   `json:"-"`
+	ClockOutLatitude  *float64 `json:"clock_out_latitude"`
+	ClockOutLongitude *float64 `json:"clock_out_longitude"`
+	ClockOutAccuracy  *float64 `json:"clock_out_accuracy"`
+	ClockOutGeofence  string   `json:"clock_out_geofence"`
+	ClockOutPhoto     string   `json:"-"`
+	// ReviewStatus is pending while a punch outside the geofence awaits a manager
+	ReviewStatus string     `gorm:"index" json:"review_status,omitempty"`
+	ReviewedBy   *uint      `json:"reviewed_by,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNotes  string     `json:"review_notes,omitempty"`
+	// Photo flags tell clients whether a selfie can be downloaded
+	HasClockInPhoto  bool `gorm:"-" json:"has_clock_in_photo"`
+	HasClockOutPhoto bool `gorm:"-" json:"has_clock_out_photo"`
+}
+
+// AfterFind sets the photo flags, since the storage paths themselves are never exposed
+func (a *Attendance) AfterFind(tx *gorm.DB) error {
+	a.HasClockInPhoto = a.ClockInPhoto != ""
+	a.HasClockOutPhoto = a.ClockOutPhoto != ""
+	return nil
 }
 
+// ClockInRequest carries the device position; the time is taken from the server
 type ClockInRequest struct {
-	EmployeeID uint         `json:"employee_id" binding:"required"`
-	Date       FlexibleDate `json:"date" binding:"required"`
-	ClockIn    time.Time    `json:"clock_in" binding:"required"`
+	Latitude  *float64 `form:"latitude" json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `form:"longitude" json:"longitude" binding:"omitempty,min=-180,max=180"`
+	Accuracy  *float64 `form:"accuracy" json:"accuracy" binding:"omitempty,min=0"`
 }
 
 type ClockOutRequest struct {
-	EmployeeID uint         `json:"employee_id" binding:"required"`
-	Date       FlexibleDate `json:"date" binding:"required"`
-	ClockOut   time.Time    `json:"clock_out" binding:"required"`
+	Latitude  *float64 `form:"latitude" json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `form:"longitude" json:"longitude" binding:"omitempty,min=-180,max=180"`
+	Accuracy  *float64 `form:"accuracy" json:"accuracy" binding:"omitempty,min=0"`
+}
+
+type ReviewAttendanceRequest struct {
+	Status string `json:"status" binding:"required,oneof=approved rejected"`
+	Notes  string `json:"notes"`
 }
 
+// MarkAbsencesRequest runs the end-of-day job again for a past date
+type MarkAbsencesRequest struct {
+	Date FlexibleDate `json:"date" binding:"required"`
+}
+
+// AbsenceMarkingResult counts what the end-of-day job recorded for a date
+type AbsenceMarkingResult struct {
+	Date       time.Time `json:"date"`
+	Absent     int       `json:"absent"`
+	AutoClosed int       `json:"auto_closed"`
+}
+// Day statuses in the attendance report
+const (
+	AttendanceDayPresent  = "present"
+	AttendanceDayAbsent   = "absent"
+	AttendanceDayOnLeave  = "on_leave"
+	AttendanceDayOff      = "day_off"
+	AttendanceDayHoliday  = "holiday"
+	AttendanceDayUpcoming = "upcoming"
+	// AttendanceDayRemote is an approved remote work day; attendance on it has this status too
+	AttendanceDayRemote = "remote"
+)
+
+// AttendedStatuses are the statuses of attendance records where the employee showed up
+var AttendedStatuses = []string{AttendanceDayPresent, "late", AttendanceDayRemote}
+
+// AttendanceReport totals an employee's attendance over a date range.
+// TotalDays counts the scheduled working days up to today.
 type AttendanceReport struct {
-	EmployeeID    uint    `json:"employee_id"`
-	EmployeeName  string  `json:"employee_name"`
-	TotalDays     int     `json:"total_days"`
-	PresentDays   int     `json:"present_days"`
-	AbsentDays    int     `json:"absent_days"`
-	LateDays      int     `json:"late_days"`
-	TotalHours    float64 `json:"total_hours"`
-	OvertimeHours float64 `json:"overtime_hours"`
+	EmployeeID        uint                  `json:"employee_id"`
+	EmployeeCode      string                `json:"employee_code"`
+	EmployeeName      string                `json:"employee_name"`
+	DepartmentID      *uint                 `json:"department_id"`
+	DepartmentName    string                `json:"department_name"`
+	TotalDays         int                   `json:"total_days"`
+	PresentDays       int                   `json:"present_days"`
+	AbsentDays        int                   `json:"absent_days"`
+	LateDays          int                   `json:"late_days"`
+	EarlyLeaveDays    int                   `json:"early_leave_days"`
+	LeaveDays         int                   `json:"leave_days"`
+	RemoteDays        int                   `json:"remote_days"`
+	LateMinutes       int                   `json:"late_minutes"`
+	EarlyLeaveMinutes int                   `json:"early_leave_minutes"`
+	TotalHours        float64               `json:"total_hours"`
+	OvertimeHours     float64               `json:"overtime_hours"`
+	Days              []AttendanceReportDay `json:"days,omitempty"`
+}
+
+// AttendanceReportDay is one day of an employee's attendance report
+type AttendanceReportDay struct {
+	Date              time.Time `json:"date"`
+	Status            string    `json:"status"`
+	ShiftID           *uint     `json:"shift_id"`
+	Late              bool      `json:"late"`
+	EarlyLeave        bool      `json:"early_leave"`
+	LateMinutes       int       `json:"late_minutes"`
+	EarlyLeaveMinutes int       `json:"early_leave_minutes"`
+	WorkingHours      float64   `json:"working_hours"`
+	AutoClosed        bool      `json:"auto_closed,omitempty"`
+	LeaveType         string    `json:"leave_type,omitempty"`
+	HolidayName       string    `json:"holiday_name,omitempty"`
+}
+
+type AttendanceReportFilter struct {
+	StartDate    time.Time
+	EndDate      time.Time
+	DepartmentID *uint
+	Page         int
+	Limit        int
+	IncludeDays  bool
 }
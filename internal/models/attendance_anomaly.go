@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Kinds of suspicious attendance
+const (
+	// AnomalySharedPunchTime is a punch made at the same second as other employees' punches
+	AnomalySharedPunchTime = "shared_punch_time"
+	// AnomalyImpossibleTravel is a punch too far from the previous one to have been reached in time
+	AnomalyImpossibleTravel = "impossible_travel"
+	// AnomalyRoundManualEntry is a manual entry with round times from an employee who has several
+	AnomalyRoundManualEntry = "round_manual_entry"
+	// AnomalyLongWorkday is a day with implausibly many working hours
+	AnomalyLongWorkday = "long_workday"
+)
+
+// AttendanceAnomalyReason explains part of an anomaly's score
+type AttendanceAnomalyReason struct {
+	Code               string `json:"code"`
+	Description        string `json:"description"`
+	Score              int    `json:"score"`
+	RelatedEmployeeIDs []uint `json:"related_employee_ids,omitempty"`
+}
+
+// AttendanceAnomaly is an attendance record that looks suspicious. Score adds up
+// the reasons and is capped at 100; higher is more suspicious.
+type AttendanceAnomaly struct {
+	AttendanceID uint                      `json:"attendance_id"`
+	EmployeeID   uint                      `json:"employee_id"`
+	EmployeeName string                    `json:"employee_name"`
+	DepartmentID *uint                     `json:"department_id"`
+	Date         time.Time                 `json:"date"`
+	Source       string                    `json:"source"`
+	ClockIn      *time.Time                `json:"clock_in"`
+	ClockOut     *time.Time                `json:"clock_out"`
+	WorkingHours float64                   `json:"working_hours"`
+	Score        int                       `json:"score"`
+	Reasons      []AttendanceAnomalyReason `json:"reasons"`
+}
+
+type AttendanceAnomalyFilter struct {
+	StartDate    time.Time
+	EndDate      time.Time
+	DepartmentID *uint
+	MinScore     int
+	Page         int
+	Limit        int
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Kinds of attendance correction
+const (
+	AttendanceCorrectionMissingPunch = "missing_punch"
+	AttendanceCorrectionWrongTime    = "wrong_time"
+)
+
+const (
+	AttendanceCorrectionPending   = "pending"
+	AttendanceCorrectionApproved  = "approved"
+	AttendanceCorrectionRejected  = "rejected"
+	AttendanceCorrectionCancelled = "cancelled"
+)
+
+// AttendanceCorrection is an employee's request to add a forgotten punch or to
+// move a punch recorded at the wrong time. Approval appends the corrected punch
+// to the punch log; the Original fields keep the day as it was before.
+type AttendanceCorrection struct {
+	BaseModel
+	EmployeeID    uint        `gorm:"not null;index" json:"employee_id"`
+	Employee      *Employee   `gorm:"constraint:OnDelete:CASCADE;" json:"employee,omitempty"`
+	Date          time.Time   `gorm:"type:date;not null" json:"date"`
+	Type          string      `gorm:"not null" json:"type"`
+	PunchType     string      `gorm:"not null" json:"punch_type"`
+	PunchEventID  *uint       `json:"punch_event_id"`
+	PunchEvent    *PunchEvent `json:"punch_event,omitempty"`
+	RequestedTime time.Time   `gorm:"not null" json:"requested_time"`
+	Reason        string      `gorm:"not null" json:"reason"`
+	EvidencePath  string      `json:"-"`
+	EvidenceName  string      `json:"evidence_name"`
+	EvidenceType  string      `json:"evidence_type"`
+	Status        string      `gorm:"default:'pending';index" json:"status"`
+	ReviewedBy    *uint       `json:"reviewed_by"`
+	ReviewedAt    *time.Time  `json:"reviewed_at"`
+	ReviewNotes   string      `json:"review_notes"`
+	AttendanceID  *uint       `json:"attendance_id"`
+	// Original values of the day, recorded when the correction is applied
+	OriginalClockIn       *time.Time `json:"original_clock_in"`
+	OriginalClockOut      *time.Time `json:"original_clock_out"`
+	OriginalWorkingHours  float64    `json:"original_working_hours"`
+	OriginalOvertimeHours float64    `json:"original_overtime_hours"`
+	OriginalStatus        string     `json:"original_status"`
+	HasEvidence           bool       `gorm:"-" json:"has_evidence"`
+}
+
+// AfterFind sets the evidence flag, since the storage path itself is never exposed
+func (c *AttendanceCorrection) AfterFind(tx *gorm.DB) error {
+	c.HasEvidence = c.EvidencePath != ""
+	return nil
+}
+
+// SubmitAttendanceCorrectionRequest is sent as a multipart form so evidence can be
+// attached as "evidence". RequestedTime is a local time such as 2024-01-15T17:30.
+type SubmitAttendanceCorrectionRequest struct {
+	Date          string `form:"date" json:"date" binding:"required"`
+	Type          string `form:"type" json:"type" binding:"required,oneof=missing_punch wrong_time"`
+	PunchType     string `form:"punch_type" json:"punch_type" binding:"required,oneof=in out break_start break_end"`
+	PunchEventID  *uint  `form:"punch_event_id" json:"punch_event_id"`
+	RequestedTime string `form:"requested_time" json:"requested_time" binding:"required"`
+	Reason        string `form:"reason" json:"reason" binding:"required"`
+}
+
+type ReviewAttendanceCorrectionRequest struct {
+	Notes string `json:"notes"`
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"time"
+)
+
+// Skill proficiency levels, from lowest to highest
+const (
+	SkillLevelBeginner     = "beginner"
+	SkillLevelIntermediate = "intermediate"
+	SkillLevelAdvanced     = "advanced"
+	SkillLevelExpert       = "expert"
+)
+
+var skillLevels = []string{SkillLevelBeginner, SkillLevelIntermediate, SkillLevelAdvanced, SkillLevelExpert}
+
+// SkillLevelsAtLeast returns the given level and every level above it
+func SkillLevelsAtLeast(level string) []string {
+	for i, l := range skillLevels {
+		if l == level {
+			return skillLevels[i:]
+		}
+	}
+	return nil
+}
+
+// Certification validity, derived from the expiry date
+const (
+	CertificationValid    = "valid"
+	CertificationExpiring = "expiring"
+	CertificationExpired  = "expired"
+)
+
+type EmployeeSkill struct {
+	BaseModel
+	EmployeeID uint      `gorm:"not null;uniqueIndex:idx_employee_skill" json:"employee_id"`
+	Employee   *Employee `gorm:"constraint:OnDelete:CASCADE;" json:"employee,omitempty"`
+	Name       string    `gorm:"not null;uniqueIndex:idx_employee_skill" json:"name"`
+	Level      string    `gorm:"not null" json:"level"`
+	Notes      string    `json:"notes"`
+}
+
+type Certification struct {
+	BaseModel
+	EmployeeID        uint                `gorm:"not null;index" json:"employee_id"`
+	Employee          *Employee           `gorm:"constraint:OnDelete:CASCADE;" json:"employee,omitempty"`
+	Name              string              `gorm:"not null;index" json:"name"`
+	Issuer            string              `json:"issuer"`
+	CertificateNumber string              `json:"certificate_number"`
+	IssueDate         time.Time           `gorm:"type:date;not null" json:"issue_date"`
+	ExpiryDate        *time.Time          `gorm:"type:date;index" json:"expiry_date"`
+	Files             []CertificationFile `gorm:"foreignKey:CertificationID;constraint:OnDelete:CASCADE;" json:"files,omitempty"`
+	// Status is computed on read from the expiry date
+	Status string `gorm:"-" json:"status"`
+	// Set once the expiry reminder went out; cleared when the expiry date changes
+	RemindedAt *time.Time `json:"-"`
+}
+
+// CertificationFile is an uploaded scan of a certificate or license
+type CertificationFile struct {
+	BaseModel
+	CertificationID uint   `gorm:"not null;index" json:"certification_id"`
+	FileName        string `gorm:"not null" json:"file_name"`
+	ContentType     string `json:"content_type"`
+	Size            int64  `json:"size"`
+	StoragePath     string `gorm:"not null" json:"-"`
+}
+
+type SkillRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Level string `json:"level" binding:"required,oneof=beginner intermediate advanced expert"`
+	Notes string `json:"notes"`
+}
+
+type CertificationRequest struct {
+	Name              string        `json:"name" binding:"required"`
+	Issuer            string        `json:"issuer"`
+	CertificateNumber string        `json:"certificate_number"`
+	IssueDate         FlexibleDate  `json:"issue_date" binding:"required"`
+	ExpiryDate        *FlexibleDate `json:"expiry_date"`
+}
+
+// CertificationFilter narrows the certification search. ValidOn finds holders whose
+// certificate is valid on that date; ExpiringBefore finds certificates that lapse by then.
+type CertificationFilter struct {
+	Name           string
+	EmployeeID     *uint
+	DepartmentID   *uint
+	ValidOn        *time.Time
+	ExpiringBefore *time.Time
+}
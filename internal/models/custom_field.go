@@ -0,0 +1,52 @@
+package models
+
+const (
+	CustomFieldEntityEmployee = "employee"
+
+	CustomFieldTypeText        = "text"
+	CustomFieldTypeNumber      = "number"
+	CustomFieldTypeDate        = "date"
+	CustomFieldTypeBoolean     = "boolean"
+	CustomFieldTypeSelect      = "select"
+	CustomFieldTypeMultiSelect = "multi_select"
+)
+
+type CustomFieldDefinition struct {
+	BaseModel
+	EntityType      string     `gorm:"not null;uniqueIndex:idx_custom_field_entity_key" json:"entity_type"`
+	Key             string     `gorm:"not null;uniqueIndex:idx_custom_field_entity_key" json:"key"`
+	Label           string     `gorm:"not null" json:"label"`
+	FieldType       string     `gorm:"not null" json:"field_type"`
+	Required        bool       `gorm:"default:false" json:"required"`
+	Options         StringList `json:"options"`
+	ValidationRegex string     `json:"validation_regex"`
+	VisibleToRoles  StringList `json:"visible_to_roles"`
+	SortOrder       int        `gorm:"default:0" json:"sort_order"`
+}
+
+// IsVisibleTo reports whether a user with the given role may see the field.
+// An empty role list means the field is visible to everyone.
+func (d *CustomFieldDefinition) IsVisibleTo(role string) bool {
+	return len(d.VisibleToRoles) == 0 || d.VisibleToRoles.Contains(role)
+}
+
+type CreateCustomFieldRequest struct {
+	EntityType      string   `json:"entity_type" binding:"required,oneof=employee"`
+	Key             string   `json:"key" binding:"required"`
+	Label           string   `json:"label" binding:"required"`
+	FieldType       string   `json:"field_type" binding:"required,oneof=text number date boolean select multi_select"`
+	Required        bool     `json:"required"`
+	Options         []string `json:"options"`
+	ValidationRegex string   `json:"validation_regex"`
+	VisibleToRoles  []string `json:"visible_to_roles" binding:"dive,oneof=admin hr_manager department_manager employee"`
+	SortOrder       int      `json:"sort_order"`
+}
+
+type UpdateCustomFieldRequest struct {
+	Label           string   `json:"label"`
+	Required        *bool    `json:"required"`
+	Options         []string `json:"options"`
+	ValidationRegex *string  `json:"validation_regex"`
+	VisibleToRoles  []string `json:"visible_to_roles" binding:"dive,oneof=admin hr_manager department_manager employee"`
+	SortOrder       *int     `json:"sort_order"`
+}
@@ -7,4 +7,8 @@ type Department struct {
 	ManagerID   *uint      `json:"manager_id"`
 	Manager     *Employee  `gorm:"foreignKey:ManagerID" json:"manager,omitempty"`
 	Employees   []Employee `gorm:"foreignKey:DepartmentID" json:"employees,omitempty"`
+
+	// RemoteWorkPolicyID overrides the default remote work policy for the department
+	RemoteWorkPolicyID *uint             `json:"remote_work_policy_id"`
+	RemoteWorkPolicy   *RemoteWorkPolicy `json:"remote_work_policy,omitempty"`
 }
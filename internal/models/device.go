@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// AttendanceDevice is a fingerprint or card terminal whose punch logs are imported
+type AttendanceDevice struct {
+	BaseModel
+	Name           string        `gorm:"uniqueIndex;not null" json:"name"`
+	SerialNumber   string        `json:"serial_number"`
+	WorkLocationID *uint         `json:"work_location_id"`
+	WorkLocation   *WorkLocation `json:"work_location,omitempty"`
+	IsActive       bool          `gorm:"default:true" json:"is_active"`
+}
+
+// DeviceUser maps the user ID (PIN) enrolled on a device to an employee
+type DeviceUser struct {
+	BaseModel
+	DeviceID     uint      `gorm:"not null;uniqueIndex:idx_device_users_pin" json:"device_id"`
+	DeviceUserID string    `gorm:"not null;uniqueIndex:idx_device_users_pin" json:"device_user_id"`
+	EmployeeID   uint      `gorm:"not null;index" json:"employee_id"`
+	Employee     *Employee `gorm:"constraint:OnDelete:CASCADE;" json:"employee,omitempty"`
+}
+
+// DeviceImport records an imported punch log; the file hash makes re-uploads no-ops
+type DeviceImport struct {
+	BaseModel
+	DeviceID      uint      `gorm:"not null;uniqueIndex:idx_device_imports_hash" json:"device_id"`
+	FileName      string    `json:"file_name"`
+	FileHash      string    `gorm:"not null;uniqueIndex:idx_device_imports_hash" json:"-"`
+	ImportedBy    uint      `json:"imported_by"`
+	ImportedAt    time.Time `json:"imported_at"`
+	Rows          int       `json:"rows"`
+	Punches       int       `json:"punches"`
+	Duplicates    int       `json:"duplicates"`
+	Created       int       `json:"created"`
+	Updated       int       `json:"updated"`
+	Unchanged     int       `json:"unchanged"`
+	UnmatchedRows int       `json:"unmatched_rows"`
+	InvalidRows   int       `json:"invalid_rows"`
+}
+
+// DeviceImportIssue describes a row or day that was not applied as-is
+type DeviceImportIssue struct {
+	Line         int    `json:"line,omitempty"`
+	DeviceUserID string `json:"device_user_id,omitempty"`
+	EmployeeID   uint   `json:"employee_id,omitempty"`
+	Date         string `json:"date,omitempty"`
+	Text         string `json:"text,omitempty"`
+	Reason       string `json:"reason"`
+}
+
+// DeviceImportResult is the outcome of an import. AlreadyImported is set when the
+// same file was imported before, in which case nothing was changed.
+type DeviceImportResult struct {
+	Import          *DeviceImport       `json:"import"`
+	AlreadyImported bool                `json:"already_imported"`
+	Unmatched       []DeviceImportIssue `json:"unmatched"`
+	Invalid         []DeviceImportIssue `json:"invalid"`
+	Warnings        []DeviceImportIssue `json:"warnings"`
+}
+
+type AttendanceDeviceRequest struct {
+	Name           string `json:"name" binding:"required"`
+	SerialNumber   string `json:"serial_number"`
+	WorkLocationID *uint  `json:"work_location_id"`
+	IsActive       *bool  `json:"is_active"`
+}
+
+type DeviceUserRequest struct {
+	DeviceUserID string `json:"device_user_id" binding:"required"`
+	EmployeeID   uint   `json:"employee_id" binding:"required"`
+}
+
+// SetDeviceUsersRequest adds or re-points device user mappings
+type SetDeviceUsersRequest struct {
+	Users []DeviceUserRequest `json:"users" binding:"required,min=1,dive"`
+}
@@ -1,27 +1,78 @@
 package models
 
 import (
+	"hr-backend/internal/fieldcrypt"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Employee struct {
 	BaseModel
-	UserID           *uint       `gorm:"uniqueIndex" json:"user_id"`
-	User             *User       `gorm:"constraint:OnDelete:CASCADE;" json:"user,omitempty"`
-	EmployeeCode     string      `gorm:"uniqueIndex;not null" json:"employee_code" binding:"required"`
-	FirstName        string      `gorm:"not null" json:"first_name" binding:"required"`
-	LastName         string      `gorm:"not null" json:"last_name" binding:"required"`
-	DateOfBirth      *time.Time  `json:"date_of_birth"`
-	Gender           string      `json:"gender"`
-	Phone            string      `json:"phone"`
-	Address          string      `json:"address"`
-	DepartmentID     *uint       `json:"department_id"`
-	Department       *Department `gorm:"foreignKey:DepartmentID" json:"department,omitempty"`
-	Position         string      `json:"position"`
-	HireDate         time.Time   `gorm:"not null" json:"hire_date" binding:"required"`
-	EmploymentStatus string      `gorm:"default:'active'" json:"employment_status"`
-	Salary           float64     `json:"salary"`
-	ProfilePicture   string      `json:"profile_picture"`
+	UserID            *uint               `gorm:"uniqueIndex" json:"user_id"`
+	User              *User               `gorm:"constraint:OnDelete:CASCADE;" json:"user,omitempty"`
+	EmployeeCode      string              `gorm:"uniqueIndex;not null" json:"employee_code" binding:"required"`
+	FirstName         string              `gorm:"not null" json:"first_name" binding:"required"`
+	LastName          string              `gorm:"not null" json:"last_name" binding:"required"`
+	DateOfBirth       *EncryptedDate      `json:"date_of_birth"`
+	Gender            string              `json:"gender"`
+	Phone             EncryptedString     `json:"phone"`
+	Address           EncryptedString     `json:"address"`
+	NationalID        EncryptedString     `json:"national_id"`
+	DepartmentID      *uint               `json:"department_id"`
+	Department        *Department         `gorm:"foreignKey:DepartmentID" json:"department,omitempty"`
+	WorkLocationID    *uint               `json:"work_location_id"`
+	WorkLocation      *WorkLocation       `json:"work_location,omitempty"`
+	Timezone          string              `json:"timezone"`
+	Position          string              `json:"position"`
+	HireDate          time.Time           `gorm:"not null" json:"hire_date" binding:"required"`
+	EmploymentStatus  string              `gorm:"default:'active'" json:"employment_status"`
+	ProbationEndDate  *time.Time          `gorm:"type:date" json:"probation_end_date"`
+	ContractType      string              `gorm:"default:'permanent'" json:"contract_type"`
+	ContractEndDate   *time.Time          `gorm:"type:date" json:"contract_end_date"`
+	TerminationDate   *time.Time          `gorm:"type:date" json:"termination_date"`
+	AnonymizedAt      *time.Time          `json:"anonymized_at,omitempty"`
+	Salary            EncryptedFloat      `json:"salary"`
+	BankName          string              `json:"bank_name"`
+	BankAccountNumber EncryptedString     `json:"bank_account_number"`
+	BankAccountName   string              `json:"bank_account_name"`
+	Dependents        []EmployeeDependent `gorm:"foreignKey:EmployeeID;constraint:OnDelete:CASCADE;" json:"dependents,omitempty"`
+	Assets            []Asset             `gorm:"foreignKey:AssignedEmployeeID" json:"assets,omitempty"`
+	ProfilePicture    string              `json:"profile_picture"`
+	CustomFields      JSONMap             `json:"custom_fields"`
+	SearchText        string              `gorm:"type:text" json:"-"`
+	SearchHighlight   string              `gorm:"->;-:migration" json:"search_highlight,omitempty"`
+	// Blind indexes allow exact-match lookups on encrypted fields
+	PhoneIndex      string `gorm:"index" json:"-"`
+	NationalIDIndex string `gorm:"index" json:"-"`
+	// MaskedFields lists the fields hidden from the current viewer
+	MaskedFields []string `gorm:"-" json:"masked_fields,omitempty"`
+	// Set once an expiry reminder went out; cleared when probation or contract is extended
+	ProbationRemindedAt *time.Time `json:"-"`
+	ContractRemindedAt  *time.Time `json:"-"`
+}
+
+// BeforeSave keeps the blind indexes in step with the encrypted values
+func (e *Employee) BeforeSave(tx *gorm.DB) error {
+	e.RefreshBlindIndexes()
+	return nil
+}
+
+// RefreshBlindIndexes recomputes the blind indexes from the plaintext values
+func (e *Employee) RefreshBlindIndexes() {
+	e.PhoneIndex = fieldcrypt.BlindIndex("phone", NormalizePhone(string(e.Phone)))
+	e.NationalIDIndex = fieldcrypt.BlindIndex("national_id", string(e.NationalID))
+}
+
+// EmployeeSuggestion is the lightweight result returned to autocomplete pickers
+type EmployeeSuggestion struct {
+	ID             uint    `json:"id"`
+	EmployeeCode   string  `json:"employee_code"`
+	FullName       string  `json:"full_name"`
+	Position       string  `json:"position"`
+	DepartmentName string  `json:"department_name"`
+	Highlight      string  `json:"highlight"`
+	Score          float64 `json:"score"`
 }
 
 type CreateEmployeeRequest struct {
@@ -34,23 +85,37 @@ type CreateEmployeeRequest struct {
 	Gender           string     `json:"gender"`
 	Phone            string     `json:"phone"`
 	Address          string     `json:"address"`
+	NationalID       string     `json:"national_id" binding:"omitempty,numeric,len=16"`
 	DepartmentID     *uint      `json:"department_id"`
+	WorkLocationID   *uint      `json:"work_location_id"`
+	Timezone         string     `json:"timezone"`
 	Position         string     `json:"position"`
 	HireDate         time.Time  `json:"hire_date" binding:"required"`
-	EmploymentStatus string     `json:"employment_status"`
+	EmploymentStatus string     `json:"employment_status" binding:"omitempty,oneof=probation active"`
+	ProbationEndDate *time.Time `json:"probation_end_date"`
+	ContractType     string     `json:"contract_type" binding:"omitempty,oneof=permanent pkwt"`
+	ContractEndDate  *time.Time `json:"contract_end_date"`
 	Salary           float64    `json:"salary"`
 	Role             string     `json:"role" binding:"required,oneof=admin hr_manager department_manager employee"`
+	CustomFields     JSONMap    `json:"custom_fields"`
 }
 
 type UpdateEmployeeRequest struct {
-	FirstName        string     `json:"first_name"`
-	LastName         string     `json:"last_name"`
-	DateOfBirth      *time.Time `json:"date_of_birth"`
-	Gender           string     `json:"gender"`
-	Phone            string     `json:"phone"`
-	Address          string     `json:"address"`
-	DepartmentID     *uint      `json:"department_id"`
-	Position         string     `json:"position"`
-	EmploymentStatus string     `json:"employment_status"`
-	Salary           float64    `json:"salary"`
+	FirstName         string             `json:"first_name"`
+	LastName          string             `json:"last_name"`
+	DateOfBirth       *time.Time         `json:"date_of_birth"`
+	Gender            string             `json:"gender"`
+	Phone             string             `json:"phone"`
+	Address           string             `json:"address"`
+	NationalID        string             `json:"national_id" binding:"omitempty,numeric,len=16"`
+	DepartmentID      *uint              `json:"department_id"`
+	WorkLocationID    *uint              `json:"work_location_id"`
+	Timezone          *string            `json:"timezone"`
+	Position          string             `json:"position"`
+	Salary            float64            `json:"salary"`
+	BankName          string             `json:"bank_name"`
+	BankAccountNumber string             `json:"bank_account_number" binding:"omitempty,numeric"`
+	BankAccountName   string             `json:"bank_account_name"`
+	Dependents        []DependentRequest `json:"dependents" binding:"omitempty,dive"`
+	CustomFields      JSONMap            `json:"custom_fields"`
 }
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	EmploymentStatusProbation  = "probation"
+	EmploymentStatusActive     = "active"
+	EmploymentStatusSuspended  = "suspended"
+	EmploymentStatusResigned   = "resigned"
+	EmploymentStatusTerminated = "terminated"
+
+	ContractTypePermanent = "permanent"
+	ContractTypePKWT      = "pkwt"
+)
+
+// employmentTransitions lists the statuses each employment status may move to.
+// Resigned and terminated are final.
+var employmentTransitions = map[string][]string{
+	EmploymentStatusProbation: {EmploymentStatusActive, EmploymentStatusResigned, EmploymentStatusTerminated},
+	EmploymentStatusActive:    {EmploymentStatusSuspended, EmploymentStatusResigned, EmploymentStatusTerminated},
+	EmploymentStatusSuspended: {EmploymentStatusActive, EmploymentStatusResigned, EmploymentStatusTerminated},
+}
+
+// WorkingEmploymentStatuses are the statuses of employees still on the payroll
+var WorkingEmploymentStatuses = []string{EmploymentStatusProbation, EmploymentStatusActive, EmploymentStatusSuspended}
+
+// CanTransitionEmploymentStatus reports whether an employee may move from one status to another
+func CanTransitionEmploymentStatus(from, to string) bool {
+	for _, allowed := range employmentTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+type EmploymentStatusHistory struct {
+	BaseModel
+	EmployeeID    uint      `gorm:"not null;index" json:"employee_id"`
+	FromStatus    string    `json:"from_status"`
+	ToStatus      string    `gorm:"not null" json:"to_status"`
+	EffectiveDate time.Time `gorm:"type:date;not null" json:"effective_date"`
+	Reason        string    `json:"reason"`
+	ChangedBy     *uint     `json:"changed_by"`
+}
+
+type ContractRenewal struct {
+	BaseModel
+	EmployeeID           uint       `gorm:"not null;index" json:"employee_id"`
+	PreviousContractType string     `gorm:"not null" json:"previous_contract_type"`
+	PreviousEndDate      *time.Time `gorm:"type:date" json:"previous_end_date"`
+	ContractType         string     `gorm:"not null" json:"contract_type"`
+	NewEndDate           *time.Time `gorm:"type:date" json:"new_end_date"`
+	Notes                string     `json:"notes"`
+	RenewedBy            uint       `gorm:"not null" json:"renewed_by"`
+}
+
+type ChangeEmploymentStatusRequest struct {
+	Status        string        `json:"status" binding:"required,oneof=active suspended"`
+	EffectiveDate *FlexibleDate `json:"effective_date"`
+	Reason        string        `json:"reason" binding:"required"`
+}
+
+type ExtendProbationRequest struct {
+	ProbationEndDate FlexibleDate `json:"probation_end_date" binding:"required"`
+}
+
+type RenewContractRequest struct {
+	ContractType    string        `json:"contract_type" binding:"required,oneof=permanent pkwt"`
+	ContractEndDate *FlexibleDate `json:"contract_end_date"`
+	Notes           string        `json:"notes"`
+}
+
+// ExpiringEmployment lists employees whose probation or fixed-term contract ends soon
+type ExpiringEmployment struct {
+	ProbationEnding []Employee `json:"probation_ending"`
+	ContractEnding  []Employee `json:"contract_ending"`
+}
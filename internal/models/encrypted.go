@@ -0,0 +1,139 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"hr-backend/internal/fieldcrypt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EncryptedString is a text column encrypted at rest with fieldcrypt
+type EncryptedString string
+
+// GormDataType tells GORM which column type to use when migrating
+func (EncryptedString) GormDataType() string {
+	return "text"
+}
+
+// Value implements driver.Valuer interface
+func (s EncryptedString) Value() (driver.Value, error) {
+	if s == "" {
+		return "", nil
+	}
+	return fieldcrypt.Encrypt(string(s))
+}
+
+// Scan implements sql.Scanner interface
+func (s *EncryptedString) Scan(value interface{}) error {
+	plaintext, err := decryptColumn(value)
+	*s = EncryptedString(plaintext)
+	return err
+}
+
+// EncryptedFloat is a numeric column encrypted at rest with fieldcrypt
+type EncryptedFloat float64
+
+// GormDataType tells GORM which column type to use when migrating
+func (EncryptedFloat) GormDataType() string {
+	return "text"
+}
+
+// Value implements driver.Valuer interface
+func (f EncryptedFloat) Value() (driver.Value, error) {
+	return fieldcrypt.Encrypt(strconv.FormatFloat(float64(f), 'f', -1, 64))
+}
+
+// Scan implements sql.Scanner interface
+func (f *EncryptedFloat) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case float64:
+		*f = EncryptedFloat(v)
+		return nil
+	case int64:
+		*f = EncryptedFloat(v)
+		return nil
+	}
+
+	plaintext, err := decryptColumn(value)
+	if err != nil || plaintext == "" {
+		*f = 0
+		return err
+	}
+	parsed, err := strconv.ParseFloat(plaintext, 64)
+	*f = EncryptedFloat(parsed)
+	return err
+}
+
+// EncryptedDate is a date column encrypted at rest with fieldcrypt
+type EncryptedDate struct {
+	time.Time
+}
+
+// GormDataType tells GORM which column type to use when migrating
+func (EncryptedDate) GormDataType() string {
+	return "text"
+}
+
+// Value implements driver.Valuer interface
+func (d EncryptedDate) Value() (driver.Value, error) {
+	return fieldcrypt.Encrypt(d.Format("2006-01-02"))
+}
+
+// Scan implements sql.Scanner interface
+func (d *EncryptedDate) Scan(value interface{}) error {
+	if t, ok := value.(time.Time); ok {
+		d.Time = t
+		return nil
+	}
+
+	plaintext, err := decryptColumn(value)
+	if err != nil || plaintext == "" {
+		d.Time = time.Time{}
+		return err
+	}
+
+	// Rows written before encryption hold the text form of a timestamp
+	for _, layout := range []string{"2006-01-02", "2006-01-02 15:04:05-07", "2006-01-02 15:04:05.999999-07", time.RFC3339} {
+		if t, err := time.Parse(layout, plaintext); err == nil {
+			d.Time = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid encrypted date %q", plaintext)
+}
+
+// NewEncryptedDate wraps an optional date for an encrypted column
+func NewEncryptedDate(t *time.Time) *EncryptedDate {
+	if t == nil {
+		return nil
+	}
+	return &EncryptedDate{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+var nonDigits = regexp.MustCompile(`\D`)
+
+// NormalizePhone reduces a phone number to its national digits so "+62 812-3456"
+// and "08123456" produce the same blind index
+func NormalizePhone(phone string) string {
+	digits := nonDigits.ReplaceAllString(phone, "")
+	if strings.HasPrefix(digits, "62") {
+		digits = "0" + digits[2:]
+	}
+	return digits
+}
+
+func decryptColumn(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case []byte:
+		return fieldcrypt.Decrypt(string(v))
+	case string:
+		return fieldcrypt.Decrypt(v)
+	default:
+		return "", fmt.Errorf("unsupported encrypted column type %T", value)
+	}
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	HolidayNational        = "national"
+	HolidayCollectiveLeave = "collective_leave"
+	HolidayCompany         = "company"
+)
+
+// Day types recorded on attendance; overtime is paid at different rates for each
+const (
+	DayTypeWorkday = "workday"
+	DayTypeRestDay = "rest_day"
+	DayTypeHoliday = "holiday"
+)
+
+// HolidayCalendar groups the holidays observed at one or more work locations.
+// The default calendar applies to employees whose location has none.
+type HolidayCalendar struct {
+	BaseModel
+	Name        string    `gorm:"uniqueIndex;not null" json:"name"`
+	Description string    `json:"description"`
+	IsDefault   bool      `gorm:"default:false" json:"is_default"`
+	Holidays    []Holiday `gorm:"foreignKey:CalendarID;constraint:OnDelete:CASCADE;" json:"holidays,omitempty"`
+}
+
+// Holiday is a single non-working day. Recurring holidays repeat on the same
+// month and day every year from Date onwards; movable holidays such as Idul Fitri
+// are entered per year.
+type Holiday struct {
+	BaseModel
+	CalendarID uint      `gorm:"not null;index" json:"calendar_id"`
+	Date       time.Time `gorm:"type:date;not null;index" json:"date"`
+	Name       string    `gorm:"not null" json:"name"`
+	Type       string    `gorm:"not null;default:'national'" json:"type"`
+	Recurring  bool      `gorm:"default:false" json:"recurring"`
+	// UID identifies holidays imported from iCalendar files so re-imports update them
+	UID string `gorm:"index" json:"uid,omitempty"`
+}
+
+// On returns the holiday's occurrence in the given year, and false when it
+// does not occur that year
+func (h *Holiday) On(year int) (time.Time, bool) {
+	if !h.Recurring {
+		return h.Date, h.Date.Year() == year
+	}
+	if year < h.Date.Year() {
+		return time.Time{}, false
+	}
+	date := time.Date(year, h.Date.Month(), h.Date.Day(), 0, 0, 0, 0, time.UTC)
+	// 29 February only recurs in leap years
+	return date, date.Month() == h.Date.Month()
+}
+
+type HolidayCalendarRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	IsDefault   bool   `json:"is_default"`
+}
+
+type HolidayRequest struct {
+	Date      FlexibleDate `json:"date" binding:"required"`
+	Name      string       `json:"name" binding:"required"`
+	Type      string       `json:"type" binding:"omitempty,oneof=national collective_leave company"`
+	Recurring bool         `json:"recurring"`
+}
+
+// HolidayImportResult summarizes an iCalendar import
+type HolidayImportResult struct {
+	Created int      `json:"created"`
+	Updated int      `json:"updated"`
+	Skipped []string `json:"skipped"`
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONMap stores arbitrary key/value data in a JSONB column
+type JSONMap map[string]interface{}
+
+// GormDataType tells GORM which column type to use when migrating
+func (JSONMap) GormDataType() string {
+	return "jsonb"
+}
+
+// Value implements driver.Valuer interface
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner interface
+func (m *JSONMap) Scan(value interface{}) error {
+	b, err := jsonBytes(value)
+	if err != nil || b == nil {
+		*m = nil
+		return err
+	}
+	return json.Unmarshal(b, m)
+}
+
+// StringList stores a list of strings in a JSONB column
+type StringList []string
+
+// GormDataType tells GORM which column type to use when migrating
+func (StringList) GormDataType() string {
+	return "jsonb"
+}
+
+// Value implements driver.Valuer interface
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner interface
+func (l *StringList) Scan(value interface{}) error {
+	b, err := jsonBytes(value)
+	if err != nil || b == nil {
+		*l = nil
+		return err
+	}
+	return json.Unmarshal(b, l)
+}
+
+// Contains reports whether the list holds the given value
+func (l StringList) Contains(value string) bool {
+	for _, v := range l {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	default:
+		return nil, fmt.Errorf("unsupported JSON column type %T", value)
+	}
+}
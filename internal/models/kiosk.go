@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// Kiosk is a shared screen at a site without personal devices. It signs in with
+// its own credential, only stored hashed, and shows a rotating QR code employees
+// scan from their phones; employees without a phone enter their PIN on it.
+type Kiosk struct {
+	BaseModel
+	Name           string        `gorm:"uniqueIndex;not null" json:"name"`
+	WorkLocationID *uint         `json:"work_location_id"`
+	WorkLocation   *WorkLocation `json:"work_location,omitempty"`
+	IsActive       bool          `gorm:"default:true" json:"is_active"`
+	TokenHash      string        `gorm:"uniqueIndex;not null" json:"-"`
+	TokenIssuedAt  time.Time     `json:"token_issued_at"`
+	LastSeenAt     *time.Time    `json:"last_seen_at"`
+}
+
+// KioskPin is the PIN an employee enters at kiosks, stored hashed. After too many
+// wrong attempts it is locked until LockedUntil.
+type KioskPin struct {
+	BaseModel
+	EmployeeID     uint       `gorm:"not null;uniqueIndex" json:"employee_id"`
+	Employee       *Employee  `gorm:"constraint:OnDelete:CASCADE;" json:"employee,omitempty"`
+	PinHash        string     `gorm:"not null" json:"-"`
+	FailedAttempts int        `json:"failed_attempts"`
+	LockedUntil    *time.Time `json:"locked_until"`
+}
+
+type KioskRequest struct {
+	Name           string `json:"name" binding:"required"`
+	WorkLocationID *uint  `json:"work_location_id"`
+	IsActive       *bool  `json:"is_active"`
+}
+
+// KioskCredential carries a newly issued kiosk token, which is only shown once
+type KioskCredential struct {
+	Kiosk *Kiosk `json:"kiosk"`
+	Token string `json:"token"`
+}
+
+// KioskQRCode is the signed code a kiosk displays until ExpiresAt
+type KioskQRCode struct {
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type KioskScanRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type KioskPinPunchRequest struct {
+	EmployeeCode string `json:"employee_code" binding:"required"`
+	Pin          string `json:"pin" binding:"required"`
+}
+
+type SetKioskPinRequest struct {
+	Pin string `json:"pin" binding:"required,numeric,min=4,max=8"`
+}
+
+// KioskPunchResult tells who punched at a kiosk and which way
+type KioskPunchResult struct {
+	EmployeeID   uint        `json:"employee_id"`
+	EmployeeName string      `json:"employee_name"`
+	Type         string      `json:"type"`
+	PunchedAt    time.Time   `json:"punched_at"`
+	Attendance   *Attendance `json:"attendance,omitempty"`
+}
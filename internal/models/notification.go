@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+type Notification struct {
+	BaseModel
+	UserID  uint       `gorm:"not null;index" json:"user_id"`
+	User    *User      `gorm:"constraint:OnDelete:CASCADE;" json:"user,omitempty"`
+	Type    string     `gorm:"not null" json:"type"`
+	Title   string     `gorm:"not null" json:"title"`
+	Message string     `json:"message"`
+	Link    string     `json:"link"`
+	ReadAt  *time.Time `json:"read_at"`
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	OnboardingTaskPending   = "pending"
+	OnboardingTaskCompleted = "completed"
+)
+
+type OnboardingTemplate struct {
+	BaseModel
+	Name         string                   `gorm:"not null" json:"name"`
+	Description  string                   `json:"description"`
+	DepartmentID *uint                    `json:"department_id"`
+	Department   *Department              `gorm:"foreignKey:DepartmentID" json:"department,omitempty"`
+	Position     string                   `json:"position"`
+	IsActive     bool                     `gorm:"default:true" json:"is_active"`
+	Tasks        []OnboardingTemplateTask `gorm:"foreignKey:TemplateID;constraint:OnDelete:CASCADE;" json:"tasks,omitempty"`
+}
+
+type OnboardingTemplateTask struct {
+	BaseModel
+	TemplateID     uint   `gorm:"not null;index" json:"template_id"`
+	Title          string `gorm:"not null" json:"title"`
+	Description    string `json:"description"`
+	Category       string `gorm:"not null" json:"category"`
+	AssigneeRole   string `json:"assignee_role"`
+	AssigneeUserID *uint  `json:"assignee_user_id"`
+	DueOffsetDays  int    `gorm:"default:0" json:"due_offset_days"`
+	SortOrder      int    `gorm:"default:0" json:"sort_order"`
+}
+
+type OnboardingTask struct {
+	BaseModel
+	EmployeeID     uint       `gorm:"not null;index" json:"employee_id"`
+	Employee       *Employee  `gorm:"constraint:OnDelete:CASCADE;" json:"employee,omitempty"`
+	TemplateTaskID *uint      `json:"template_task_id"`
+	Title          string     `gorm:"not null" json:"title"`
+	Description    string     `json:"description"`
+	Category       string     `gorm:"not null" json:"category"`
+	AssigneeRole   string     `json:"assignee_role"`
+	AssigneeUserID *uint      `json:"assignee_user_id"`
+	Assignee       *User      `gorm:"foreignKey:AssigneeUserID" json:"assignee,omitempty"`
+	DueDate        time.Time  `gorm:"type:date;not null" json:"due_date"`
+	Status         string     `gorm:"default:'pending'" json:"status"`
+	CompletedAt    *time.Time `json:"completed_at"`
+	CompletedBy    *uint      `json:"completed_by"`
+	Notes          string     `json:"notes"`
+	LastRemindedAt *time.Time `json:"-"`
+}
+
+type OnboardingTemplateTaskRequest struct {
+	Title          string `json:"title" binding:"required"`
+	Description    string `json:"description"`
+	Category       string `json:"category" binding:"required,oneof=account document equipment training other"`
+	AssigneeRole   string `json:"assignee_role" binding:"omitempty,oneof=admin hr_manager department_manager employee"`
+	AssigneeUserID *uint  `json:"assignee_user_id"`
+	DueOffsetDays  int    `json:"due_offset_days"`
+	SortOrder      int    `json:"sort_order"`
+}
+
+type CreateOnboardingTemplateRequest struct {
+	Name         string                          `json:"name" binding:"required"`
+	Description  string                          `json:"description"`
+	DepartmentID *uint                           `json:"department_id"`
+	Position     string                          `json:"position"`
+	Tasks        []OnboardingTemplateTaskRequest `json:"tasks" binding:"required,min=1,dive"`
+}
+
+type UpdateOnboardingTemplateRequest struct {
+	Name         string                          `json:"name"`
+	Description  string                          `json:"description"`
+	DepartmentID *uint                           `json:"department_id"`
+	Position     *string                         `json:"position"`
+	IsActive     *bool                           `json:"is_active"`
+	Tasks        []OnboardingTemplateTaskRequest `json:"tasks" binding:"omitempty,dive"`
+}
+
+type CreateOnboardingTaskRequest struct {
+	Title          string       `json:"title" binding:"required"`
+	Description    string       `json:"description"`
+	Category       string       `json:"category" binding:"required,oneof=account document equipment training other"`
+	AssigneeRole   string       `json:"assignee_role" binding:"omitempty,oneof=admin hr_manager department_manager employee"`
+	AssigneeUserID *uint        `json:"assignee_user_id"`
+	DueDate        FlexibleDate `json:"due_date" binding:"required"`
+}
+
+type CompleteOnboardingTaskRequest struct {
+	Notes string `json:"notes"`
+}
+
+type OnboardingProgress struct {
+	EmployeeID     uint             `json:"employee_id"`
+	EmployeeName   string           `json:"employee_name"`
+	DepartmentName string           `json:"department_name"`
+	HireDate       time.Time        `json:"hire_date"`
+	TotalTasks     int              `json:"total_tasks"`
+	CompletedTasks int              `json:"completed_tasks"`
+	OverdueTasks   int              `json:"overdue_tasks"`
+	Tasks          []OnboardingTask `json:"tasks,omitempty"`
+}
+
+type OnboardingDashboard struct {
+	TotalOverdue int                  `json:"total_overdue"`
+	Employees    []OnboardingProgress `json:"employees"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+const (
+	OvertimePending   = "pending"
+	OvertimeApproved  = "approved"
+	OvertimeRejected  = "rejected"
+	OvertimeCancelled = "cancelled"
+)
+
+// OvertimeRequest asks approval to work extra hours on a date. Only overtime
+// covered by an approved request counts towards overtime pay; DayType records
+// whether the date was a workday, rest day or holiday when the request was made.
+type OvertimeRequest struct {
+	BaseModel
+	EmployeeID  uint       `gorm:"not null;index" json:"employee_id"`
+	Employee    *Employee  `gorm:"constraint:OnDelete:CASCADE;" json:"employee,omitempty"`
+	Date        time.Time  `gorm:"type:date;not null" json:"date"`
+	DayType     string     `json:"day_type"`
+	Hours       float64    `gorm:"not null" json:"hours"`
+	Reason      string     `gorm:"not null" json:"reason"`
+	Status      string     `gorm:"default:'pending';index" json:"status"`
+	ReviewedBy  *uint      `json:"reviewed_by"`
+	ReviewedAt  *time.Time `json:"reviewed_at"`
+	ReviewNotes string     `json:"review_notes"`
+}
+
+type SubmitOvertimeRequest struct {
+	Date   FlexibleDate `json:"date" binding:"required"`
+	Hours  float64      `json:"hours" binding:"required,gt=0"`
+	Reason string       `json:"reason" binding:"required"`
+}
+
+type ReviewOvertimeRequest struct {
+	Notes string `json:"notes"`
+}
+
+// OvertimeDay compares the overtime worked on a day with what was approved.
+// WeightedHours are the approved hours after applying the multipliers.
+type OvertimeDay struct {
+	AttendanceID    uint      `json:"attendance_id"`
+	Date            time.Time `json:"date"`
+	DayType         string    `json:"day_type"`
+	WorkedHours     float64   `json:"worked_hours"`
+	ApprovedHours   float64   `json:"approved_hours"`
+	UnapprovedHours float64   `json:"unapproved_hours"`
+	WeightedHours   float64   `json:"weighted_hours"`
+	Amount          float64   `json:"amount"`
+}
+
+// OvertimeSummary is an employee's overtime pay for a month
+type OvertimeSummary struct {
+	EmployeeID      uint          `json:"employee_id"`
+	Month           int           `json:"month"`
+	Year            int           `json:"year"`
+	HourlyRate      float64       `json:"hourly_rate"`
+	ApprovedHours   float64       `json:"approved_hours"`
+	UnapprovedHours float64       `json:"unapproved_hours"`
+	Amount          float64       `json:"amount"`
+	Days            []OvertimeDay `json:"days"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ProfileChangePending   = "pending"
+	ProfileChangeApproved  = "approved"
+	ProfileChangeRejected  = "rejected"
+	ProfileChangeCancelled = "cancelled"
+)
+
+type EmployeeDependent struct {
+	BaseModel
+	EmployeeID   uint       `gorm:"not null;index" json:"employee_id"`
+	Name         string     `gorm:"not null" json:"name"`
+	Relationship string     `gorm:"not null" json:"relationship"`
+	DateOfBirth  *time.Time `gorm:"type:date" json:"date_of_birth"`
+}
+
+// ProfileChangeRequest holds changes an employee submitted to their own profile.
+// Original is the snapshot of the same fields at submission time; requests are
+// kept after review as the change history.
+type ProfileChangeRequest struct {
+	BaseModel
+	EmployeeID  uint                 `gorm:"not null;index" json:"employee_id"`
+	Employee    *Employee            `gorm:"constraint:OnDelete:CASCADE;" json:"employee,omitempty"`
+	Changes     JSONMap              `gorm:"not null" json:"changes"`
+	Original    JSONMap              `gorm:"not null" json:"original"`
+	Notes       string               `json:"notes"`
+	Status      string               `gorm:"default:'pending';index" json:"status"`
+	ReviewedBy  *uint                `json:"reviewed_by"`
+	ReviewedAt  *time.Time           `json:"reviewed_at"`
+	ReviewNotes string               `json:"review_notes"`
+	Diff        []ProfileFieldChange `gorm:"-" json:"diff"`
+}
+
+// ProfileFieldChange is one line of the diff shown to HR
+type ProfileFieldChange struct {
+	Field    string      `json:"field"`
+	OldValue interface{} `json:"old_value"`
+	NewValue interface{} `json:"new_value"`
+}
+
+type DependentRequest struct {
+	Name         string        `json:"name" binding:"required"`
+	Relationship string        `json:"relationship" binding:"required,oneof=spouse child parent"`
+	DateOfBirth  *FlexibleDate `json:"date_of_birth"`
+}
+
+type SubmitProfileChangeRequest struct {
+	Phone             *string             `json:"phone" binding:"omitempty,min=1"`
+	Address           *string             `json:"address" binding:"omitempty,min=1"`
+	BankName          *string             `json:"bank_name" binding:"omitempty,min=1"`
+	BankAccountNumber *string             `json:"bank_account_number" binding:"omitempty,numeric"`
+	BankAccountName   *string             `json:"bank_account_name" binding:"omitempty,min=1"`
+	Dependents        *[]DependentRequest `json:"dependents" binding:"omitempty,dive"`
+	Notes             string              `json:"notes"`
+}
+
+type ReviewProfileChangeRequest struct {
+	Notes string `json:"notes"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Punch event types. Device logs do not say which way an employee punched, so
+// their events are auto and get their direction from the events around them.
+// A void event cancels the event it points to.
+const (
+	PunchTypeIn         = "in"
+	PunchTypeOut        = "out"
+	PunchTypeBreakStart = "break_start"
+	PunchTypeBreakEnd   = "break_end"
+	PunchTypeAuto       = "auto"
+	PunchTypeVoid       = "void"
+)
+
+// PunchEvent is a single clock action. Events are only ever appended; the daily
+// Attendance record is derived from the events of its work date.
+type PunchEvent struct {
+	ID             uint      `gorm:"primarykey" json:"id"`
+	CreatedAt      time.Time `json:"created_at"`
+	EmployeeID     uint      `gorm:"not null;index:idx_punch_events_day,priority:1;uniqueIndex:idx_punch_events_unique,priority:1" json:"employee_id"`
+	WorkDate       time.Time `gorm:"type:date;not null;index:idx_punch_events_day,priority:2" json:"work_date"`
+	Type           string    `gorm:"not null;uniqueIndex:idx_punch_events_unique,priority:3" json:"type"`
+	PunchedAt      time.Time `gorm:"not null;uniqueIndex:idx_punch_events_unique,priority:4" json:"punched_at"`
+	Source         string    `gorm:"not null;uniqueIndex:idx_punch_events_unique,priority:2" json:"source"`
+	DeviceID       *uint     `json:"device_id"`
+	KioskID        *uint     `json:"kiosk_id"`
+	WorkLocationID *uint     `json:"work_location_id"`
+	Latitude       *float64  `json:"latitude"`
+	Longitude      *float64  `json:"longitude"`
+	Accuracy       *float64  `json:"accuracy"`
+	Geofence       string    `json:"geofence"`
+	Photo          string    `json:"-"`
+	VoidsID        *uint     `gorm:"index" json:"voids_id"`
+	RecordedBy     *uint     `json:"recorded_by"`
+	Notes          string    `json:"notes"`
+}
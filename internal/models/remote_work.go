@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// Kinds of remote work: from home, or from anywhere else such as a client site
+const (
+	RemoteWorkFromHome     = "wfh"
+	RemoteWorkFromAnywhere = "wfa"
+)
+
+const (
+	RemoteWorkPending   = "pending"
+	RemoteWorkApproved  = "approved"
+	RemoteWorkRejected  = "rejected"
+	RemoteWorkCancelled = "cancelled"
+)
+
+// RemoteWorkPolicy caps how many days a week employees may work remotely. A
+// department follows its own policy, otherwise the default one; without either,
+// remote work is not available.
+type RemoteWorkPolicy struct {
+	BaseModel
+	Name        string `gorm:"uniqueIndex;not null" json:"name"`
+	Description string `json:"description"`
+	WeeklyQuota int    `gorm:"not null" json:"weekly_quota"`
+	IsDefault   bool   `gorm:"default:false" json:"is_default"`
+}
+
+// RemoteWorkRequest asks approval to work remotely over a range of dates. Days is
+// the number of scheduled working days in the range, which count towards the quota.
+type RemoteWorkRequest struct {
+	BaseModel
+	EmployeeID  uint       `gorm:"not null;index" json:"employee_id"`
+	Employee    *Employee  `gorm:"constraint:OnDelete:CASCADE;" json:"employee,omitempty"`
+	Type        string     `gorm:"not null" json:"type"`
+	StartDate   time.Time  `gorm:"type:date;not null" json:"start_date"`
+	EndDate     time.Time  `gorm:"type:date;not null" json:"end_date"`
+	Days        int        `json:"days"`
+	Reason      string     `gorm:"not null" json:"reason"`
+	Status      string     `gorm:"default:'pending';index" json:"status"`
+	ReviewedBy  *uint      `json:"reviewed_by"`
+	ReviewedAt  *time.Time `json:"reviewed_at"`
+	ReviewNotes string     `json:"review_notes"`
+}
+
+type RemoteWorkPolicyRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	WeeklyQuota int    `json:"weekly_quota" binding:"min=1,max=7"`
+	IsDefault   bool   `json:"is_default"`
+}
+
+type SubmitRemoteWorkRequest struct {
+	Type      string       `json:"type" binding:"required,oneof=wfh wfa"`
+	StartDate FlexibleDate `json:"start_date" binding:"required"`
+	EndDate   FlexibleDate `json:"end_date" binding:"required"`
+	Reason    string       `json:"reason" binding:"required"`
+}
+
+type ReviewRemoteWorkRequest struct {
+	Notes string `json:"notes"`
+}
+
+// RemoteWorkCalendarEntry is one employee working remotely on one day
+type RemoteWorkCalendarEntry struct {
+	Date         time.Time `json:"date"`
+	RequestID    uint      `json:"request_id"`
+	EmployeeID   uint      `json:"employee_id"`
+	EmployeeName string    `json:"employee_name"`
+	DepartmentID *uint     `json:"department_id"`
+	Type         string    `json:"type"`
+	Status       string    `json:"status"`
+}
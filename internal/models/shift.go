@@ -0,0 +1,128 @@
+package models
+
+import (
+	"time"
+)
+
+// shiftClockLayout is the format of shift start and end times
+const shiftClockLayout = "15:04"
+
+// Shift is a working time template. An end time at or before the start time
+// means the shift crosses midnight and ends on the following day.
+type Shift struct {
+	BaseModel
+	Name         string `gorm:"uniqueIndex;not null" json:"name"`
+	StartTime    string `gorm:"not null" json:"start_time"`
+	EndTime      string `gorm:"not null" json:"end_time"`
+	BreakMinutes int    `gorm:"default:0" json:"break_minutes"`
+	GraceMinutes int    `gorm:"default:0" json:"grace_minutes"`
+	// IsDefault applies the shift to employees without a roster entry for the day
+	IsDefault bool `gorm:"default:false" json:"is_default"`
+	IsActive  bool `gorm:"default:true" json:"is_active"`
+}
+
+// CrossesMidnight reports whether the shift ends on the day after it starts
+func (s *Shift) CrossesMidnight() bool {
+	return s.EndTime <= s.StartTime
+}
+
+// Window returns the scheduled start and end of the shift that starts on the given date
+func (s *Shift) Window(date time.Time, loc *time.Location) (time.Time, time.Time) {
+	start, _ := time.Parse(shiftClockLayout, s.StartTime)
+	end, _ := time.Parse(shiftClockLayout, s.EndTime)
+
+	y, m, d := date.Date()
+	scheduledStart := time.Date(y, m, d, start.Hour(), start.Minute(), 0, 0, loc)
+	scheduledEnd := time.Date(y, m, d, end.Hour(), end.Minute(), 0, 0, loc)
+	if s.CrossesMidnight() {
+		scheduledEnd = scheduledEnd.AddDate(0, 0, 1)
+	}
+	return scheduledStart, scheduledEnd
+}
+
+// ScheduledHours is the paid length of the shift, excluding the break
+func (s *Shift) ScheduledHours() float64 {
+	start, end := s.Window(time.Time{}, time.UTC)
+	return end.Sub(start).Hours() - float64(s.BreakMinutes)/60
+}
+
+// ValidShiftClock reports whether the value is a valid HH:MM shift time
+func ValidShiftClock(value string) bool {
+	_, err := time.Parse(shiftClockLayout, value)
+	return err == nil && len(value) == len(shiftClockLayout)
+}
+
+// ShiftPattern is a rotating sequence of shifts, e.g. two mornings, two nights, two days off
+type ShiftPattern struct {
+	BaseModel
+	Name        string             `gorm:"uniqueIndex;not null" json:"name"`
+	Description string             `json:"description"`
+	Slots       []ShiftPatternSlot `gorm:"foreignKey:PatternID;constraint:OnDelete:CASCADE;" json:"slots,omitempty"`
+}
+
+// ShiftPatternSlot is one day of a pattern; no shift means a day off
+type ShiftPatternSlot struct {
+	BaseModel
+	PatternID uint   `gorm:"not null;index" json:"pattern_id"`
+	DayIndex  int    `gorm:"not null" json:"day_index"`
+	ShiftID   *uint  `json:"shift_id"`
+	Shift     *Shift `json:"shift,omitempty"`
+}
+
+// RosterEntry assigns a shift to an employee on a date; no shift means a day off
+type RosterEntry struct {
+	BaseModel
+	EmployeeID uint      `gorm:"not null;uniqueIndex:idx_roster_employee_date" json:"employee_id"`
+	Employee   *Employee `gorm:"constraint:OnDelete:CASCADE;" json:"employee,omitempty"`
+	Date       time.Time `gorm:"type:date;not null;uniqueIndex:idx_roster_employee_date" json:"date"`
+	ShiftID    *uint     `json:"shift_id"`
+	Shift      *Shift    `json:"shift,omitempty"`
+	PatternID  *uint     `json:"pattern_id"`
+}
+
+// DaySchedule is what an employee is expected to work on a date
+type DaySchedule struct {
+	Date  time.Time
+	Shift *Shift
+	// DayOff is set on rest days and on holidays the employee is not rostered to work
+	DayOff  bool
+	DayType string
+	Holiday *Holiday
+}
+
+type ShiftRequest struct {
+	Name         string `json:"name" binding:"required"`
+	StartTime    string `json:"start_time" binding:"required"`
+	EndTime      string `json:"end_time" binding:"required"`
+	BreakMinutes int    `json:"break_minutes" binding:"min=0"`
+	GraceMinutes int    `json:"grace_minutes" binding:"min=0"`
+	IsDefault    bool   `json:"is_default"`
+	IsActive     *bool  `json:"is_active"`
+}
+
+type ShiftPatternRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	// ShiftIDs lists the shift of each day in the cycle; null is a day off
+	ShiftIDs []*uint `json:"shift_ids" binding:"required,min=1,max=62"`
+}
+
+type RosterEntryRequest struct {
+	EmployeeID uint         `json:"employee_id" binding:"required"`
+	Date       FlexibleDate `json:"date" binding:"required"`
+	ShiftID    *uint        `json:"shift_id"`
+}
+
+type SetRosterRequest struct {
+	Entries []RosterEntryRequest `json:"entries" binding:"required,min=1,dive"`
+}
+
+// ApplyShiftPatternRequest rolls a pattern out over a date range. StartDay is the
+// day of the cycle that falls on StartDate, so teams can be staggered.
+type ApplyShiftPatternRequest struct {
+	PatternID   uint         `json:"pattern_id" binding:"required"`
+	EmployeeIDs []uint       `json:"employee_ids" binding:"required,min=1"`
+	StartDate   FlexibleDate `json:"start_date" binding:"required"`
+	EndDate     FlexibleDate `json:"end_date" binding:"required"`
+	StartDay    int          `json:"start_day" binding:"min=0"`
+}
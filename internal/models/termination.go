@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	TerminationScheduled = "scheduled"
+	TerminationCompleted = "completed"
+	TerminationCancelled = "cancelled"
+)
+
+type Termination struct {
+	BaseModel
+	EmployeeID      uint       `gorm:"not null;index" json:"employee_id"`
+	Employee        *Employee  `gorm:"constraint:OnDelete:CASCADE;" json:"employee,omitempty"`
+	TerminationType string     `gorm:"not null" json:"termination_type"`
+	NoticeDate      time.Time  `gorm:"type:date;not null" json:"notice_date"`
+	TerminationDate time.Time  `gorm:"type:date;not null" json:"termination_date"`
+	Reason          string     `gorm:"not null" json:"reason"`
+	Notes           string     `json:"notes"`
+	Status          string     `gorm:"default:'scheduled'" json:"status"`
+	InitiatedBy     uint       `gorm:"not null" json:"initiated_by"`
+	CompletedAt     *time.Time `json:"completed_at"`
+	YearsOfService  float64    `json:"years_of_service"`
+	MonthlyWage     float64    `json:"monthly_wage"`
+	ProratedSalary  float64    `json:"prorated_salary"`
+	UnusedLeaveDays int        `json:"unused_leave_days"`
+	LeaveEncashment float64    `json:"leave_encashment"`
+	OvertimeHours   float64    `json:"overtime_hours"`
+	OvertimePay     float64    `json:"overtime_pay"`
+	SeverancePay    float64    `json:"severance_pay"`
+	ServiceAwardPay float64    `json:"service_award_pay"`
+	// PayrollPaid is the salary and overtime of the final month already paid by payroll
+	PayrollPaid     float64             `json:"payroll_paid"`
+	TotalSettlement float64             `json:"total_settlement"`
+	ChecklistItems  []ExitChecklistItem `gorm:"foreignKey:TerminationID;constraint:OnDelete:CASCADE;" json:"checklist_items,omitempty"`
+}
+
+type ExitChecklistItem struct {
+	BaseModel
+	TerminationID uint       `gorm:"not null;index" json:"termination_id"`
+	Title         string     `gorm:"not null" json:"title"`
+	Category      string     `gorm:"not null" json:"category"`
+	Completed     bool       `gorm:"default:false" json:"completed"`
+	CompletedAt   *time.Time `json:"completed_at"`
+	CompletedBy   *uint      `json:"completed_by"`
+	Notes         string     `json:"notes"`
+	// AssetID links the item to an asset the employee must return
+	AssetID *uint `gorm:"index" json:"asset_id,omitempty"`
+}
+
+// SeveranceRule scales the statutory severance and service award tables
+// (PP 35/2021) for one termination type
+type SeveranceRule struct {
+	BaseModel
+	TerminationType        string  `gorm:"uniqueIndex;not null" json:"termination_type"`
+	SeveranceMultiplier    float64 `gorm:"not null" json:"severance_multiplier"`
+	ServiceAwardMultiplier float64 `gorm:"not null" json:"service_award_multiplier"`
+	LeaveEncashment        bool    `gorm:"default:true" json:"leave_encashment"`
+	Description            string  `json:"description"`
+}
+
+type CreateTerminationRequest struct {
+	TerminationType string        `json:"termination_type" binding:"required,oneof=resignation layoff misconduct disciplinary contract_end retirement death"`
+	NoticeDate      *FlexibleDate `json:"notice_date"`
+	TerminationDate FlexibleDate  `json:"termination_date" binding:"required"`
+	Reason          string        `json:"reason" binding:"required"`
+	Notes           string        `json:"notes"`
+}
+
+type CompleteChecklistItemRequest struct {
+	Notes string `json:"notes"`
+}
+
+type UpdateSeveranceRuleRequest struct {
+	SeveranceMultiplier    *float64 `json:"severance_multiplier" binding:"omitempty,min=0"`
+	ServiceAwardMultiplier *float64 `json:"service_award_multiplier" binding:"omitempty,min=0"`
+	LeaveEncashment        *bool    `json:"leave_encashment"`
+	Description            string   `json:"description"`
+}
@@ -0,0 +1,161 @@
+package models
+
+import "time"
+
+const (
+	ProjectActive = "active"
+	ProjectClosed = "closed"
+)
+
+const (
+	TimesheetDraft     = "draft"
+	TimesheetSubmitted = "submitted"
+	TimesheetApproved  = "approved"
+	TimesheetRejected  = "rejected"
+)
+
+// How the hours logged on a day compare with the attendance working hours
+const (
+	TimesheetDayMatch = "match"
+	TimesheetDayOver  = "over"
+	TimesheetDayUnder = "under"
+)
+
+// Project is client work employees log hours against. Hours are billable when both
+// the project and the task are, and are billed at HourlyRate.
+type Project struct {
+	BaseModel
+	Code        string        `gorm:"uniqueIndex;not null" json:"code"`
+	Name        string        `gorm:"not null" json:"name"`
+	ClientName  string        `json:"client_name"`
+	Description string        `json:"description"`
+	IsBillable  bool          `gorm:"default:false" json:"is_billable"`
+	HourlyRate  float64       `json:"hourly_rate"`
+	Status      string        `gorm:"default:'active';index" json:"status"`
+	Tasks       []ProjectTask `json:"tasks,omitempty"`
+}
+
+type ProjectTask struct {
+	BaseModel
+	ProjectID  uint   `gorm:"not null;index" json:"project_id"`
+	Name       string `gorm:"not null" json:"name"`
+	IsBillable bool   `gorm:"default:false" json:"is_billable"`
+	IsActive   bool   `gorm:"default:true" json:"is_active"`
+}
+
+// Timesheet holds an employee's hours for one Monday-to-Sunday week. Entries can
+// be changed while it is a draft or was rejected; submitting sends it to the
+// manager, and only approved timesheets count in the reports. WorkedHours are the
+// attendance working hours of the week when it was submitted.
+type Timesheet struct {
+	BaseModel
+	EmployeeID    uint             `gorm:"not null;uniqueIndex:idx_timesheets_employee_week" json:"employee_id"`
+	Employee      *Employee        `gorm:"constraint:OnDelete:CASCADE;" json:"employee,omitempty"`
+	WeekStart     time.Time        `gorm:"type:date;not null;uniqueIndex:idx_timesheets_employee_week" json:"week_start"`
+	Status        string           `gorm:"default:'draft';index" json:"status"`
+	TotalHours    float64          `json:"total_hours"`
+	BillableHours float64          `json:"billable_hours"`
+	WorkedHours   float64          `json:"worked_hours"`
+	SubmittedAt   *time.Time       `json:"submitted_at"`
+	ReviewedBy    *uint            `json:"reviewed_by"`
+	ReviewedAt    *time.Time       `json:"reviewed_at"`
+	ReviewNotes   string           `json:"review_notes"`
+	Entries       []TimesheetEntry `gorm:"constraint:OnDelete:CASCADE;" json:"entries,omitempty"`
+}
+
+// TimesheetEntry is time spent on a project on one day. Billable is decided from
+// the project and task when the entry is saved.
+type TimesheetEntry struct {
+	BaseModel
+	TimesheetID uint         `gorm:"not null;index" json:"timesheet_id"`
+	EmployeeID  uint         `gorm:"not null;index" json:"employee_id"`
+	ProjectID   uint         `gorm:"not null;index" json:"project_id"`
+	Project     *Project     `json:"project,omitempty"`
+	TaskID      *uint        `gorm:"index" json:"task_id"`
+	Task        *ProjectTask `json:"task,omitempty"`
+	Date        time.Time    `gorm:"type:date;not null;index" json:"date"`
+	Hours       float64      `gorm:"not null" json:"hours"`
+	Billable    bool         `json:"billable"`
+	Description string       `json:"description"`
+}
+
+type ProjectRequest struct {
+	Code        string  `json:"code" binding:"required"`
+	Name        string  `json:"name" binding:"required"`
+	ClientName  string  `json:"client_name"`
+	Description string  `json:"description"`
+	IsBillable  bool    `json:"is_billable"`
+	HourlyRate  float64 `json:"hourly_rate" binding:"min=0"`
+	Status      string  `json:"status" binding:"omitempty,oneof=active closed"`
+}
+
+type ProjectTaskRequest struct {
+	Name       string `json:"name" binding:"required"`
+	IsBillable bool   `json:"is_billable"`
+	IsActive   *bool  `json:"is_active"`
+}
+
+type TimesheetEntryRequest struct {
+	ProjectID   uint         `json:"project_id" binding:"required"`
+	TaskID      *uint        `json:"task_id"`
+	Date        FlexibleDate `json:"date" binding:"required"`
+	Hours       float64      `json:"hours" binding:"required,gt=0,lte=24"`
+	Description string       `json:"description"`
+}
+
+type ReviewTimesheetRequest struct {
+	Notes string `json:"notes"`
+}
+
+// TimesheetDay compares the hours logged on a day with the attendance working
+// hours; Difference is logged minus worked
+type TimesheetDay struct {
+	Date        time.Time `json:"date"`
+	LoggedHours float64   `json:"logged_hours"`
+	WorkedHours float64   `json:"worked_hours"`
+	Difference  float64   `json:"difference"`
+	Status      string    `json:"status"`
+}
+
+// TimesheetDetail is a timesheet with its entries reconciled against attendance
+type TimesheetDetail struct {
+	Timesheet      *Timesheet     `json:"timesheet"`
+	WorkedHours    float64        `json:"worked_hours"`
+	Difference     float64        `json:"difference"`
+	Discrepancies  int            `json:"discrepancies"`
+	Reconciliation []TimesheetDay `json:"reconciliation"`
+}
+
+// TimesheetReportFilter selects the approved hours counted in the reports
+type TimesheetReportFilter struct {
+	StartDate    time.Time
+	EndDate      time.Time
+	DepartmentID *uint
+	ProjectID    *uint
+}
+
+// EmployeeUtilization is the share of an employee's attendance working hours that
+// was logged, and that was billable, within a date range
+type EmployeeUtilization struct {
+	EmployeeID          uint    `json:"employee_id"`
+	EmployeeName        string  `json:"employee_name"`
+	DepartmentID        *uint   `json:"department_id"`
+	WorkedHours         float64 `json:"worked_hours"`
+	LoggedHours         float64 `json:"logged_hours"`
+	BillableHours       float64 `json:"billable_hours"`
+	BillableAmount      float64 `json:"billable_amount"`
+	Utilization         float64 `json:"utilization"`
+	BillableUtilization float64 `json:"billable_utilization"`
+}
+
+// ProjectHoursReport totals the approved hours logged on a project within a date range
+type ProjectHoursReport struct {
+	ProjectID      uint    `json:"project_id"`
+	Code           string  `json:"code"`
+	Name           string  `json:"name"`
+	ClientName     string  `json:"client_name"`
+	TotalHours     float64 `json:"total_hours"`
+	BillableHours  float64 `json:"billable_hours"`
+	BillableAmount float64 `json:"billable_amount"`
+	Employees      int     `json:"employees"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Entities that can be listed, restored and purged from the trash
+const (
+	TrashEntityEmployee   = "employee"
+	TrashEntityDepartment = "department"
+	TrashEntityLeave      = "leave"
+)
+
+// TrashedRecord is a soft-deleted record together with when it was deleted
+// and when the purge job will remove it permanently
+type TrashedRecord struct {
+	ID        uint        `json:"id"`
+	Label     string      `json:"label"`
+	DeletedAt time.Time   `json:"deleted_at"`
+	PurgeAt   time.Time   `json:"purge_at"`
+	Record    interface{} `json:"record"`
+}
@@ -0,0 +1,135 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"time"
+)
+
+// earthRadiusMeters is the mean Earth radius used for distances between coordinates
+const earthRadiusMeters = 6371000
+
+const (
+	GeofenceCircle  = "circle"
+	GeofencePolygon = "polygon"
+)
+
+// WorkLocation is an office, plant or site employees are based at
+type WorkLocation struct {
+	BaseModel
+	Name              string           `gorm:"uniqueIndex;not null" json:"name"`
+	Address           string           `json:"address"`
+	Timezone          string           `json:"timezone"`
+	HolidayCalendarID *uint            `json:"holiday_calendar_id"`
+	HolidayCalendar   *HolidayCalendar `json:"holiday_calendar,omitempty"`
+	Geofences         []Geofence       `gorm:"constraint:OnDelete:CASCADE;" json:"geofences,omitempty"`
+}
+
+// Geofence is an area where employees of a work location may clock in,
+// either a circle around a point or a polygon
+type Geofence struct {
+	BaseModel
+	WorkLocationID uint      `gorm:"not null;index" json:"work_location_id"`
+	Name           string    `gorm:"not null" json:"name"`
+	Type           string    `gorm:"not null" json:"type"`
+	Latitude       float64   `json:"latitude"`
+	Longitude      float64   `json:"longitude"`
+	RadiusMeters   float64   `json:"radius_meters"`
+	Polygon        GeoPoints `json:"polygon"`
+	IsActive       bool      `gorm:"default:true" json:"is_active"`
+}
+
+// ValidTimezone reports whether the value is an IANA time zone name such as Asia/Makassar
+func ValidTimezone(value string) bool {
+	if value == "" || value == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(value)
+	return err == nil
+}
+
+// Contains reports whether the coordinates fall inside the geofence
+func (g *Geofence) Contains(latitude, longitude float64) bool {
+	if g.Type == GeofencePolygon {
+		return g.Polygon.Contains(latitude, longitude)
+	}
+	return DistanceMeters(g.Latitude, g.Longitude, latitude, longitude) <= g.RadiusMeters
+}
+
+// GeoPoint is a WGS84 coordinate
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude" binding:"min=-90,max=90"`
+	Longitude float64 `json:"longitude" binding:"min=-180,max=180"`
+}
+
+// GeoPoints stores a polygon's vertices in a JSONB column
+type GeoPoints []GeoPoint
+
+// GormDataType tells GORM which column type to use when migrating
+func (GeoPoints) GormDataType() string {
+	return "jsonb"
+}
+
+// Value implements driver.Valuer interface
+func (p GeoPoints) Value() (driver.Value, error) {
+	if p == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner interface
+func (p *GeoPoints) Scan(value interface{}) error {
+	b, err := jsonBytes(value)
+	if err != nil || b == nil {
+		*p = nil
+		return err
+	}
+	return json.Unmarshal(b, p)
+}
+
+// Contains runs a ray casting test, treating coordinates as planar, which is
+// accurate enough at the size of an office compound
+func (p GeoPoints) Contains(latitude, longitude float64) bool {
+	inside := false
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		a, b := p[i], p[j]
+		if (a.Latitude > latitude) != (b.Latitude > latitude) &&
+			longitude < (b.Longitude-a.Longitude)*(latitude-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// DistanceMeters returns the great-circle distance between two coordinates
+func DistanceMeters(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(h))
+}
+
+type WorkLocationRequest struct {
+	Name              string `json:"name" binding:"required"`
+	Address           string `json:"address"`
+	Timezone          string `json:"timezone"`
+	HolidayCalendarID *uint  `json:"holiday_calendar_id"`
+}
+
+type GeofenceRequest struct {
+	Name         string     `json:"name" binding:"required"`
+	Type         string     `json:"type" binding:"required,oneof=circle polygon"`
+	Latitude     float64    `json:"latitude" binding:"min=-90,max=90"`
+	Longitude    float64    `json:"longitude" binding:"min=-180,max=180"`
+	RadiusMeters float64    `json:"radius_meters" binding:"min=0"`
+	Polygon      []GeoPoint `json:"polygon" binding:"omitempty,dive"`
+	IsActive     *bool      `json:"is_active"`
+}
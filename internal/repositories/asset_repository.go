@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"hr-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AssetRepository struct {
+	db *gorm.DB
+}
+
+func NewAssetRepository(db *gorm.DB) *AssetRepository {
+	return &AssetRepository{db: db}
+}
+
+func (r *AssetRepository) Create(asset *models.Asset) error {
+	return r.db.Create(asset).Error
+}
+
+func (r *AssetRepository) FindAll(page, limit int, assetType, status, search string) ([]models.Asset, int64, error) {
+	var assets []models.Asset
+	var total int64
+
+	query := r.db.Model(&models.Asset{}).Preload("AssignedEmployee", employeeSummary)
+
+	if assetType != "" {
+		query = query.Where("type = ?", assetType)
+	}
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if search != "" {
+		pattern := "%" + search + "%"
+		query = query.Where("asset_tag ILIKE ? OR name ILIKE ? OR serial_number ILIKE ?", pattern, pattern, pattern)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("asset_tag ASC").Offset(offset).Limit(limit).Find(&assets).Error
+
+	return assets, total, err
+}
+
+func (r *AssetRepository) FindByID(id uint) (*models.Asset, error) {
+	var asset models.Asset
+	err := r.db.Preload("AssignedEmployee", employeeSummary).First(&asset, id).Error
+	return &asset, err
+}
+
+func (r *AssetRepository) FindByTag(tag string) (*models.Asset, error) {
+	var asset models.Asset
+	err := r.db.Where("asset_tag = ?", tag).First(&asset).Error
+	return &asset, err
+}
+
+// FindByEmployee returns the assets an employee currently holds
+func (r *AssetRepository) FindByEmployee(employeeID uint) ([]models.Asset, error) {
+	var assets []models.Asset
+	err := r.db.Where("assigned_employee_id = ? AND status = ?", employeeID, models.AssetAssigned).
+		Order("assigned_at ASC, asset_tag ASC").
+		Find(&assets).Error
+	return assets, err
+}
+
+// FindHeldByInactive returns assigned assets whose holder no longer works here,
+// including holders that were deleted
+func (r *AssetRepository) FindHeldByInactive() ([]models.Asset, error) {
+	var assets []models.Asset
+	err := r.db.Preload("AssignedEmployee", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Select("id", "employee_code", "first_name", "last_name", "department_id",
+			"position", "employment_status", "termination_date")
+	}).Preload("AssignedEmployee.Department").
+		Joins("JOIN employees ON employees.id = assets.assigned_employee_id").
+		Where("assets.status = ?", models.AssetAssigned).
+		Where("employees.employment_status NOT IN ? OR employees.deleted_at IS NOT NULL", models.WorkingEmploymentStatuses).
+		Order("employees.termination_date ASC NULLS LAST, assets.asset_tag ASC").
+		Find(&assets).Error
+	return assets, err
+}
+
+func (r *AssetRepository) FindHistory(assetID uint) ([]models.AssetAssignment, error) {
+	var assignments []models.AssetAssignment
+	err := r.db.Preload("Employee", func(db *gorm.DB) *gorm.DB { return employeeSummary(db.Unscoped()) }).
+		Where("asset_id = ?", assetID).
+		Order("assigned_at DESC, id DESC").
+		Find(&assignments).Error
+	return assignments, err
+}
+
+func (r *AssetRepository) FindHistoryByEmployee(employeeID uint) ([]models.AssetAssignment, error) {
+	var assignments []models.AssetAssignment
+	err := r.db.Preload("Asset").
+		Where("employee_id = ?", employeeID).
+		Order("assigned_at DESC, id DESC").
+		Find(&assignments).Error
+	return assignments, err
+}
+
+// FindOpenAssignment returns the assignment of the asset that has not been returned yet
+func (r *AssetRepository) FindOpenAssignment(assetID uint) (*models.AssetAssignment, error) {
+	var assignment models.AssetAssignment
+	err := r.db.Where("asset_id = ? AND returned_at IS NULL", assetID).
+		Order("id DESC").
+		First(&assignment).Error
+	return &assignment, err
+}
+
+func (r *AssetRepository) Update(asset *models.Asset) error {
+	return r.db.Omit("AssignedEmployee").Save(asset).Error
+}
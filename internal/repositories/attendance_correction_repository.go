@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"hr-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AttendanceCorrectionRepository struct {
+	db *gorm.DB
+}
+
+func NewAttendanceCorrectionRepository(db *gorm.DB) *AttendanceCorrectionRepository {
+	return &AttendanceCorrectionRepository{db: db}
+}
+
+func (r *AttendanceCorrectionRepository) Create(correction *models.AttendanceCorrection) error {
+	return r.db.Create(correction).Error
+}
+
+func (r *AttendanceCorrectionRepository) FindAll(status string, departmentID *uint, page, limit int) ([]models.AttendanceCorrection, int64, error) {
+	var corrections []models.AttendanceCorrection
+	var total int64
+
+	query := r.db.Model(&models.AttendanceCorrection{}).Preload("Employee", employeeSummary).Preload("PunchEvent")
+
+	if status != "" {
+		query = query.Where("attendance_corrections.status = ?", status)
+	}
+
+	if departmentID != nil {
+		query = query.Joins("JOIN employees ON employees.id = attendance_corrections.employee_id").
+			Where("employees.department_id = ?", *departmentID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("attendance_corrections.created_at DESC").Offset(offset).Limit(limit).Find(&corrections).Error
+	return corrections, total, err
+}
+
+func (r *AttendanceCorrectionRepository) FindByEmployee(employeeID uint) ([]models.AttendanceCorrection, error) {
+	var corrections []models.AttendanceCorrection
+	err := r.db.Preload("PunchEvent").Where("employee_id = ?", employeeID).Order("created_at DESC").Find(&corrections).Error
+	return corrections, err
+}
+
+func (r *AttendanceCorrectionRepository) FindByID(id uint) (*models.AttendanceCorrection, error) {
+	var correction models.AttendanceCorrection
+	err := r.db.Preload("Employee", employeeSummary).Preload("Employee.Department").Preload("PunchEvent").First(&correction, id).Error
+	return &correction, err
+}
+
+// CountPending counts pending corrections of the same punch, or of the same event
+func (r *AttendanceCorrectionRepository) CountPending(employeeID uint, date time.Time, punchType string, punchEventID *uint) (int64, error) {
+	var count int64
+	query := r.db.Model(&models.AttendanceCorrection{}).
+		Where("employee_id = ? AND status = ?", employeeID, models.AttendanceCorrectionPending)
+	if punchEventID != nil {
+		query = query.Where("punch_event_id = ?", *punchEventID)
+	} else {
+		query = query.Where("date = ? AND punch_type = ? AND punch_event_id IS NULL", date, punchType)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+func (r *AttendanceCorrectionRepository) Update(correction *models.AttendanceCorrection) error {
+	return r.db.Omit("Employee", "PunchEvent").Save(correction).Error
+}
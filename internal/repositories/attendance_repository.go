@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AttendanceRepository struct {
@@ -35,13 +36,83 @@ func (r *AttendanceRepository) FindByEmployee(employeeID uint, startDate, endDat
 
 func (r *AttendanceRepository) FindByDateRange(startDate, endDate time.Time) ([]models.Attendance, error) {
 	var attendances []models.Attendance
-	err := r.db.Preload("Employee").
+	err := r.db.Preload("Employee", employeeSummary).
 		Where("date BETWEEN ? AND ?", startDate, endDate).
 		Order("date DESC").
 		Find(&attendances).Error
 	return attendances, err
 }
 
+// FindReportEmployees returns a page of the employees employed at some point within the range
+func (r *AttendanceRepository) FindReportEmployees(filter models.AttendanceReportFilter) ([]models.Employee, int64, error) {
+	var employees []models.Employee
+	var total int64
+
+	query := r.db.Model(&models.Employee{}).Preload("Department").
+		Where("hire_date <= ? AND (termination_date IS NULL OR termination_date >= ?)", filter.EndDate, filter.StartDate)
+
+	if filter.DepartmentID != nil {
+		query = query.Where("department_id = ?", *filter.DepartmentID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	err := query.Order("first_name ASC, last_name ASC, id ASC").Offset(offset).Limit(filter.Limit).Find(&employees).Error
+	return employees, total, err
+}
+
+// FindEmployedOn returns the employees employed on the date
+func (r *AttendanceRepository) FindEmployedOn(date time.Time) ([]models.Employee, error) {
+	var employees []models.Employee
+	err := r.db.Where("hire_date <= ? AND (termination_date IS NULL OR termination_date >= ?)", date, date).
+		Order("id ASC").
+		Find(&employees).Error
+	return employees, err
+}
+
+func (r *AttendanceRepository) FindByEmployeesAndDateRange(employeeIDs []uint, startDate, endDate time.Time) ([]models.Attendance, error) {
+	var attendances []models.Attendance
+	if len(employeeIDs) == 0 {
+		return attendances, nil
+	}
+
+	err := r.db.Where("employee_id IN ? AND date BETWEEN ? AND ?", employeeIDs, startDate, endDate).
+		Order("date ASC").
+		Find(&attendances).Error
+	return attendances, err
+}
+
+func (r *AttendanceRepository) FindByID(id uint) (*models.Attendance, error) {
+	var attendance models.Attendance
+	err := r.db.Preload("Employee", employeeSummary).First(&attendance, id).Error
+	return &attendance, err
+}
+
+// FindPendingReview returns attendance flagged for review, oldest first
+func (r *AttendanceRepository) FindPendingReview(departmentID *uint, page, limit int) ([]models.Attendance, int64, error) {
+	var attendances []models.Attendance
+	var total int64
+
+	query := r.db.Model(&models.Attendance{}).Preload("Employee", employeeSummary).
+		Where("attendances.review_status = ?", models.AttendanceReviewPending)
+
+	if departmentID != nil {
+		query = query.Joins("JOIN employees ON employees.id = attendances.employee_id").
+			Where("employees.department_id = ?", *departmentID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("attendances.date ASC, attendances.id ASC").Offset(offset).Limit(limit).Find(&attendances).Error
+	return attendances, total, err
+}
+
 func (r *AttendanceRepository) Update(attendance *models.Attendance) error {
 	return r.db.Save(attendance).Error
 }
@@ -58,11 +129,91 @@ func (r *AttendanceRepository) CountByStatus(status string, date time.Time) (int
 	return count, err
 }
 
-func (r *AttendanceRepository) CountTodayPresent() (int64, error) {
-	today := time.Now().Format("2006-01-02")
+// employeeTodaySQL is today's date in each employee's time zone, for queries joined
+// with employees and work_locations. Its placeholder takes the company time zone.
+const employeeTodaySQL = "(NOW() AT TIME ZONE COALESCE(NULLIF(employees.timezone, ''), NULLIF(work_locations.timezone, ''), ?))::date"
+
+// joinEmployeeZone joins what employeeTodaySQL needs to the query of an employee-owned table
+func joinEmployeeZone(query *gorm.DB, table string) *gorm.DB {
+	return query.Joins("JOIN employees ON employees.id = " + table + ".employee_id AND employees.deleted_at IS NULL").
+		Joins("LEFT JOIN work_locations ON work_locations.id = employees.work_location_id")
+}
+
+// CountTodayPresent counts the employees present on what is today where they work,
+// including those who came in late or work remotely
+func (r *AttendanceRepository) CountTodayPresent(defaultZone string) (int64, error) {
 	var count int64
-	err := r.db.Model(&models.Attendance{}).
-		Where("date = ? AND status = ?", today, "present").
+	err := joinEmployeeZone(r.db.Model(&models.Attendance{}), "attendances").
+		Where("attendances.date = "+employeeTodaySQL+" AND attendances.status IN ?", defaultZone, models.AttendedStatuses).
 		Count(&count).Error
 	return count, err
 }
+
+func (r *AttendanceRepository) CreatePunchEvent(event *models.PunchEvent) error {
+	return r.db.Create(event).Error
+}
+
+// CreatePunchEvents stores the events, skipping any already recorded, and
+// returns how many were new
+func (r *AttendanceRepository) CreatePunchEvents(events []models.PunchEvent) (int64, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&events)
+	return result.RowsAffected, result.Error
+}
+
+// FindPunchEvents returns the events of an employee's work date in punch order
+func (r *AttendanceRepository) FindPunchEvents(employeeID uint, workDate time.Time) ([]models.PunchEvent, error) {
+	var events []models.PunchEvent
+	err := r.db.Where("employee_id = ? AND work_date = ?", employeeID, workDate).
+		Order("punched_at ASC, id ASC").
+		Find(&events).Error
+	return events, err
+}
+
+func (r *AttendanceRepository) FindPunchEventByID(id uint) (*models.PunchEvent, error) {
+	var event models.PunchEvent
+	err := r.db.First(&event, id).Error
+	return &event, err
+}
+
+// IsPunchEventVoided reports whether a void event points to the event
+func (r *AttendanceRepository) IsPunchEventVoided(id uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.PunchEvent{}).Where("voids_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+// FindPunchedEmployeeIDs returns the employees with punch events on the work date
+func (r *AttendanceRepository) FindPunchedEmployeeIDs(workDate time.Time) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.PunchEvent{}).Where("work_date = ?", workDate).Distinct().Pluck("employee_id", &ids).Error
+	return ids, err
+}
+
+// FindForAnomalyCheck returns the attendance within the range with their employees
+func (r *AttendanceRepository) FindForAnomalyCheck(startDate, endDate time.Time, departmentID *uint) ([]models.Attendance, error) {
+	var attendances []models.Attendance
+
+	query := r.db.Preload("Employee", employeeSummary).
+		Where("attendances.date BETWEEN ? AND ?", startDate, endDate)
+
+	if departmentID != nil {
+		query = query.Joins("JOIN employees ON employees.id = attendances.employee_id").
+			Where("employees.department_id = ?", *departmentID)
+	}
+
+	err := query.Order("attendances.date ASC, attendances.id ASC").Find(&attendances).Error
+	return attendances, err
+}
+
+// FindPunchEventsInRange returns the events of every employee within the range,
+// by employee in punch order
+func (r *AttendanceRepository) FindPunchEventsInRange(startDate, endDate time.Time) ([]models.PunchEvent, error) {
+	var events []models.PunchEvent
+	err := r.db.Where("work_date BETWEEN ? AND ?", startDate, endDate).
+		Order("employee_id ASC, punched_at ASC, id ASC").
+		Find(&events).Error
+	return events, err
+}
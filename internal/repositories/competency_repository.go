@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"hr-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CompetencyRepository struct {
+	db *gorm.DB
+}
+
+func NewCompetencyRepository(db *gorm.DB) *CompetencyRepository {
+	return &CompetencyRepository{db: db}
+}
+
+func (r *CompetencyRepository) CreateSkill(skill *models.EmployeeSkill) error {
+	return r.db.Create(skill).Error
+}
+
+func (r *CompetencyRepository) FindSkillByID(id uint) (*models.EmployeeSkill, error) {
+	var skill models.EmployeeSkill
+	err := r.db.First(&skill, id).Error
+	return &skill, err
+}
+
+func (r *CompetencyRepository) FindSkillByName(employeeID uint, name string) (*models.EmployeeSkill, error) {
+	var skill models.EmployeeSkill
+	err := r.db.Where("employee_id = ? AND LOWER(name) = LOWER(?)", employeeID, name).First(&skill).Error
+	return &skill, err
+}
+
+func (r *CompetencyRepository) FindSkillsByEmployee(employeeID uint) ([]models.EmployeeSkill, error) {
+	var skills []models.EmployeeSkill
+	err := r.db.Where("employee_id = ?", employeeID).Order("name ASC").Find(&skills).Error
+	return skills, err
+}
+
+// SearchSkills finds employees holding a skill at one of the given levels
+func (r *CompetencyRepository) SearchSkills(name string, levels []string, departmentID *uint) ([]models.EmployeeSkill, error) {
+	var skills []models.EmployeeSkill
+
+	query := r.db.Preload("Employee", employeeSummary).Preload("Employee.Department").
+		Joins("JOIN employees ON employees.id = employee_skills.employee_id AND employees.deleted_at IS NULL").
+		Where("employee_skills.name ILIKE ?", "%"+name+"%")
+
+	if len(levels) > 0 {
+		query = query.Where("employee_skills.level IN ?", levels)
+	}
+
+	if departmentID != nil {
+		query = query.Where("employees.department_id = ?", *departmentID)
+	}
+
+	err := query.Order("employee_skills.name ASC, employees.first_name ASC").Find(&skills).Error
+	return skills, err
+}
+
+func (r *CompetencyRepository) UpdateSkill(skill *models.EmployeeSkill) error {
+	return r.db.Omit("Employee").Save(skill).Error
+}
+
+func (r *CompetencyRepository) DeleteSkill(id uint) error {
+	return r.db.Delete(&models.EmployeeSkill{}, id).Error
+}
+
+func (r *CompetencyRepository) CreateCertification(certification *models.Certification) error {
+	return r.db.Create(certification).Error
+}
+
+func (r *CompetencyRepository) FindCertificationByID(id uint) (*models.Certification, error) {
+	var certification models.Certification
+	err := r.db.Preload("Employee", employeeSummary).Preload("Files").First(&certification, id).Error
+	return &certification, err
+}
+
+func (r *CompetencyRepository) FindCertificationsByEmployee(employeeID uint) ([]models.Certification, error) {
+	var certifications []models.Certification
+	err := r.db.Preload("Files").
+		Where("employee_id = ?", employeeID).
+		Order("expiry_date ASC NULLS LAST, name ASC").
+		Find(&certifications).Error
+	return certifications, err
+}
+
+func (r *CompetencyRepository) SearchCertifications(filter models.CertificationFilter, page, limit int) ([]models.Certification, int64, error) {
+	var certifications []models.Certification
+	var total int64
+
+	query := r.db.Model(&models.Certification{}).
+		Preload("Employee", employeeSummary).Preload("Employee.Department").
+		Joins("JOIN employees ON employees.id = certifications.employee_id AND employees.deleted_at IS NULL")
+
+	if filter.Name != "" {
+		query = query.Where("certifications.name ILIKE ?", "%"+filter.Name+"%")
+	}
+
+	if filter.EmployeeID != nil {
+		query = query.Where("certifications.employee_id = ?", *filter.EmployeeID)
+	}
+
+	if filter.DepartmentID != nil {
+		query = query.Where("employees.department_id = ?", *filter.DepartmentID)
+	}
+
+	if filter.ValidOn != nil {
+		query = query.Where("certifications.issue_date <= ? AND (certifications.expiry_date IS NULL OR certifications.expiry_date >= ?)",
+			*filter.ValidOn, *filter.ValidOn)
+	}
+
+	if filter.ExpiringBefore != nil {
+		query = query.Where("certifications.expiry_date IS NOT NULL AND certifications.expiry_date <= ?", *filter.ExpiringBefore)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("certifications.expiry_date ASC NULLS LAST, certifications.name ASC").
+		Offset(offset).Limit(limit).Find(&certifications).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return certifications, total, nil
+}
+
+// FindCertificationsToRemind returns certificates of current employees expiring on or
+// before the cutoff that have not been reminded about yet
+func (r *CompetencyRepository) FindCertificationsToRemind(expiringBy time.Time) ([]models.Certification, error) {
+	var certifications []models.Certification
+	err := r.db.Preload("Employee", employeeSummary).Preload("Employee.Department").
+		Joins("JOIN employees ON employees.id = certifications.employee_id AND employees.deleted_at IS NULL").
+		Where("employees.employment_status IN ?", models.WorkingEmploymentStatuses).
+		Where("certifications.expiry_date IS NOT NULL AND certifications.expiry_date <= ?", expiringBy).
+		Where("certifications.reminded_at IS NULL").
+		Find(&certifications).Error
+	return certifications, err
+}
+
+func (r *CompetencyRepository) UpdateCertification(certification *models.Certification) error {
+	return r.db.Omit("Employee", "Files").Save(certification).Error
+}
+
+func (r *CompetencyRepository) MarkCertificationReminded(id uint, at time.Time) error {
+	return r.db.Model(&models.Certification{}).Where("id = ?", id).Update("reminded_at", at).Error
+}
+
+// DeleteCertification removes the certificate and permanently removes its file records,
+// since the scans themselves are deleted from storage
+func (r *CompetencyRepository) DeleteCertification(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("certification_id = ?", id).Delete(&models.CertificationFile{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Certification{}, id).Error
+	})
+}
+
+func (r *CompetencyRepository) CreateFile(file *models.CertificationFile) error {
+	return r.db.Create(file).Error
+}
+
+func (r *CompetencyRepository) FindFile(certificationID, fileID uint) (*models.CertificationFile, error) {
+	var file models.CertificationFile
+	err := r.db.Where("certification_id = ?", certificationID).First(&file, fileID).Error
+	return &file, err
+}
+
+// DeleteFile removes the file record permanently, along with the scan in storage
+func (r *CompetencyRepository) DeleteFile(id uint) error {
+	return r.db.Unscoped().Delete(&models.CertificationFile{}, id).Error
+}
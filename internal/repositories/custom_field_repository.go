@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"hr-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type CustomFieldRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomFieldRepository(db *gorm.DB) *CustomFieldRepository {
+	return &CustomFieldRepository{db: db}
+}
+
+func (r *CustomFieldRepository) Create(definition *models.CustomFieldDefinition) error {
+	return r.db.Create(definition).Error
+}
+
+func (r *CustomFieldRepository) FindByEntity(entityType string) ([]models.CustomFieldDefinition, error) {
+	var definitions []models.CustomFieldDefinition
+	err := r.db.Where("entity_type = ?", entityType).
+		Order("sort_order ASC, id ASC").
+		Find(&definitions).Error
+	return definitions, err
+}
+
+func (r *CustomFieldRepository) FindByID(id uint) (*models.CustomFieldDefinition, error) {
+	var definition models.CustomFieldDefinition
+	err := r.db.First(&definition, id).Error
+	return &definition, err
+}
+
+func (r *CustomFieldRepository) FindByEntityAndKey(entityType, key string) (*models.CustomFieldDefinition, error) {
+	var definition models.CustomFieldDefinition
+	err := r.db.Where("entity_type = ? AND key = ?", entityType, key).First(&definition).Error
+	return &definition, err
+}
+
+func (r *CustomFieldRepository) Update(definition *models.CustomFieldDefinition) error {
+	return r.db.Save(definition).Error
+}
+
+// Delete removes the definition permanently so its key can be reused.
+// Values already stored on records are left untouched.
+func (r *CustomFieldRepository) Delete(id uint) error {
+	return r.db.Unscoped().Delete(&models.CustomFieldDefinition{}, id).Error
+}
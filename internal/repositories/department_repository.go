@@ -20,13 +20,13 @@ func (r *DepartmentRepository) Create(department *models.Department) error {
 
 func (r *DepartmentRepository) FindAll() ([]models.Department, error) {
 	var departments []models.Department
-	err := r.db.Preload("Manager").Find(&departments).Error
+	err := r.db.Preload("Manager", employeeSummary).Find(&departments).Error
 	return departments, err
 }
 
 func (r *DepartmentRepository) FindByID(id uint) (*models.Department, error) {
 	var department models.Department
-	err := r.db.Preload("Manager").Preload("Employees").First(&department, id).Error
+	err := r.db.Preload("Manager", employeeSummary).Preload("Employees", employeeSummary).Preload("RemoteWorkPolicy").First(&department, id).Error
 	return &department, err
 }
 
@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"hr-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeviceRepository struct {
+	db *gorm.DB
+}
+
+func NewDeviceRepository(db *gorm.DB) *DeviceRepository {
+	return &DeviceRepository{db: db}
+}
+
+func (r *DeviceRepository) FindAll() ([]models.AttendanceDevice, error) {
+	var devices []models.AttendanceDevice
+	err := r.db.Preload("WorkLocation").Order("name ASC").Find(&devices).Error
+	return devices, err
+}
+
+func (r *DeviceRepository) FindByID(id uint) (*models.AttendanceDevice, error) {
+	var device models.AttendanceDevice
+	err := r.db.Preload("WorkLocation").First(&device, id).Error
+	return &device, err
+}
+
+func (r *DeviceRepository) FindByName(name string) (*models.AttendanceDevice, error) {
+	var device models.AttendanceDevice
+	err := r.db.Where("LOWER(name) = LOWER(?)", name).First(&device).Error
+	return &device, err
+}
+
+func (r *DeviceRepository) Save(device *models.AttendanceDevice) error {
+	return r.db.Omit("WorkLocation").Save(device).Error
+}
+
+// Delete removes the device with its user mappings; the import history is kept
+func (r *DeviceRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("device_id = ?", id).Delete(&models.DeviceUser{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.AttendanceDevice{}, id).Error
+	})
+}
+
+func (r *DeviceRepository) FindUsers(deviceID uint) ([]models.DeviceUser, error) {
+	var users []models.DeviceUser
+	err := r.db.Preload("Employee", employeeSummary).
+		Where("device_id = ?", deviceID).
+		Order("device_user_id ASC").
+		Find(&users).Error
+	return users, err
+}
+
+func (r *DeviceRepository) FindUserByID(id uint) (*models.DeviceUser, error) {
+	var user models.DeviceUser
+	err := r.db.First(&user, id).Error
+	return &user, err
+}
+
+// UpsertUsers creates the mappings or points existing device user IDs to a new employee
+func (r *DeviceRepository) UpsertUsers(users []models.DeviceUser) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "device_id"}, {Name: "device_user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"employee_id", "updated_at", "deleted_at"}),
+	}).Create(&users).Error
+}
+
+// DeleteUser removes the mapping permanently so the device user ID can be mapped again
+func (r *DeviceRepository) DeleteUser(id uint) error {
+	return r.db.Unscoped().Delete(&models.DeviceUser{}, id).Error
+}
+
+func (r *DeviceRepository) FindImports(deviceID uint, page, limit int) ([]models.DeviceImport, int64, error) {
+	var imports []models.DeviceImport
+	var total int64
+
+	query := r.db.Model(&models.DeviceImport{}).Where("device_id = ?", deviceID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("imported_at DESC").Offset(offset).Limit(limit).Find(&imports).Error
+	return imports, total, err
+}
+
+func (r *DeviceRepository) FindImportByHash(deviceID uint, hash string) (*models.DeviceImport, error) {
+	var record models.DeviceImport
+	err := r.db.Where("device_id = ? AND file_hash = ?", deviceID, hash).First(&record).Error
+	return &record, err
+}
+
+func (r *DeviceRepository) CreateImport(record *models.DeviceImport) error {
+	return r.db.Create(record).Error
+}
@@ -1,11 +1,48 @@
 package repositories
 
 import (
+	"hr-backend/internal/fieldcrypt"
 	"hr-backend/internal/models"
+	"html"
+	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// employeeSearchDocument builds the denormalized text that employee search runs against
+const employeeSearchDocument = `concat_ws(' ',
+	first_name, last_name, employee_code, position,
+	(SELECT email FROM users WHERE users.id = employees.user_id),
+	(SELECT name FROM departments WHERE departments.id = employees.department_id))`
+
+const (
+	// employeeSearchMatch matches full words, substrings (both served by the
+	// GIN indexes) and misspelled words through trigram word similarity.
+	// Encrypted phone and national ID numbers only match exactly, via their blind indexes.
+	employeeSearchMatch = `(to_tsvector('simple', coalesce(employees.search_text, '')) @@ plainto_tsquery('simple', @q)
+		OR employees.search_text ILIKE @pattern
+		OR @q <% employees.search_text
+		OR employees.phone_index = @phone_index
+		OR employees.national_id_index = @national_id_index)`
+	employeeSearchRank = `ts_rank(to_tsvector('simple', coalesce(employees.search_text, '')), plainto_tsquery('simple', @q))
+		+ word_similarity(@q, employees.search_text)`
+	// employeeSearchHighlight marks matches with control characters stripped from
+	// the document, so escapeSearchHighlight can escape the text before adding markup
+	employeeSearchHighlight = `ts_headline('simple', translate(employees.search_text, chr(1) || chr(2), ''),
+		plainto_tsquery('simple', @q), 'StartSel=' || chr(1) || ', StopSel=' || chr(2) || ', HighlightAll=true')`
+
+	// searchSimilarityThreshold lowers pg_trgm's default of 0.6 so single typos still match
+	searchSimilarityThreshold = "0.3"
+)
+
+// employeeSummary limits preloaded employees to columns without personal data,
+// for records that any authenticated user can read
+func employeeSummary(db *gorm.DB) *gorm.DB {
+	return db.Select("id", "created_at", "updated_at", "user_id", "employee_code", "first_name", "last_name",
+		"gender", "department_id", "position", "hire_date", "employment_status", "profile_picture")
+}
+
 type EmployeeRepository struct {
 	db *gorm.DB
 }
@@ -18,40 +55,160 @@ func (r *EmployeeRepository) Create(employee *models.Employee) error {
 	return r.db.Create(employee).Error
 }
 
-func (r *EmployeeRepository) FindAll(page, limit int, departmentID *uint, status, search string) ([]models.Employee, int64, error) {
+func (r *EmployeeRepository) FindAll(page, limit int, departmentID *uint, status, search string, customFilters map[string]string) ([]models.Employee, int64, error) {
 	var employees []models.Employee
 	var total int64
 
-	query := r.db.Model(&models.Employee{}).Preload("User").Preload("Department")
+	err := r.withSearchSession(search, func(db *gorm.DB) error {
+		query := db.Model(&models.Employee{}).Preload("User").Preload("Department")
+
+		if departmentID != nil {
+			query = query.Where("department_id = ?", *departmentID)
+		}
 
-	if departmentID != nil {
-		query = query.Where("department_id = ?", *departmentID)
+		if status != "" {
+			query = query.Where("employment_status = ?", status)
+		}
+
+		if search != "" {
+			query = query.Where(employeeSearchMatch, searchArgs(search))
+		}
+
+		for key, value := range customFilters {
+			query = query.Where("custom_fields ->> ? = ?", key, value)
+		}
+
+		if err := query.Count(&total).Error; err != nil {
+			return err
+		}
+
+		if search != "" {
+			query = query.
+				Select("employees.*, "+employeeSearchHighlight+" AS search_highlight", searchArgs(search)).
+				Clauses(clause.OrderBy{Expression: clause.NamedExpr{SQL: employeeSearchRank + " DESC", Vars: []interface{}{searchArgs(search)}}})
+		}
+
+		offset := (page - 1) * limit
+		return query.Offset(offset).Limit(limit).Find(&employees).Error
+	})
+	if err != nil {
+		return nil, 0, err
 	}
 
-	if status != "" {
-		query = query.Where("employment_status = ?", status)
+	for i := range employees {
+		employees[i].SearchHighlight = escapeSearchHighlight(employees[i].SearchHighlight)
 	}
 
-	if search != "" {
-		query = query.Where(
-			"first_name ILIKE ? OR last_name ILIKE ? OR employee_code ILIKE ?",
-			"%"+search+"%", "%"+search+"%", "%"+search+"%",
-		)
+	return employees, total, nil
+}
+
+// Suggest returns the best matching employees for autocomplete pickers
+func (r *EmployeeRepository) Suggest(search string, limit int) ([]models.EmployeeSuggestion, error) {
+	var suggestions []models.EmployeeSuggestion
+
+	err := r.withSearchSession(search, func(db *gorm.DB) error {
+		return db.Model(&models.Employee{}).
+			Select(`employees.id, employees.employee_code, employees.position,
+				concat_ws(' ', employees.first_name, employees.last_name) AS full_name,
+				(SELECT name FROM departments WHERE departments.id = employees.department_id) AS department_name,
+				`+employeeSearchHighlight+` AS highlight,
+				`+employeeSearchRank+` AS score`, searchArgs(search)).
+			Where(employeeSearchMatch, searchArgs(search)).
+			Order("score DESC").
+			Limit(limit).
+			Scan(&suggestions).Error
+	})
+
+	for i := range suggestions {
+		suggestions[i].Highlight = escapeSearchHighlight(suggestions[i].Highlight)
 	}
 
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	return suggestions, err
+}
+
+// RefreshSearchText rebuilds the search document of a single employee
+func (r *EmployeeRepository) RefreshSearchText(id uint) error {
+	return r.db.Exec("UPDATE employees SET search_text = "+employeeSearchDocument+" WHERE id = ?", id).Error
+}
+
+// RefreshSearchTextByDepartment rebuilds the search documents after a department is renamed
+func (r *EmployeeRepository) RefreshSearchTextByDepartment(departmentID uint) error {
+	return r.db.Exec("UPDATE employees SET search_text = "+employeeSearchDocument+" WHERE department_id = ?", departmentID).Error
+}
+
+// RefreshAllSearchText rebuilds the search documents of every employee
+func (r *EmployeeRepository) RefreshAllSearchText() error {
+	return r.db.Exec("UPDATE employees SET search_text = " + employeeSearchDocument).Error
+}
+
+// withSearchSession runs fn in a transaction with a relaxed trigram threshold
+// when a search term is present, so the setting never leaks to pooled connections
+func (r *EmployeeRepository) withSearchSession(search string, fn func(db *gorm.DB) error) error {
+	if search == "" {
+		return fn(r.db)
 	}
 
-	offset := (page - 1) * limit
-	err := query.Offset(offset).Limit(limit).Find(&employees).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL pg_trgm.word_similarity_threshold = " + searchSimilarityThreshold).Error; err != nil {
+			return err
+		}
+		return fn(tx)
+	})
+}
+
+// likeEscaper makes LIKE wildcards in a search term match literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
 
-	return employees, total, err
+// escapeSearchHighlight HTML-escapes employee-entered text in a highlight and
+// turns the match markers of employeeSearchHighlight into <mark> tags
+func escapeSearchHighlight(highlight string) string {
+	return strings.NewReplacer("\x01", "<mark>", "\x02", "</mark>").Replace(html.EscapeString(highlight))
+}
+
+func searchArgs(search string) map[string]interface{} {
+	return map[string]interface{}{
+		"q":                 search,
+		"pattern":           "%" + likeEscaper.Replace(search) + "%",
+		"phone_index":       blindIndexArg("phone", models.NormalizePhone(search)),
+		"national_id_index": blindIndexArg("national_id", search),
+	}
+}
+
+// blindIndexArg returns NULL rather than an empty index so that employees
+// without a value never match
+func blindIndexArg(domain, value string) interface{} {
+	if index := fieldcrypt.BlindIndex(domain, value); index != "" {
+		return index
+	}
+	return nil
 }
 
 func (r *EmployeeRepository) FindByID(id uint) (*models.Employee, error) {
 	var employee models.Employee
-	err := r.db.Preload("User").Preload("Department").First(&employee, id).Error
+	err := r.db.Preload("User").Preload("Department").Preload("Dependents").First(&employee, id).Error
+	return &employee, err
+}
+
+// FindByIDs loads several employees without their associations
+func (r *EmployeeRepository) FindByIDs(ids []uint) ([]models.Employee, error) {
+	var employees []models.Employee
+	if len(ids) == 0 {
+		return employees, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&employees).Error
+	return employees, err
+}
+
+// FindByNationalID looks an employee up through the national ID blind index
+func (r *EmployeeRepository) FindByNationalID(nationalID string) (*models.Employee, error) {
+	var employee models.Employee
+	err := r.db.Where("national_id_index = ?", fieldcrypt.BlindIndex("national_id", nationalID)).First(&employee).Error
+	return &employee, err
+}
+
+func (r *EmployeeRepository) FindByUserID(userID uint) (*models.Employee, error) {
+	var employee models.Employee
+	err := r.db.Preload("Department").Preload("Dependents").Where("user_id = ?", userID).First(&employee).Error
 	return &employee, err
 }
 
@@ -62,7 +219,20 @@ func (r *EmployeeRepository) FindByEmployeeCode(code string) (*models.Employee,
 }
 
 func (r *EmployeeRepository) Update(employee *models.Employee) error {
-	return r.db.Save(employee).Error
+	return r.db.Omit("Dependents", "Assets").Save(employee).Error
+}
+
+// ReplaceDependents swaps the employee's dependents for the given list
+func (r *EmployeeRepository) ReplaceDependents(employeeID uint, dependents []models.EmployeeDependent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("employee_id = ?", employeeID).Delete(&models.EmployeeDependent{}).Error; err != nil {
+			return err
+		}
+		if len(dependents) == 0 {
+			return nil
+		}
+		return tx.Create(&dependents).Error
+	})
 }
 
 func (r *EmployeeRepository) Delete(id uint) error {
@@ -75,6 +245,18 @@ func (r *EmployeeRepository) CountByStatus(status string) (int64, error) {
 	return count, err
 }
 
+func (r *EmployeeRepository) CountByStatuses(statuses []string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Employee{}).Where("employment_status IN ?", statuses).Count(&count).Error
+	return count, err
+}
+
+func (r *EmployeeRepository) FindByStatuses(statuses []string) ([]models.Employee, error) {
+	var employees []models.Employee
+	err := r.db.Where("employment_status IN ?", statuses).Order("id ASC").Find(&employees).Error
+	return employees, err
+}
+
 func (r *EmployeeRepository) CountByDepartment(departmentID uint) (int64, error) {
 	var count int64
 	err := r.db.Model(&models.Employee{}).Where("department_id = ?", departmentID).Count(&count).Error
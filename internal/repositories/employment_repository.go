@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"hr-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type EmploymentRepository struct {
+	db *gorm.DB
+}
+
+func NewEmploymentRepository(db *gorm.DB) *EmploymentRepository {
+	return &EmploymentRepository{db: db}
+}
+
+func (r *EmploymentRepository) FindStatusHistory(employeeID uint) ([]models.EmploymentStatusHistory, error) {
+	var history []models.EmploymentStatusHistory
+	err := r.db.Where("employee_id = ?", employeeID).Order("effective_date DESC, id DESC").Find(&history).Error
+	return history, err
+}
+
+func (r *EmploymentRepository) FindContractRenewals(employeeID uint) ([]models.ContractRenewal, error) {
+	var renewals []models.ContractRenewal
+	err := r.db.Where("employee_id = ?", employeeID).Order("created_at DESC").Find(&renewals).Error
+	return renewals, err
+}
+
+// FindProbationEnding returns employees still on probation whose probation ends on or before the given date
+func (r *EmploymentRepository) FindProbationEnding(before time.Time) ([]models.Employee, error) {
+	var employees []models.Employee
+	err := r.db.Preload("Department").
+		Where("employment_status = ? AND probation_end_date <= ?", models.EmploymentStatusProbation, before).
+		Order("probation_end_date ASC").
+		Find(&employees).Error
+	return employees, err
+}
+
+// FindContractEnding returns working employees on a fixed-term contract ending on or before the given date
+func (r *EmploymentRepository) FindContractEnding(before time.Time) ([]models.Employee, error) {
+	var employees []models.Employee
+	err := r.db.Preload("Department").
+		Where("contract_type = ? AND contract_end_date <= ? AND employment_status IN ?", models.ContractTypePKWT, before, models.WorkingEmploymentStatuses).
+		Order("contract_end_date ASC").
+		Find(&employees).Error
+	return employees, err
+}
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"hr-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type HolidayRepository struct {
+	db *gorm.DB
+}
+
+func NewHolidayRepository(db *gorm.DB) *HolidayRepository {
+	return &HolidayRepository{db: db}
+}
+
+func (r *HolidayRepository) FindAllCalendars() ([]models.HolidayCalendar, error) {
+	var calendars []models.HolidayCalendar
+	err := r.db.Order("is_default DESC, name ASC").Find(&calendars).Error
+	return calendars, err
+}
+
+func (r *HolidayRepository) FindCalendarByID(id uint) (*models.HolidayCalendar, error) {
+	var calendar models.HolidayCalendar
+	err := r.db.First(&calendar, id).Error
+	return &calendar, err
+}
+
+func (r *HolidayRepository) FindCalendarByName(name string) (*models.HolidayCalendar, error) {
+	var calendar models.HolidayCalendar
+	err := r.db.Where("LOWER(name) = LOWER(?)", name).First(&calendar).Error
+	return &calendar, err
+}
+
+func (r *HolidayRepository) FindDefaultCalendar() (*models.HolidayCalendar, error) {
+	var calendar models.HolidayCalendar
+	err := r.db.Where("is_default = ?", true).First(&calendar).Error
+	return &calendar, err
+}
+
+// SaveCalendar stores the calendar; making it the default clears the flag on every other calendar
+func (r *HolidayRepository) SaveCalendar(calendar *models.HolidayCalendar) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if calendar.IsDefault {
+			if err := tx.Model(&models.HolidayCalendar{}).Where("id <> ? AND is_default = ?", calendar.ID, true).
+				Update("is_default", false).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Save(calendar).Error
+	})
+}
+
+// DeleteCalendar removes the calendar with its holidays and detaches it from work locations
+func (r *HolidayRepository) DeleteCalendar(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.WorkLocation{}).Where("holiday_calendar_id = ?", id).
+			Update("holiday_calendar_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("calendar_id = ?", id).Delete(&models.Holiday{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.HolidayCalendar{}, id).Error
+	})
+}
+
+// FindHolidays lists a calendar's holidays, limited to those occurring in the year when given
+func (r *HolidayRepository) FindHolidays(calendarID uint, year *int) ([]models.Holiday, error) {
+	var holidays []models.Holiday
+
+	query := r.db.Where("calendar_id = ?", calendarID)
+	if year != nil {
+		query = query.Where("(recurring = ? AND EXTRACT(YEAR FROM date) = ?) OR (recurring = ? AND EXTRACT(YEAR FROM date) <= ?)",
+			false, *year, true, *year)
+	}
+
+	err := query.Order("EXTRACT(MONTH FROM date) ASC, EXTRACT(DAY FROM date) ASC, name ASC").Find(&holidays).Error
+	return holidays, err
+}
+
+// FindHolidaysInRange returns the one-off holidays within the range and every
+// recurring holiday that started on or before its end
+func (r *HolidayRepository) FindHolidaysInRange(calendarIDs []uint, startDate, endDate time.Time) ([]models.Holiday, error) {
+	var holidays []models.Holiday
+	if len(calendarIDs) == 0 {
+		return holidays, nil
+	}
+
+	err := r.db.Where("calendar_id IN ?", calendarIDs).
+		Where("(recurring = ? AND date BETWEEN ? AND ?) OR (recurring = ? AND date <= ?)", false, startDate, endDate, true, endDate).
+		Order("date ASC").
+		Find(&holidays).Error
+	return holidays, err
+}
+
+func (r *HolidayRepository) FindHolidayByID(id uint) (*models.Holiday, error) {
+	var holiday models.Holiday
+	err := r.db.First(&holiday, id).Error
+	return &holiday, err
+}
+
+func (r *HolidayRepository) FindHolidayByUID(calendarID uint, uid string) (*models.Holiday, error) {
+	var holiday models.Holiday
+	err := r.db.Where("calendar_id = ? AND uid = ?", calendarID, uid).First(&holiday).Error
+	return &holiday, err
+}
+
+func (r *HolidayRepository) SaveHoliday(holiday *models.Holiday) error {
+	return r.db.Save(holiday).Error
+}
+
+// DeleteHoliday removes the holiday permanently so a re-import can recreate it
+func (r *HolidayRepository) DeleteHoliday(id uint) error {
+	return r.db.Unscoped().Delete(&models.Holiday{}, id).Error
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"hr-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type KioskRepository struct {
+	db *gorm.DB
+}
+
+func NewKioskRepository(db *gorm.DB) *KioskRepository {
+	return &KioskRepository{db: db}
+}
+
+func (r *KioskRepository) FindAll() ([]models.Kiosk, error) {
+	var kiosks []models.Kiosk
+	err := r.db.Preload("WorkLocation").Order("name ASC").Find(&kiosks).Error
+	return kiosks, err
+}
+
+func (r *KioskRepository) FindByID(id uint) (*models.Kiosk, error) {
+	var kiosk models.Kiosk
+	err := r.db.Preload("WorkLocation").First(&kiosk, id).Error
+	return &kiosk, err
+}
+
+func (r *KioskRepository) FindByName(name string) (*models.Kiosk, error) {
+	var kiosk models.Kiosk
+	err := r.db.Where("name = ?", name).First(&kiosk).Error
+	return &kiosk, err
+}
+
+func (r *KioskRepository) FindByTokenHash(hash string) (*models.Kiosk, error) {
+	var kiosk models.Kiosk
+	err := r.db.Where("token_hash = ?", hash).First(&kiosk).Error
+	return &kiosk, err
+}
+
+func (r *KioskRepository) Save(kiosk *models.Kiosk) error {
+	return r.db.Omit("WorkLocation").Save(kiosk).Error
+}
+
+func (r *KioskRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Kiosk{}, id).Error
+}
+
+func (r *KioskRepository) TouchLastSeen(id uint, seenAt time.Time) error {
+	return r.db.Model(&models.Kiosk{}).Where("id = ?", id).UpdateColumn("last_seen_at", seenAt).Error
+}
+
+func (r *KioskRepository) FindPin(employeeID uint) (*models.KioskPin, error) {
+	var pin models.KioskPin
+	err := r.db.Where("employee_id = ?", employeeID).First(&pin).Error
+	return &pin, err
+}
+
+func (r *KioskRepository) SavePin(pin *models.KioskPin) error {
+	return r.db.Omit("Employee").Save(pin).Error
+}
@@ -23,7 +23,7 @@ func (r *LeaveRepository) FindAll(employeeID *uint, status string, page, limit i
 	var leaves []models.Leave
 	var total int64
 
-	query := r.db.Model(&models.Leave{}).Preload("Employee").Preload("Approver")
+	query := r.db.Model(&models.Leave{}).Preload("Employee", employeeSummary).Preload("Approver")
 
 	if employeeID != nil {
 		query = query.Where("employee_id = ?", *employeeID)
@@ -45,10 +45,22 @@ func (r *LeaveRepository) FindAll(employeeID *uint, status string, page, limit i
 
 func (r *LeaveRepository) FindByID(id uint) (*models.Leave, error) {
 	var leave models.Leave
-	err := r.db.Preload("Employee").Preload("Approver").First(&leave, id).Error
+	err := r.db.Preload("Employee", employeeSummary).Preload("Approver").First(&leave, id).Error
 	return &leave, err
 }
 
+// FindApprovedInRange returns the approved leaves of the employees that overlap the range
+func (r *LeaveRepository) FindApprovedInRange(employeeIDs []uint, startDate, endDate time.Time) ([]models.Leave, error) {
+	var leaves []models.Leave
+	if len(employeeIDs) == 0 {
+		return leaves, nil
+	}
+
+	err := r.db.Where("employee_id IN ? AND status = ? AND start_date <= ? AND end_date >= ?", employeeIDs, "approved", endDate, startDate).
+		Find(&leaves).Error
+	return leaves, err
+}
+
 func (r *LeaveRepository) Update(leave *models.Leave) error {
 	return r.db.Save(leave).Error
 }
@@ -84,11 +96,11 @@ func (r *LeaveRepository) CountPendingLeaves() (int64, error) {
 	return count, err
 }
 
-func (r *LeaveRepository) CountTodayOnLeave() (int64, error) {
-	today := time.Now()
+// CountTodayOnLeave counts the employees on approved leave on what is today where they work
+func (r *LeaveRepository) CountTodayOnLeave(defaultZone string) (int64, error) {
 	var count int64
-	err := r.db.Model(&models.Leave{}).
-		Where("status = ? AND ? BETWEEN start_date AND end_date", "approved", today).
+	err := joinEmployeeZone(r.db.Model(&models.Leave{}), "leaves").
+		Where("leaves.status = ? AND "+employeeTodaySQL+" BETWEEN leaves.start_date AND leaves.end_date", "approved", defaultZone).
 		Count(&count).Error
 	return count, err
 }
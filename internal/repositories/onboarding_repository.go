@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"hr-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OnboardingRepository struct {
+	db *gorm.DB
+}
+
+func NewOnboardingRepository(db *gorm.DB) *OnboardingRepository {
+	return &OnboardingRepository{db: db}
+}
+
+func (r *OnboardingRepository) CreateTemplate(template *models.OnboardingTemplate) error {
+	return r.db.Create(template).Error
+}
+
+func (r *OnboardingRepository) FindAllTemplates() ([]models.OnboardingTemplate, error) {
+	var templates []models.OnboardingTemplate
+	err := r.db.Preload("Department").
+		Preload("Tasks", func(db *gorm.DB) *gorm.DB { return db.Order("sort_order ASC, id ASC") }).
+		Order("name ASC").
+		Find(&templates).Error
+	return templates, err
+}
+
+func (r *OnboardingRepository) FindTemplateByID(id uint) (*models.OnboardingTemplate, error) {
+	var template models.OnboardingTemplate
+	err := r.db.Preload("Department").
+		Preload("Tasks", func(db *gorm.DB) *gorm.DB { return db.Order("sort_order ASC, id ASC") }).
+		First(&template, id).Error
+	return &template, err
+}
+
+// FindMatchingTemplates returns active templates that apply to the department and position.
+// Templates without a department or position act as wildcards.
+func (r *OnboardingRepository) FindMatchingTemplates(departmentID *uint, position string) ([]models.OnboardingTemplate, error) {
+	var templates []models.OnboardingTemplate
+
+	query := r.db.Preload("Tasks", func(db *gorm.DB) *gorm.DB { return db.Order("sort_order ASC, id ASC") }).
+		Where("is_active = ?", true).
+		Where("position = '' OR position IS NULL OR LOWER(position) = LOWER(?)", position)
+
+	if departmentID != nil {
+		query = query.Where("department_id IS NULL OR department_id = ?", *departmentID)
+	} else {
+		query = query.Where("department_id IS NULL")
+	}
+
+	err := query.Find(&templates).Error
+	return templates, err
+}
+
+func (r *OnboardingRepository) UpdateTemplate(template *models.OnboardingTemplate) error {
+	return r.db.Omit("Tasks").Save(template).Error
+}
+
+// ReplaceTemplateTasks swaps the template's task list. Spawned employee tasks keep their copies.
+func (r *OnboardingRepository) ReplaceTemplateTasks(templateID uint, tasks []models.OnboardingTemplateTask) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("template_id = ?", templateID).Delete(&models.OnboardingTemplateTask{}).Error; err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return nil
+		}
+		for i := range tasks {
+			tasks[i].TemplateID = templateID
+		}
+		return tx.Create(&tasks).Error
+	})
+}
+
+func (r *OnboardingRepository) DeleteTemplate(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("template_id = ?", id).Delete(&models.OnboardingTemplateTask{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.OnboardingTemplate{}, id).Error
+	})
+}
+
+func (r *OnboardingRepository) CreateTasks(tasks []models.OnboardingTask) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	return r.db.Create(&tasks).Error
+}
+
+func (r *OnboardingRepository) FindTaskByID(id uint) (*models.OnboardingTask, error) {
+	var task models.OnboardingTask
+	err := r.db.Preload("Employee", employeeSummary).Preload("Assignee").First(&task, id).Error
+	return &task, err
+}
+
+func (r *OnboardingRepository) FindTasksByEmployee(employeeID uint) ([]models.OnboardingTask, error) {
+	var tasks []models.OnboardingTask
+	err := r.db.Preload("Assignee").
+		Where("employee_id = ?", employeeID).
+		Order("due_date ASC, id ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *OnboardingRepository) CountTasksByEmployee(employeeID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.OnboardingTask{}).Where("employee_id = ?", employeeID).Count(&count).Error
+	return count, err
+}
+
+// FindTasksForAssignee returns pending tasks assigned to the user directly or to their role
+func (r *OnboardingRepository) FindTasksForAssignee(userID uint, role string) ([]models.OnboardingTask, error) {
+	var tasks []models.OnboardingTask
+	err := r.db.Preload("Employee", employeeSummary).
+		Where("status = ?", models.OnboardingTaskPending).
+		Where("assignee_user_id = ? OR (assignee_user_id IS NULL AND assignee_role = ?)", userID, role).
+		Order("due_date ASC, id ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *OnboardingRepository) FindOverdueTasks(today time.Time, departmentID *uint) ([]models.OnboardingTask, error) {
+	var tasks []models.OnboardingTask
+
+	query := r.db.Preload("Employee", employeeSummary).Preload("Employee.Department").Preload("Assignee").
+		Where("onboarding_tasks.status = ? AND onboarding_tasks.due_date < ?", models.OnboardingTaskPending, today)
+
+	if departmentID != nil {
+		query = query.Joins("JOIN employees ON employees.id = onboarding_tasks.employee_id").
+			Where("employees.department_id = ?", *departmentID)
+	}
+
+	err := query.Order("onboarding_tasks.due_date ASC").Find(&tasks).Error
+	return tasks, err
+}
+
+// FindTasksToRemind returns pending tasks due on or before the cutoff that have not been reminded since the given time
+func (r *OnboardingRepository) FindTasksToRemind(dueBy, remindedBefore time.Time) ([]models.OnboardingTask, error) {
+	var tasks []models.OnboardingTask
+	err := r.db.Preload("Employee", employeeSummary).
+		Where("status = ? AND due_date <= ?", models.OnboardingTaskPending, dueBy).
+		Where("last_reminded_at IS NULL OR last_reminded_at < ?", remindedBefore).
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *OnboardingRepository) UpdateTask(task *models.OnboardingTask) error {
+	return r.db.Omit("Employee", "Assignee").Save(task).Error
+}
+
+func (r *OnboardingRepository) DeleteTask(id uint) error {
+	return r.db.Delete(&models.OnboardingTask{}, id).Error
+}
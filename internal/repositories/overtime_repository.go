@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"hr-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OvertimeRepository struct {
+	db *gorm.DB
+}
+
+func NewOvertimeRepository(db *gorm.DB) *OvertimeRepository {
+	return &OvertimeRepository{db: db}
+}
+
+func (r *OvertimeRepository) Create(request *models.OvertimeRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *OvertimeRepository) FindAll(status string, employeeID, departmentID *uint, page, limit int) ([]models.OvertimeRequest, int64, error) {
+	var requests []models.OvertimeRequest
+	var total int64
+
+	query := r.db.Model(&models.OvertimeRequest{}).Preload("Employee", employeeSummary)
+
+	if status != "" {
+		query = query.Where("overtime_requests.status = ?", status)
+	}
+
+	if employeeID != nil {
+		query = query.Where("overtime_requests.employee_id = ?", *employeeID)
+	}
+
+	if departmentID != nil {
+		query = query.Joins("JOIN employees ON employees.id = overtime_requests.employee_id").
+			Where("employees.department_id = ?", *departmentID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("overtime_requests.date DESC, overtime_requests.id DESC").Offset(offset).Limit(limit).Find(&requests).Error
+	return requests, total, err
+}
+
+func (r *OvertimeRepository) FindByEmployee(employeeID uint) ([]models.OvertimeRequest, error) {
+	var requests []models.OvertimeRequest
+	err := r.db.Where("employee_id = ?", employeeID).Order("date DESC, id DESC").Find(&requests).Error
+	return requests, err
+}
+
+func (r *OvertimeRepository) FindByID(id uint) (*models.OvertimeRequest, error) {
+	var request models.OvertimeRequest
+	err := r.db.Preload("Employee", employeeSummary).Preload("Employee.Department").First(&request, id).Error
+	return &request, err
+}
+
+// FindOpenOnDate returns a pending or approved request of the employee for the date
+func (r *OvertimeRepository) FindOpenOnDate(employeeID uint, date time.Time) (*models.OvertimeRequest, error) {
+	var request models.OvertimeRequest
+	err := r.db.Where("employee_id = ? AND date = ? AND status IN ?", employeeID, date,
+		[]string{models.OvertimePending, models.OvertimeApproved}).
+		First(&request).Error
+	return &request, err
+}
+
+func (r *OvertimeRepository) FindApproved(employeeID uint, date time.Time) (*models.OvertimeRequest, error) {
+	var request models.OvertimeRequest
+	err := r.db.Where("employee_id = ? AND date = ? AND status = ?", employeeID, date, models.OvertimeApproved).
+		First(&request).Error
+	return &request, err
+}
+
+// SumOpenHours adds up the pending and approved hours of the employee within the range
+func (r *OvertimeRepository) SumOpenHours(employeeID uint, startDate, endDate time.Time) (float64, error) {
+	var hours float64
+	err := r.db.Model(&models.OvertimeRequest{}).
+		Where("employee_id = ? AND date BETWEEN ? AND ? AND status IN ?", employeeID, startDate, endDate,
+			[]string{models.OvertimePending, models.OvertimeApproved}).
+		Select("COALESCE(SUM(hours), 0)").
+		Scan(&hours).Error
+	return hours, err
+}
+
+func (r *OvertimeRepository) Update(request *models.OvertimeRequest) error {
+	return r.db.Omit("Employee").Save(request).Error
+}
@@ -72,6 +72,7 @@ func (r *PayrollRepository) GetPayrollSummary(month, year int) (*models.PayrollS
 			COUNT(*) as total_employees,
 			COALESCE(SUM(basic_salary), 0) as total_basic_pay,
 			COALESCE(SUM(allowances), 0) as total_allowances,
+			COALESCE(SUM(overtime_pay), 0) as total_overtime_pay,
 			COALESCE(SUM(deductions), 0) as total_deductions,
 			COALESCE(SUM(tax), 0) as total_tax,
 			COALESCE(SUM(net_salary), 0) as total_net_pay
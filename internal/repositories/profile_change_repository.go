@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"hr-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ProfileChangeRepository struct {
+	db *gorm.DB
+}
+
+func NewProfileChangeRepository(db *gorm.DB) *ProfileChangeRepository {
+	return &ProfileChangeRepository{db: db}
+}
+
+func (r *ProfileChangeRepository) Create(request *models.ProfileChangeRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *ProfileChangeRepository) FindAll(status string, page, limit int) ([]models.ProfileChangeRequest, int64, error) {
+	var requests []models.ProfileChangeRequest
+	var total int64
+
+	query := r.db.Model(&models.ProfileChangeRequest{}).Preload("Employee.Department")
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Offset(offset).Limit(limit).Order("created_at DESC").Find(&requests).Error
+
+	return requests, total, err
+}
+
+func (r *ProfileChangeRepository) FindByEmployee(employeeID uint) ([]models.ProfileChangeRequest, error) {
+	var requests []models.ProfileChangeRequest
+	err := r.db.Where("employee_id = ?", employeeID).Order("created_at DESC").Find(&requests).Error
+	return requests, err
+}
+
+func (r *ProfileChangeRepository) FindPendingByEmployee(employeeID uint) (*models.ProfileChangeRequest, error) {
+	var request models.ProfileChangeRequest
+	err := r.db.Where("employee_id = ? AND status = ?", employeeID, models.ProfileChangePending).First(&request).Error
+	return &request, err
+}
+
+func (r *ProfileChangeRepository) FindByID(id uint) (*models.ProfileChangeRequest, error) {
+	var request models.ProfileChangeRequest
+	err := r.db.Preload("Employee.Department").First(&request, id).Error
+	return &request, err
+}
+
+func (r *ProfileChangeRepository) Update(request *models.ProfileChangeRequest) error {
+	return r.db.Omit("Employee").Save(request).Error
+}
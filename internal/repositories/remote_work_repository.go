@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"hr-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// openRemoteWorkStatuses are the statuses of requests that hold quota
+var openRemoteWorkStatuses = []string{models.RemoteWorkPending, models.RemoteWorkApproved}
+
+type RemoteWorkRepository struct {
+	db *gorm.DB
+}
+
+func NewRemoteWorkRepository(db *gorm.DB) *RemoteWorkRepository {
+	return &RemoteWorkRepository{db: db}
+}
+
+func (r *RemoteWorkRepository) FindPolicies() ([]models.RemoteWorkPolicy, error) {
+	var policies []models.RemoteWorkPolicy
+	err := r.db.Order("name ASC").Find(&policies).Error
+	return policies, err
+}
+
+func (r *RemoteWorkRepository) FindPolicyByID(id uint) (*models.RemoteWorkPolicy, error) {
+	var policy models.RemoteWorkPolicy
+	err := r.db.First(&policy, id).Error
+	return &policy, err
+}
+
+func (r *RemoteWorkRepository) FindPolicyByName(name string) (*models.RemoteWorkPolicy, error) {
+	var policy models.RemoteWorkPolicy
+	err := r.db.Where("name = ?", name).First(&policy).Error
+	return &policy, err
+}
+
+func (r *RemoteWorkRepository) FindDefaultPolicy() (*models.RemoteWorkPolicy, error) {
+	var policy models.RemoteWorkPolicy
+	err := r.db.Where("is_default = ?", true).First(&policy).Error
+	return &policy, err
+}
+
+// SavePolicy stores the policy; making it the default clears the flag on every other policy
+func (r *RemoteWorkRepository) SavePolicy(policy *models.RemoteWorkPolicy) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if policy.IsDefault {
+			if err := tx.Model(&models.RemoteWorkPolicy{}).Where("id <> ? AND is_default = ?", policy.ID, true).
+				Update("is_default", false).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Save(policy).Error
+	})
+}
+
+func (r *RemoteWorkRepository) DeletePolicy(id uint) error {
+	return r.db.Delete(&models.RemoteWorkPolicy{}, id).Error
+}
+
+func (r *RemoteWorkRepository) CountPolicyDepartments(id uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Department{}).Where("remote_work_policy_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+func (r *RemoteWorkRepository) Create(request *models.RemoteWorkRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *RemoteWorkRepository) FindAll(status string, employeeID, departmentID *uint, page, limit int) ([]models.RemoteWorkRequest, int64, error) {
+	var requests []models.RemoteWorkRequest
+	var total int64
+
+	query := r.db.Model(&models.RemoteWorkRequest{}).Preload("Employee", employeeSummary)
+
+	if status != "" {
+		query = query.Where("remote_work_requests.status = ?", status)
+	}
+
+	if employeeID != nil {
+		query = query.Where("remote_work_requests.employee_id = ?", *employeeID)
+	}
+
+	if departmentID != nil {
+		query = query.Joins("JOIN employees ON employees.id = remote_work_requests.employee_id").
+			Where("employees.department_id = ?", *departmentID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("remote_work_requests.start_date DESC, remote_work_requests.id DESC").Offset(offset).Limit(limit).Find(&requests).Error
+	return requests, total, err
+}
+
+func (r *RemoteWorkRepository) FindByEmployee(employeeID uint) ([]models.RemoteWorkRequest, error) {
+	var requests []models.RemoteWorkRequest
+	err := r.db.Where("employee_id = ?", employeeID).Order("start_date DESC, id DESC").Find(&requests).Error
+	return requests, err
+}
+
+func (r *RemoteWorkRepository) FindByID(id uint) (*models.RemoteWorkRequest, error) {
+	var request models.RemoteWorkRequest
+	err := r.db.Preload("Employee", employeeSummary).Preload("Employee.Department").First(&request, id).Error
+	return &request, err
+}
+
+// FindOpenInRange returns the pending and approved requests of the employee overlapping the range
+func (r *RemoteWorkRepository) FindOpenInRange(employeeID uint, startDate, endDate time.Time) ([]models.RemoteWorkRequest, error) {
+	var requests []models.RemoteWorkRequest
+	err := r.db.Where("employee_id = ? AND start_date <= ? AND end_date >= ? AND status IN ?", employeeID, endDate, startDate, openRemoteWorkStatuses).
+		Order("start_date ASC").
+		Find(&requests).Error
+	return requests, err
+}
+
+// FindApprovedOn returns the approved request covering the employee's date
+func (r *RemoteWorkRepository) FindApprovedOn(employeeID uint, date time.Time) (*models.RemoteWorkRequest, error) {
+	var request models.RemoteWorkRequest
+	err := r.db.Where("employee_id = ? AND ? BETWEEN start_date AND end_date AND status = ?", employeeID, date, models.RemoteWorkApproved).
+		First(&request).Error
+	return &request, err
+}
+
+func (r *RemoteWorkRepository) FindApprovedInRange(employeeIDs []uint, startDate, endDate time.Time) ([]models.RemoteWorkRequest, error) {
+	var requests []models.RemoteWorkRequest
+	if len(employeeIDs) == 0 {
+		return requests, nil
+	}
+
+	err := r.db.Where("employee_id IN ? AND start_date <= ? AND end_date >= ? AND status = ?", employeeIDs, endDate, startDate, models.RemoteWorkApproved).
+		Find(&requests).Error
+	return requests, err
+}
+
+// FindCalendar returns the pending and approved requests overlapping the range with their employees
+func (r *RemoteWorkRepository) FindCalendar(startDate, endDate time.Time, departmentID *uint) ([]models.RemoteWorkRequest, error) {
+	var requests []models.RemoteWorkRequest
+
+	query := r.db.Preload("Employee", employeeSummary).
+		Where("remote_work_requests.start_date <= ? AND remote_work_requests.end_date >= ? AND remote_work_requests.status IN ?", endDate, startDate, openRemoteWorkStatuses)
+
+	if departmentID != nil {
+		query = query.Joins("JOIN employees ON employees.id = remote_work_requests.employee_id").
+			Where("employees.department_id = ?", *departmentID)
+	}
+
+	err := query.Order("remote_work_requests.start_date ASC, remote_work_requests.id ASC").Find(&requests).Error
+	return requests, err
+}
+
+func (r *RemoteWorkRepository) Update(request *models.RemoteWorkRequest) error {
+	return r.db.Omit("Employee").Save(request).Error
+}
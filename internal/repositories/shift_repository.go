@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"hr-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ShiftRepository struct {
+	db *gorm.DB
+}
+
+func NewShiftRepository(db *gorm.DB) *ShiftRepository {
+	return &ShiftRepository{db: db}
+}
+
+func (r *ShiftRepository) Create(shift *models.Shift) error {
+	return r.db.Create(shift).Error
+}
+
+func (r *ShiftRepository) FindAll() ([]models.Shift, error) {
+	var shifts []models.Shift
+	err := r.db.Order("start_time ASC, name ASC").Find(&shifts).Error
+	return shifts, err
+}
+
+func (r *ShiftRepository) FindByID(id uint) (*models.Shift, error) {
+	var shift models.Shift
+	err := r.db.First(&shift, id).Error
+	return &shift, err
+}
+
+func (r *ShiftRepository) FindByName(name string) (*models.Shift, error) {
+	var shift models.Shift
+	err := r.db.Where("LOWER(name) = LOWER(?)", name).First(&shift).Error
+	return &shift, err
+}
+
+func (r *ShiftRepository) FindDefault() (*models.Shift, error) {
+	var shift models.Shift
+	err := r.db.Where("is_default = ? AND is_active = ?", true, true).First(&shift).Error
+	return &shift, err
+}
+
+// Save stores the shift; making it the default clears the flag on every other shift
+func (r *ShiftRepository) Save(shift *models.Shift) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if shift.IsDefault {
+			if err := tx.Model(&models.Shift{}).Where("id <> ? AND is_default = ?", shift.ID, true).
+				Update("is_default", false).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Save(shift).Error
+	})
+}
+
+func (r *ShiftRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Shift{}, id).Error
+}
+
+// CountUpcomingUse counts roster entries and pattern slots that still reference the shift
+func (r *ShiftRepository) CountUpcomingUse(shiftID uint, from time.Time) (int64, error) {
+	var entries, slots int64
+	if err := r.db.Model(&models.RosterEntry{}).Where("shift_id = ? AND date >= ?", shiftID, from).Count(&entries).Error; err != nil {
+		return 0, err
+	}
+	if err := r.db.Model(&models.ShiftPatternSlot{}).Where("shift_id = ?", shiftID).Count(&slots).Error; err != nil {
+		return 0, err
+	}
+	return entries + slots, nil
+}
+
+func (r *ShiftRepository) CreatePattern(pattern *models.ShiftPattern) error {
+	return r.db.Create(pattern).Error
+}
+
+func (r *ShiftRepository) FindAllPatterns() ([]models.ShiftPattern, error) {
+	var patterns []models.ShiftPattern
+	err := r.db.Preload("Slots", func(db *gorm.DB) *gorm.DB { return db.Order("day_index ASC") }).
+		Preload("Slots.Shift").
+		Order("name ASC").
+		Find(&patterns).Error
+	return patterns, err
+}
+
+func (r *ShiftRepository) FindPatternByID(id uint) (*models.ShiftPattern, error) {
+	var pattern models.ShiftPattern
+	err := r.db.Preload("Slots", func(db *gorm.DB) *gorm.DB { return db.Order("day_index ASC") }).
+		Preload("Slots.Shift").
+		First(&pattern, id).Error
+	return &pattern, err
+}
+
+// UpdatePattern saves the pattern and replaces its slots
+func (r *ShiftRepository) UpdatePattern(pattern *models.ShiftPattern, slots []models.ShiftPatternSlot) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Slots").Save(pattern).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("pattern_id = ?", pattern.ID).Delete(&models.ShiftPatternSlot{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&slots).Error
+	})
+}
+
+func (r *ShiftRepository) DeletePattern(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("pattern_id = ?", id).Delete(&models.ShiftPatternSlot{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.ShiftPattern{}, id).Error
+	})
+}
+
+// FindRosterEntry returns the roster entry of an employee on a date
+func (r *ShiftRepository) FindRosterEntry(employeeID uint, date time.Time) (*models.RosterEntry, error) {
+	var entry models.RosterEntry
+	err := r.db.Preload("Shift", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("employee_id = ? AND date = ?", employeeID, date).
+		First(&entry).Error
+	return &entry, err
+}
+
+func (r *ShiftRepository) FindRoster(startDate, endDate time.Time, employeeID, departmentID *uint) ([]models.RosterEntry, error) {
+	var entries []models.RosterEntry
+
+	query := r.db.Preload("Employee", employeeSummary).Preload("Shift", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("roster_entries.date BETWEEN ? AND ?", startDate, endDate)
+
+	if employeeID != nil {
+		query = query.Where("roster_entries.employee_id = ?", *employeeID)
+	}
+
+	if departmentID != nil {
+		query = query.Joins("JOIN employees ON employees.id = roster_entries.employee_id").
+			Where("employees.department_id = ?", *departmentID)
+	}
+
+	err := query.Order("roster_entries.date ASC, roster_entries.employee_id ASC").Find(&entries).Error
+	return entries, err
+}
+
+// FindRosterForEmployees returns the entries of several employees, with their shifts, for reports
+func (r *ShiftRepository) FindRosterForEmployees(employeeIDs []uint, startDate, endDate time.Time) ([]models.RosterEntry, error) {
+	var entries []models.RosterEntry
+	if len(employeeIDs) == 0 {
+		return entries, nil
+	}
+
+	err := r.db.Preload("Shift", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("employee_id IN ? AND date BETWEEN ? AND ?", employeeIDs, startDate, endDate).
+		Find(&entries).Error
+	return entries, err
+}
+
+// UpsertRoster creates or replaces the entries for each employee and date
+func (r *ShiftRepository) UpsertRoster(entries []models.RosterEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "employee_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"shift_id", "pattern_id", "updated_at", "deleted_at"}),
+	}).CreateInBatches(&entries, 500).Error
+}
+
+func (r *ShiftRepository) FindRosterEntryByID(id uint) (*models.RosterEntry, error) {
+	var entry models.RosterEntry
+	err := r.db.First(&entry, id).Error
+	return &entry, err
+}
+
+// DeleteRosterEntry removes the entry permanently so the date can be rostered again
+func (r *ShiftRepository) DeleteRosterEntry(id uint) error {
+	return r.db.Unscoped().Delete(&models.RosterEntry{}, id).Error
+}
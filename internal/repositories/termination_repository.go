@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"hr-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TerminationRepository struct {
+	db *gorm.DB
+}
+
+func NewTerminationRepository(db *gorm.DB) *TerminationRepository {
+	return &TerminationRepository{db: db}
+}
+
+func (r *TerminationRepository) Create(termination *models.Termination) error {
+	return r.db.Create(termination).Error
+}
+
+func (r *TerminationRepository) FindAll(status string, page, limit int) ([]models.Termination, int64, error) {
+	var terminations []models.Termination
+	var total int64
+
+	query := r.db.Model(&models.Termination{}).Preload("Employee.Department")
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Offset(offset).Limit(limit).Order("termination_date DESC").Find(&terminations).Error
+
+	return terminations, total, err
+}
+
+func (r *TerminationRepository) FindByID(id uint) (*models.Termination, error) {
+	var termination models.Termination
+	err := r.db.Preload("Employee.Department").
+		Preload("ChecklistItems", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		First(&termination, id).Error
+	return &termination, err
+}
+
+// FindOpenByEmployee returns the scheduled termination of an employee; only
+// scheduled terminations are open
+func (r *TerminationRepository) FindOpenByEmployee(employeeID uint) (*models.Termination, error) {
+	var termination models.Termination
+	err := r.db.Where("employee_id = ? AND status = ?", employeeID, models.TerminationScheduled).
+		First(&termination).Error
+	return &termination, err
+}
+
+// FindDue returns scheduled terminations whose last working day is before the given date
+func (r *TerminationRepository) FindDue(before time.Time) ([]models.Termination, error) {
+	var terminations []models.Termination
+	err := r.db.Where("status = ? AND termination_date < ?", models.TerminationScheduled, before).
+		Find(&terminations).Error
+	return terminations, err
+}
+
+func (r *TerminationRepository) Update(termination *models.Termination) error {
+	return r.db.Omit("Employee", "ChecklistItems").Save(termination).Error
+}
+
+func (r *TerminationRepository) CreateChecklistItems(items []models.ExitChecklistItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return r.db.Create(&items).Error
+}
+
+func (r *TerminationRepository) FindChecklistItem(terminationID, itemID uint) (*models.ExitChecklistItem, error) {
+	var item models.ExitChecklistItem
+	err := r.db.Where("termination_id = ?", terminationID).First(&item, itemID).Error
+	return &item, err
+}
+
+func (r *TerminationRepository) UpdateChecklistItem(item *models.ExitChecklistItem) error {
+	return r.db.Save(item).Error
+}
+
+func (r *TerminationRepository) FindAllRules() ([]models.SeveranceRule, error) {
+	var rules []models.SeveranceRule
+	err := r.db.Order("termination_type ASC").Find(&rules).Error
+	return rules, err
+}
+
+func (r *TerminationRepository) FindRuleByType(terminationType string) (*models.SeveranceRule, error) {
+	var rule models.SeveranceRule
+	err := r.db.Where("termination_type = ?", terminationType).First(&rule).Error
+	return &rule, err
+}
+
+func (r *TerminationRepository) SaveRule(rule *models.SeveranceRule) error {
+	return r.db.Save(rule).Error
+}
@@ -0,0 +1,184 @@
+package repositories
+
+import (
+	"hr-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TimesheetRepository struct {
+	db *gorm.DB
+}
+
+func NewTimesheetRepository(db *gorm.DB) *TimesheetRepository {
+	return &TimesheetRepository{db: db}
+}
+
+func (r *TimesheetRepository) FindProjects(status, search string) ([]models.Project, error) {
+	var projects []models.Project
+	query := r.db.Model(&models.Project{})
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if search != "" {
+		pattern := "%" + search + "%"
+		query = query.Where("code ILIKE ? OR name ILIKE ? OR client_name ILIKE ?", pattern, pattern, pattern)
+	}
+
+	err := query.Order("code ASC").Find(&projects).Error
+	return projects, err
+}
+
+func (r *TimesheetRepository) FindProjectByID(id uint) (*models.Project, error) {
+	var project models.Project
+	err := r.db.Preload("Tasks", func(db *gorm.DB) *gorm.DB { return db.Order("name ASC") }).First(&project, id).Error
+	return &project, err
+}
+
+func (r *TimesheetRepository) FindProjectByCode(code string) (*models.Project, error) {
+	var project models.Project
+	err := r.db.Where("code = ?", code).First(&project).Error
+	return &project, err
+}
+
+func (r *TimesheetRepository) SaveProject(project *models.Project) error {
+	return r.db.Omit("Tasks").Save(project).Error
+}
+
+func (r *TimesheetRepository) DeleteProject(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("project_id = ?", id).Delete(&models.ProjectTask{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Project{}, id).Error
+	})
+}
+
+// CountProjectEntries counts the timesheet entries logged on the project, or on
+// one of its tasks when taskID is set
+func (r *TimesheetRepository) CountProjectEntries(projectID uint, taskID *uint) (int64, error) {
+	var count int64
+	query := r.db.Model(&models.TimesheetEntry{}).Where("project_id = ?", projectID)
+	if taskID != nil {
+		query = query.Where("task_id = ?", *taskID)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+func (r *TimesheetRepository) FindTask(projectID, taskID uint) (*models.ProjectTask, error) {
+	var task models.ProjectTask
+	err := r.db.Where("project_id = ?", projectID).First(&task, taskID).Error
+	return &task, err
+}
+
+func (r *TimesheetRepository) SaveTask(task *models.ProjectTask) error {
+	return r.db.Save(task).Error
+}
+
+func (r *TimesheetRepository) DeleteTask(id uint) error {
+	return r.db.Delete(&models.ProjectTask{}, id).Error
+}
+
+func (r *TimesheetRepository) Create(timesheet *models.Timesheet) error {
+	return r.db.Create(timesheet).Error
+}
+
+func (r *TimesheetRepository) FindAll(status string, employeeID, departmentID *uint, page, limit int) ([]models.Timesheet, int64, error) {
+	var timesheets []models.Timesheet
+	var total int64
+
+	query := r.db.Model(&models.Timesheet{}).Preload("Employee", employeeSummary)
+
+	if status != "" {
+		query = query.Where("timesheets.status = ?", status)
+	}
+
+	if employeeID != nil {
+		query = query.Where("timesheets.employee_id = ?", *employeeID)
+	}
+
+	if departmentID != nil {
+		query = query.Joins("JOIN employees ON employees.id = timesheets.employee_id").
+			Where("employees.department_id = ?", *departmentID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("timesheets.week_start DESC, timesheets.id DESC").Offset(offset).Limit(limit).Find(&timesheets).Error
+	return timesheets, total, err
+}
+
+func (r *TimesheetRepository) FindByEmployee(employeeID uint) ([]models.Timesheet, error) {
+	var timesheets []models.Timesheet
+	err := r.db.Where("employee_id = ?", employeeID).Order("week_start DESC").Find(&timesheets).Error
+	return timesheets, err
+}
+
+func (r *TimesheetRepository) FindByEmployeeAndWeek(employeeID uint, weekStart time.Time) (*models.Timesheet, error) {
+	var timesheet models.Timesheet
+	err := r.db.Where("employee_id = ? AND week_start = ?", employeeID, weekStart).First(&timesheet).Error
+	return &timesheet, err
+}
+
+func (r *TimesheetRepository) FindByID(id uint) (*models.Timesheet, error) {
+	var timesheet models.Timesheet
+	err := r.db.Preload("Employee", employeeSummary).Preload("Employee.Department").
+		Preload("Entries", func(db *gorm.DB) *gorm.DB { return db.Order("date ASC, id ASC") }).
+		Preload("Entries.Project").
+		Preload("Entries.Task").
+		First(&timesheet, id).Error
+	return &timesheet, err
+}
+
+func (r *TimesheetRepository) Update(timesheet *models.Timesheet) error {
+	return r.db.Omit("Employee", "Entries").Save(timesheet).Error
+}
+
+func (r *TimesheetRepository) FindEntryByID(id uint) (*models.TimesheetEntry, error) {
+	var entry models.TimesheetEntry
+	err := r.db.First(&entry, id).Error
+	return &entry, err
+}
+
+func (r *TimesheetRepository) FindEntries(timesheetID uint) ([]models.TimesheetEntry, error) {
+	var entries []models.TimesheetEntry
+	err := r.db.Where("timesheet_id = ?", timesheetID).Order("date ASC, id ASC").Find(&entries).Error
+	return entries, err
+}
+
+func (r *TimesheetRepository) SaveEntry(entry *models.TimesheetEntry) error {
+	return r.db.Omit("Project", "Task").Save(entry).Error
+}
+
+func (r *TimesheetRepository) DeleteEntry(id uint) error {
+	return r.db.Delete(&models.TimesheetEntry{}, id).Error
+}
+
+// FindApprovedEntries returns the entries of approved timesheets within the range
+// with their projects
+func (r *TimesheetRepository) FindApprovedEntries(filter models.TimesheetReportFilter) ([]models.TimesheetEntry, error) {
+	var entries []models.TimesheetEntry
+
+	query := r.db.Preload("Project").
+		Joins("JOIN timesheets ON timesheets.id = timesheet_entries.timesheet_id AND timesheets.deleted_at IS NULL").
+		Where("timesheets.status = ? AND timesheet_entries.date BETWEEN ? AND ?", models.TimesheetApproved, filter.StartDate, filter.EndDate)
+
+	if filter.ProjectID != nil {
+		query = query.Where("timesheet_entries.project_id = ?", *filter.ProjectID)
+	}
+
+	if filter.DepartmentID != nil {
+		query = query.Joins("JOIN employees ON employees.id = timesheet_entries.employee_id").
+			Where("employees.department_id = ?", *filter.DepartmentID)
+	}
+
+	err := query.Order("timesheet_entries.date ASC, timesheet_entries.id ASC").Find(&entries).Error
+	return entries, err
+}
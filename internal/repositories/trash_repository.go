@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"hr-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TrashRepository reads and manages soft-deleted records
+type TrashRepository struct {
+	db *gorm.DB
+}
+
+func NewTrashRepository(db *gorm.DB) *TrashRepository {
+	return &TrashRepository{db: db}
+}
+
+func (r *TrashRepository) FindEmployees(page, limit int) ([]models.Employee, int64, error) {
+	var employees []models.Employee
+	query := r.db.Unscoped().Preload("User", unscopedPreload).Preload("Department", unscopedPreload)
+	total, err := findTrashed(query, &models.Employee{}, &employees, page, limit)
+	return employees, total, err
+}
+
+func (r *TrashRepository) FindDepartments(page, limit int) ([]models.Department, int64, error) {
+	var departments []models.Department
+	query := r.db.Unscoped().Preload("Manager", func(db *gorm.DB) *gorm.DB { return employeeSummary(db.Unscoped()) })
+	total, err := findTrashed(query, &models.Department{}, &departments, page, limit)
+	return departments, total, err
+}
+
+func (r *TrashRepository) FindLeaves(page, limit int) ([]models.Leave, int64, error) {
+	var leaves []models.Leave
+	query := r.db.Unscoped().Preload("Employee", func(db *gorm.DB) *gorm.DB { return employeeSummary(db.Unscoped()) })
+	total, err := findTrashed(query, &models.Leave{}, &leaves, page, limit)
+	return leaves, total, err
+}
+
+func (r *TrashRepository) FindTrashedEmployee(id uint) (*models.Employee, error) {
+	var employee models.Employee
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&employee, id).Error
+	return &employee, err
+}
+
+func (r *TrashRepository) FindTrashedDepartment(id uint) (*models.Department, error) {
+	var department models.Department
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&department, id).Error
+	return &department, err
+}
+
+func (r *TrashRepository) FindTrashedLeave(id uint) (*models.Leave, error) {
+	var leave models.Leave
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&leave, id).Error
+	return &leave, err
+}
+
+// FindExpiredIDs returns the IDs of records of the model deleted before the cutoff
+func (r *TrashRepository) FindExpiredIDs(model interface{}, before time.Time) ([]uint, error) {
+	var ids []uint
+	err := r.db.Unscoped().Model(model).Where("deleted_at < ?", before).Order("id ASC").Pluck("id", &ids).Error
+	return ids, err
+}
+
+// RestoreEmployee brings back the employee and the user account deleted with it
+func (r *TrashRepository) RestoreEmployee(employee *models.Employee) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := restore(tx, &models.Employee{}, employee.ID); err != nil {
+			return err
+		}
+		if employee.UserID != nil {
+			return restore(tx, &models.User{}, *employee.UserID)
+		}
+		return nil
+	})
+}
+
+func (r *TrashRepository) RestoreDepartment(id uint) error {
+	return restore(r.db, &models.Department{}, id)
+}
+
+func (r *TrashRepository) RestoreLeave(id uint) error {
+	return restore(r.db, &models.Leave{}, id)
+}
+
+// PurgeEmployee permanently removes an employee, their user account and every
+// record that only exists for them. There are no foreign keys to cascade, so every
+// table keyed by employee_id is listed here. Payroll and attendance are not touched
+// because DeleteEmployee refuses employees with that history; punches and
+// corrections are removed in case they never produced an attendance record. The
+// attendance anomaly report is computed on request and stores nothing.
+func (r *TrashRepository) PurgeEmployee(employee *models.Employee) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		tx = tx.Unscoped().Session(&gorm.Session{})
+
+		terminations := tx.Model(&models.Termination{}).Select("id").Where("employee_id = ?", employee.ID)
+		if err := tx.Where("termination_id IN (?)", terminations).Delete(&models.ExitChecklistItem{}).Error; err != nil {
+			return err
+		}
+
+		certifications := tx.Model(&models.Certification{}).Select("id").Where("employee_id = ?", employee.ID)
+		if err := tx.Where("certification_id IN (?)", certifications).Delete(&models.CertificationFile{}).Error; err != nil {
+			return err
+		}
+
+		owned := []interface{}{
+			&models.Termination{},
+			&models.Leave{},
+			&models.LeaveBalance{},
+			&models.OnboardingTask{},
+			&models.EmploymentStatusHistory{},
+			&models.ContractRenewal{},
+			&models.EmployeeDependent{},
+			&models.ProfileChangeRequest{},
+			&models.Certification{},
+			&models.EmployeeSkill{},
+			&models.AssetAssignment{},
+			&models.RosterEntry{},
+			&models.DeviceUser{},
+			&models.OvertimeRequest{},
+			&models.RemoteWorkRequest{},
+			&models.AttendanceCorrection{},
+			&models.PunchEvent{},
+			&models.TimesheetEntry{},
+			&models.Timesheet{},
+			&models.KioskPin{},
+		}
+		for _, model := range owned {
+			if err := tx.Where("employee_id = ?", employee.ID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.Department{}).Where("manager_id = ?", employee.ID).Update("manager_id", nil).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&models.Employee{}, employee.ID).Error; err != nil {
+			return err
+		}
+
+		if employee.UserID == nil {
+			return nil
+		}
+		if err := tx.Where("user_id = ?", *employee.UserID).Delete(&models.Notification{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.OnboardingTask{}).Where("assignee_user_id = ?", *employee.UserID).Update("assignee_user_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.User{}, *employee.UserID).Error
+	})
+}
+
+// PurgeDepartment permanently removes a department, unassigning its employees and onboarding templates
+func (r *TrashRepository) PurgeDepartment(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		tx = tx.Unscoped().Session(&gorm.Session{})
+
+		for _, model := range []interface{}{&models.Employee{}, &models.OnboardingTemplate{}} {
+			if err := tx.Model(model).Where("department_id = ?", id).Update("department_id", nil).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Delete(&models.Department{}, id).Error
+	})
+}
+
+func (r *TrashRepository) PurgeLeave(id uint) error {
+	return r.db.Unscoped().Delete(&models.Leave{}, id).Error
+}
+
+// unscopedPreload lets preloads include records that were deleted along with the parent
+func unscopedPreload(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+func findTrashed(query *gorm.DB, model, dest interface{}, page, limit int) (int64, error) {
+	var total int64
+	query = query.Model(model).Where("deleted_at IS NOT NULL")
+
+	if err := query.Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("deleted_at DESC").Offset(offset).Limit(limit).Find(dest).Error
+	return total, err
+}
+
+func restore(db *gorm.DB, model interface{}, id uint) error {
+	return db.Unscoped().Model(model).Where("id = ?", id).Update("deleted_at", nil).Error
+}
@@ -37,3 +37,9 @@ func (r *UserRepository) Update(user *models.User) error {
 func (r *UserRepository) Delete(id uint) error {
 	return r.db.Delete(&models.User{}, id).Error
 }
+
+func (r *UserRepository) FindActiveByRoles(roles ...string) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Where("role IN ? AND is_active = ?", roles, true).Find(&users).Error
+	return users, err
+}
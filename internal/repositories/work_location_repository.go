@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"hr-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type WorkLocationRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkLocationRepository(db *gorm.DB) *WorkLocationRepository {
+	return &WorkLocationRepository{db: db}
+}
+
+func (r *WorkLocationRepository) FindAll() ([]models.WorkLocation, error) {
+	var locations []models.WorkLocation
+	err := r.db.Preload("HolidayCalendar").Order("name ASC").Find(&locations).Error
+	return locations, err
+}
+
+func (r *WorkLocationRepository) FindByID(id uint) (*models.WorkLocation, error) {
+	var location models.WorkLocation
+	err := r.db.Preload("HolidayCalendar").Preload("Geofences").First(&location, id).Error
+	return &location, err
+}
+
+func (r *WorkLocationRepository) FindByName(name string) (*models.WorkLocation, error) {
+	var location models.WorkLocation
+	err := r.db.Where("LOWER(name) = LOWER(?)", name).First(&location).Error
+	return &location, err
+}
+
+func (r *WorkLocationRepository) Save(location *models.WorkLocation) error {
+	return r.db.Omit("HolidayCalendar", "Geofences").Save(location).Error
+}
+
+func (r *WorkLocationRepository) Delete(id uint) error {
+	return r.db.Delete(&models.WorkLocation{}, id).Error
+}
+
+func (r *WorkLocationRepository) CountEmployees(id uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Employee{}).Where("work_location_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+func (r *WorkLocationRepository) FindGeofences(locationID uint) ([]models.Geofence, error) {
+	var geofences []models.Geofence
+	err := r.db.Where("work_location_id = ?", locationID).Order("name ASC").Find(&geofences).Error
+	return geofences, err
+}
+
+func (r *WorkLocationRepository) FindActiveGeofences(locationID uint) ([]models.Geofence, error) {
+	var geofences []models.Geofence
+	err := r.db.Where("work_location_id = ? AND is_active = ?", locationID, true).Find(&geofences).Error
+	return geofences, err
+}
+
+func (r *WorkLocationRepository) FindGeofenceByID(id uint) (*models.Geofence, error) {
+	var geofence models.Geofence
+	err := r.db.First(&geofence, id).Error
+	return &geofence, err
+}
+
+func (r *WorkLocationRepository) SaveGeofence(geofence *models.Geofence) error {
+	return r.db.Save(geofence).Error
+}
+
+func (r *WorkLocationRepository) DeleteGeofence(id uint) error {
+	return r.db.Delete(&models.Geofence{}, id).Error
+}
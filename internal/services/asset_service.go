@@ -0,0 +1,284 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AssetService struct {
+	assetRepo       *repositories.AssetRepository
+	employeeRepo    *repositories.EmployeeRepository
+	terminationRepo *repositories.TerminationRepository
+	db              *gorm.DB
+}
+
+func NewAssetService(assetRepo *repositories.AssetRepository, employeeRepo *repositories.EmployeeRepository, terminationRepo *repositories.TerminationRepository, db *gorm.DB) *AssetService {
+	return &AssetService{
+		assetRepo:       assetRepo,
+		employeeRepo:    employeeRepo,
+		terminationRepo: terminationRepo,
+		db:              db,
+	}
+}
+
+func (s *AssetService) CreateAsset(req *models.CreateAssetRequest) (*models.Asset, error) {
+	tag := strings.TrimSpace(req.AssetTag)
+	if _, err := s.assetRepo.FindByTag(tag); err == nil {
+		return nil, errors.New("asset tag already exists")
+	}
+
+	asset := &models.Asset{
+		AssetTag:     tag,
+		Type:         req.Type,
+		Name:         strings.TrimSpace(req.Name),
+		SerialNumber: strings.TrimSpace(req.SerialNumber),
+		PurchaseCost: req.PurchaseCost,
+		Condition:    req.Condition,
+		Status:       models.AssetAvailable,
+		Notes:        req.Notes,
+	}
+	if req.PurchaseDate != nil {
+		purchaseDate := dateOnly(req.PurchaseDate.Time)
+		asset.PurchaseDate = &purchaseDate
+	}
+
+	if err := s.assetRepo.Create(asset); err != nil {
+		return nil, err
+	}
+
+	return s.assetRepo.FindByID(asset.ID)
+}
+
+func (s *AssetService) GetAssets(page, limit int, assetType, status, search string) ([]models.Asset, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	return s.assetRepo.FindAll(page, limit, assetType, status, strings.TrimSpace(search))
+}
+
+func (s *AssetService) GetAssetByID(id uint) (*models.Asset, error) {
+	asset, err := s.assetRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("asset not found")
+	}
+	return asset, nil
+}
+
+func (s *AssetService) UpdateAsset(id uint, req *models.UpdateAssetRequest) (*models.Asset, error) {
+	asset, err := s.assetRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("asset not found")
+	}
+
+	if req.Status != "" && req.Status != asset.Status {
+		if asset.Status == models.AssetAssigned {
+			return nil, errors.New("return the asset before changing its status")
+		}
+		asset.Status = req.Status
+	}
+
+	asset.Type = req.Type
+	asset.Name = strings.TrimSpace(req.Name)
+	asset.SerialNumber = strings.TrimSpace(req.SerialNumber)
+	asset.PurchaseCost = req.PurchaseCost
+	asset.Condition = req.Condition
+	asset.Notes = req.Notes
+	asset.PurchaseDate = nil
+	if req.PurchaseDate != nil {
+		purchaseDate := dateOnly(req.PurchaseDate.Time)
+		asset.PurchaseDate = &purchaseDate
+	}
+
+	if err := s.assetRepo.Update(asset); err != nil {
+		return nil, err
+	}
+
+	return s.assetRepo.FindByID(id)
+}
+
+// AssignAsset issues an available asset to a current employee. If the employee is
+// already leaving, the asset is added to their exit checklist.
+func (s *AssetService) AssignAsset(id, assignedBy uint, req *models.AssignAssetRequest) (*models.Asset, error) {
+	asset, err := s.assetRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("asset not found")
+	}
+
+	if asset.Status != models.AssetAvailable {
+		return nil, fmt.Errorf("asset is %s and cannot be assigned", asset.Status)
+	}
+
+	employee, err := s.employeeRepo.FindByID(req.EmployeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+
+	if !isWorkingStatus(employee.EmploymentStatus) {
+		return nil, errors.New("assets can only be assigned to current employees")
+	}
+
+	assignedAt := dateOnly(time.Now())
+	if req.AssignedAt != nil {
+		assignedAt = dateOnly(req.AssignedAt.Time)
+	}
+	if assignedAt.After(dateOnly(time.Now())) {
+		return nil, errors.New("assignment date cannot be in the future")
+	}
+
+	assignment := &models.AssetAssignment{
+		AssetID:      asset.ID,
+		EmployeeID:   employee.ID,
+		AssignedAt:   assignedAt,
+		AssignedBy:   assignedBy,
+		ConditionOut: asset.Condition,
+		Notes:        req.Notes,
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(assignment).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Asset{}).Where("id = ?", asset.ID).Updates(map[string]interface{}{
+			"status":               models.AssetAssigned,
+			"assigned_employee_id": employee.ID,
+			"assigned_at":          assignedAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		termination, err := s.terminationRepo.FindOpenByEmployee(employee.ID)
+		if err != nil {
+			return nil
+		}
+		return tx.Create(&models.ExitChecklistItem{
+			TerminationID: termination.ID,
+			Title:         assetReturnTitle(asset),
+			Category:      "assets",
+			AssetID:       &asset.ID,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.assetRepo.FindByID(id)
+}
+
+// ReturnAsset closes the open assignment and ticks off the matching exit checklist item
+func (s *AssetService) ReturnAsset(id, receivedBy uint, req *models.ReturnAssetRequest) (*models.Asset, error) {
+	asset, err := s.assetRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("asset not found")
+	}
+
+	if asset.Status != models.AssetAssigned {
+		return nil, errors.New("asset is not assigned")
+	}
+
+	assignment, err := s.assetRepo.FindOpenAssignment(asset.ID)
+	if err != nil {
+		return nil, errors.New("open assignment not found")
+	}
+
+	returnedAt := dateOnly(time.Now())
+	if req.ReturnedAt != nil {
+		returnedAt = dateOnly(req.ReturnedAt.Time)
+	}
+	if returnedAt.Before(dateOnly(assignment.AssignedAt)) {
+		return nil, errors.New("return date cannot be before the assignment date")
+	}
+
+	status := models.AssetAvailable
+	condition := req.Condition
+	checklistNote := fmt.Sprintf("Returned on %s in %s condition", returnedAt.Format("2006-01-02"), condition)
+	if req.Lost {
+		status = models.AssetLost
+		condition = asset.Condition
+		checklistNote = fmt.Sprintf("Reported lost on %s", returnedAt.Format("2006-01-02"))
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.AssetAssignment{}).Where("id = ?", assignment.ID).Updates(map[string]interface{}{
+			"returned_at":  returnedAt,
+			"received_by":  receivedBy,
+			"condition_in": req.Condition,
+			"lost":         req.Lost,
+			"return_notes": req.Notes,
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Asset{}).Where("id = ?", asset.ID).Updates(map[string]interface{}{
+			"status":               status,
+			"condition":            condition,
+			"assigned_employee_id": nil,
+			"assigned_at":          nil,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.ExitChecklistItem{}).
+			Where("asset_id = ? AND completed = ?", asset.ID, false).
+			Where("termination_id IN (?)", tx.Model(&models.Termination{}).Select("id").Where("employee_id = ?", assignment.EmployeeID)).
+			Updates(map[string]interface{}{
+				"completed":    true,
+				"completed_at": time.Now(),
+				"completed_by": receivedBy,
+				"notes":        checklistNote,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.assetRepo.FindByID(id)
+}
+
+func (s *AssetService) GetAssetHistory(id uint) ([]models.AssetAssignment, error) {
+	if _, err := s.assetRepo.FindByID(id); err != nil {
+		return nil, errors.New("asset not found")
+	}
+	return s.assetRepo.FindHistory(id)
+}
+
+// GetEmployeeAssets returns the assets the employee holds and their assignment history
+func (s *AssetService) GetEmployeeAssets(employeeID uint) (map[string]interface{}, error) {
+	if _, err := s.employeeRepo.FindByID(employeeID); err != nil {
+		return nil, errors.New("employee not found")
+	}
+
+	outstanding, err := s.assetRepo.FindByEmployee(employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.assetRepo.FindHistoryByEmployee(employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"outstanding": outstanding,
+		"history":     history,
+	}, nil
+}
+
+// GetAssetsHeldByInactive reports assets still assigned to people who have left or were deleted
+func (s *AssetService) GetAssetsHeldByInactive() ([]models.Asset, error) {
+	return s.assetRepo.FindHeldByInactive()
+}
+
+func assetReturnTitle(asset *models.Asset) string {
+	return fmt.Sprintf("Return %s %s (%s)", strings.ReplaceAll(asset.Type, "_", " "), asset.Name, asset.AssetTag)
+}
@@ -0,0 +1,303 @@
+package services
+
+import (
+	"fmt"
+	"hr-backend/internal/models"
+	"sort"
+	"time"
+)
+
+// Thresholds and scores of the attendance anomaly checks
+const (
+	// Punches this far apart count as travel; closer ones are GPS noise
+	anomalyMinTravelMeters = 1000
+	// Faster than this between two punches is not plausible over land
+	anomalyMaxTravelKmh = 200
+	// An employee needs this many round-time manual entries in the range to be flagged
+	anomalyRoundEntryRecurrence = 3
+	anomalyMaxWorkingHours      = 16
+
+	anomalySharedPunchScore      = 40
+	anomalyImpossibleTravelScore = 50
+	anomalyRoundEntryScore       = 20
+	anomalyLongWorkdayScore      = 30
+	anomalyMaxScore              = 100
+)
+
+// GetAttendanceAnomalies looks for signs of buddy punching and abuse in the
+// attendance of the range: punches at the same second as other employees,
+// punches too far from the previous one to have been reached in time, recurring
+// manual entries with round times and days with more than 16 working hours.
+// Records are returned most suspicious first.
+func (s *AttendanceService) GetAttendanceAnomalies(filter models.AttendanceAnomalyFilter) ([]models.AttendanceAnomaly, int64, error) {
+	filter.StartDate, filter.EndDate = dateOnly(filter.StartDate), dateOnly(filter.EndDate)
+	if err := validateDateRange(filter.StartDate, filter.EndDate); err != nil {
+		return nil, 0, err
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit < 1 || filter.Limit > 100 {
+		filter.Limit = 10
+	}
+
+	attendances, err := s.attendanceRepo.FindForAnomalyCheck(filter.StartDate, filter.EndDate, filter.DepartmentID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Punches are matched across every department, only the findings are filtered
+	events, err := s.attendanceRepo.FindPunchEventsInRange(filter.StartDate, filter.EndDate)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	employeeIDs := []uint{}
+	seen := map[uint]bool{}
+	for _, attendance := range attendances {
+		if !seen[attendance.EmployeeID] {
+			seen[attendance.EmployeeID] = true
+			employeeIDs = append(employeeIDs, attendance.EmployeeID)
+		}
+	}
+	employees, err := s.employeeRepo.FindByIDs(employeeIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	zones, err := s.timezoneService.ForEmployees(employees)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	check := &anomalyCheck{
+		records: map[string]*models.AttendanceAnomaly{},
+		zones:   zones,
+	}
+	for i := range attendances {
+		check.add(&attendances[i])
+	}
+
+	events = activePunches(events)
+	check.sharedPunchTimes(events)
+	check.impossibleTravel(events)
+	check.roundManualEntries(attendances)
+	check.longWorkdays(attendances)
+
+	anomalies := []models.AttendanceAnomaly{}
+	for _, anomaly := range check.records {
+		if len(anomaly.Reasons) == 0 || anomaly.Score < filter.MinScore {
+			continue
+		}
+		anomalies = append(anomalies, *anomaly)
+	}
+	sort.Slice(anomalies, func(i, j int) bool {
+		if anomalies[i].Score != anomalies[j].Score {
+			return anomalies[i].Score > anomalies[j].Score
+		}
+		if !anomalies[i].Date.Equal(anomalies[j].Date) {
+			return anomalies[i].Date.After(anomalies[j].Date)
+		}
+		return anomalies[i].AttendanceID < anomalies[j].AttendanceID
+	})
+
+	total := int64(len(anomalies))
+	start := (filter.Page - 1) * filter.Limit
+	if start > len(anomalies) {
+		start = len(anomalies)
+	}
+	end := start + filter.Limit
+	if end > len(anomalies) {
+		end = len(anomalies)
+	}
+	return anomalies[start:end], total, nil
+}
+
+// anomalyCheck collects the findings per attendance record, keyed by employee and date
+type anomalyCheck struct {
+	records map[string]*models.AttendanceAnomaly
+	zones   map[uint]*time.Location
+}
+
+func anomalyKey(employeeID uint, date time.Time) string {
+	return fmt.Sprintf("%d:%s", employeeID, date.Format("2006-01-02"))
+}
+
+func (c *anomalyCheck) add(attendance *models.Attendance) {
+	anomaly := &models.AttendanceAnomaly{
+		AttendanceID: attendance.ID,
+		EmployeeID:   attendance.EmployeeID,
+		Date:         attendance.Date,
+		Source:       attendance.Source,
+		ClockIn:      attendance.ClockIn,
+		ClockOut:     attendance.ClockOut,
+		WorkingHours: attendance.WorkingHours,
+		Reasons:      []models.AttendanceAnomalyReason{},
+	}
+	if attendance.Employee != nil {
+		anomaly.EmployeeName = attendance.Employee.FirstName + " " + attendance.Employee.LastName
+		anomaly.DepartmentID = attendance.Employee.DepartmentID
+	}
+	c.records[anomalyKey(attendance.EmployeeID, attendance.Date)] = anomaly
+}
+
+// flag adds a reason to the record of the employee's date, when that record is being checked
+func (c *anomalyCheck) flag(employeeID uint, date time.Time, reason models.AttendanceAnomalyReason) {
+	anomaly, ok := c.records[anomalyKey(employeeID, date)]
+	if !ok {
+		return
+	}
+	anomaly.Reasons = append(anomaly.Reasons, reason)
+	anomaly.Score += reason.Score
+	if anomaly.Score > anomalyMaxScore {
+		anomaly.Score = anomalyMaxScore
+	}
+}
+
+func (c *anomalyCheck) clock(employeeID uint, t time.Time) string {
+	zone, ok := c.zones[employeeID]
+	if !ok {
+		return t.Format("15:04:05")
+	}
+	return t.In(zone).Format("15:04:05")
+}
+
+// sharedPunchTimes flags punches made at the same second as another employee's.
+// Times typed in by people are skipped, they are round by nature. So are device
+// punches on a full minute: some terminals log minutes only, and every punch of
+// such a log would otherwise share its time with colleagues punching that minute.
+func (c *anomalyCheck) sharedPunchTimes(events []models.PunchEvent) {
+	bySecond := map[int64][]models.PunchEvent{}
+	for _, event := range events {
+		switch event.Source {
+		case models.AttendanceSourceDevice:
+			if event.PunchedAt.Second() == 0 {
+				continue
+			}
+			bySecond[event.PunchedAt.Unix()] = append(bySecond[event.PunchedAt.Unix()], event)
+		case models.AttendanceSourceApp, models.AttendanceSourceKiosk:
+			bySecond[event.PunchedAt.Unix()] = append(bySecond[event.PunchedAt.Unix()], event)
+		}
+	}
+
+	for _, group := range bySecond {
+		employees := map[uint]bool{}
+		for _, event := range group {
+			employees[event.EmployeeID] = true
+		}
+		if len(employees) < 2 {
+			continue
+		}
+
+		for _, event := range group {
+			related := []uint{}
+			for employeeID := range employees {
+				if employeeID != event.EmployeeID {
+					related = append(related, employeeID)
+				}
+			}
+			sort.Slice(related, func(i, j int) bool { return related[i] < related[j] })
+
+			c.flag(event.EmployeeID, event.WorkDate, models.AttendanceAnomalyReason{
+				Code: models.AnomalySharedPunchTime,
+				Description: fmt.Sprintf("%s punch at %s was made at the same second as %d other employees",
+					event.Type, c.clock(event.EmployeeID, event.PunchedAt), len(related)),
+				Score:              anomalySharedPunchScore,
+				RelatedEmployeeIDs: related,
+			})
+		}
+	}
+}
+
+// impossibleTravel flags punches too far from the employee's previous punch with
+// a position to have been reached in the time between them
+func (c *anomalyCheck) impossibleTravel(events []models.PunchEvent) {
+	var previous *models.PunchEvent
+	for i := range events {
+		event := &events[i]
+		if event.Latitude == nil || event.Longitude == nil {
+			continue
+		}
+		if previous == nil || previous.EmployeeID != event.EmployeeID {
+			previous = event
+			continue
+		}
+
+		meters := models.DistanceMeters(*previous.Latitude, *previous.Longitude, *event.Latitude, *event.Longitude)
+		hours := event.PunchedAt.Sub(previous.PunchedAt).Hours()
+		if meters >= anomalyMinTravelMeters && (hours <= 0 || meters/1000/hours > anomalyMaxTravelKmh) {
+			description := fmt.Sprintf("%s punch at %s was %.1f km from the previous punch, %s earlier",
+				event.Type, c.clock(event.EmployeeID, event.PunchedAt), meters/1000, event.PunchedAt.Sub(previous.PunchedAt).Round(time.Minute))
+			c.flag(event.EmployeeID, event.WorkDate, models.AttendanceAnomalyReason{
+				Code:        models.AnomalyImpossibleTravel,
+				Description: description,
+				Score:       anomalyImpossibleTravelScore,
+			})
+		}
+		previous = event
+	}
+}
+
+// roundManualEntries flags manual entries whose times fall on the hour or half
+// hour, for employees who have several of them in the range
+func (c *anomalyCheck) roundManualEntries(attendances []models.Attendance) {
+	round := map[uint][]models.Attendance{}
+	for _, attendance := range attendances {
+		if attendance.Source != models.AttendanceSourceManual || attendance.ClockIn == nil {
+			continue
+		}
+		if c.isRoundTime(attendance.EmployeeID, *attendance.ClockIn) &&
+			(attendance.ClockOut == nil || c.isRoundTime(attendance.EmployeeID, *attendance.ClockOut)) {
+			round[attendance.EmployeeID] = append(round[attendance.EmployeeID], attendance)
+		}
+	}
+
+	for employeeID, entries := range round {
+		if len(entries) < anomalyRoundEntryRecurrence {
+			continue
+		}
+		for _, attendance := range entries {
+			c.flag(employeeID, attendance.Date, models.AttendanceAnomalyReason{
+				Code:        models.AnomalyRoundManualEntry,
+				Description: fmt.Sprintf("manual entry with round times, one of %d in the range", len(entries)),
+				Score:       anomalyRoundEntryScore,
+			})
+		}
+	}
+}
+
+func (c *anomalyCheck) isRoundTime(employeeID uint, t time.Time) bool {
+	if zone, ok := c.zones[employeeID]; ok {
+		t = t.In(zone)
+	}
+	return t.Second() == 0 && t.Minute()%30 == 0
+}
+
+func (c *anomalyCheck) longWorkdays(attendances []models.Attendance) {
+	for _, attendance := range attendances {
+		if attendance.WorkingHours > anomalyMaxWorkingHours {
+			c.flag(attendance.EmployeeID, attendance.Date, models.AttendanceAnomalyReason{
+				Code:        models.AnomalyLongWorkday,
+				Description: fmt.Sprintf("%g working hours recorded, more than %d", attendance.WorkingHours, anomalyMaxWorkingHours),
+				Score:       anomalyLongWorkdayScore,
+			})
+		}
+	}
+}
+
+// activePunches drops void events and the events they cancel
+func activePunches(events []models.PunchEvent) []models.PunchEvent {
+	voided := map[uint]bool{}
+	for _, event := range events {
+		if event.Type == models.PunchTypeVoid && event.VoidsID != nil {
+			voided[*event.VoidsID] = true
+		}
+	}
+
+	active := make([]models.PunchEvent, 0, len(events))
+	for _, event := range events {
+		if event.Type != models.PunchTypeVoid && !voided[event.ID] {
+			active = append(active, event)
+		}
+	}
+	return active
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"hr-backend/internal/models"
+	"testing"
+	"time"
+)
+
+var anomalyDay = time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+func anomalyTime(day time.Time, clock string) time.Time {
+	parsed, err := time.Parse("15:04:05", clock)
+	if err != nil {
+		panic(err)
+	}
+	return day.Add(time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute +
+		time.Duration(parsed.Second())*time.Second)
+}
+
+func anomalyPunch(id, employeeID uint, source, clock string) models.PunchEvent {
+	return models.PunchEvent{
+		ID:         id,
+		EmployeeID: employeeID,
+		WorkDate:   anomalyDay,
+		Type:       models.PunchTypeIn,
+		Source:     source,
+		PunchedAt:  anomalyTime(anomalyDay, clock),
+	}
+}
+
+func positioned(event models.PunchEvent, lat, lng float64) models.PunchEvent {
+	event.Latitude, event.Longitude = &lat, &lng
+	return event
+}
+
+func anomalyAttendance(id, employeeID uint, day time.Time, source string, hours float64) models.Attendance {
+	attendance := models.Attendance{EmployeeID: employeeID, Date: day, Source: source, WorkingHours: hours}
+	attendance.ID = id
+	return attendance
+}
+
+func manualEntry(id, employeeID uint, day time.Time, in, out string) models.Attendance {
+	attendance := anomalyAttendance(id, employeeID, day, models.AttendanceSourceManual, 8)
+	clockIn, clockOut := anomalyTime(day, in), anomalyTime(day, out)
+	attendance.ClockIn, attendance.ClockOut = &clockIn, &clockOut
+	return attendance
+}
+
+func newAnomalyCheck(attendances []models.Attendance) *anomalyCheck {
+	check := &anomalyCheck{records: map[string]*models.AttendanceAnomaly{}, zones: map[uint]*time.Location{}}
+	for i := range attendances {
+		check.add(&attendances[i])
+	}
+	return check
+}
+
+func TestAnomalyChecks(t *testing.T) {
+	app, device, kiosk, manual := models.AttendanceSourceApp, models.AttendanceSourceDevice,
+		models.AttendanceSourceKiosk, models.AttendanceSourceManual
+
+	// Employees 1 to 3 have a record on anomalyDay
+	dayRecords := []models.Attendance{
+		anomalyAttendance(1, 1, anomalyDay, app, 8),
+		anomalyAttendance(2, 2, anomalyDay, app, 8),
+		anomalyAttendance(3, 3, anomalyDay, app, 8),
+	}
+
+	tests := []struct {
+		name        string
+		attendances []models.Attendance
+		events      []models.PunchEvent
+		// want maps attendance IDs to their expected reason codes; others have none
+		want map[uint][]string
+	}{
+		{
+			name:        "punches at the same second",
+			attendances: dayRecords,
+			events: []models.PunchEvent{
+				anomalyPunch(1, 1, app, "07:59:41"),
+				anomalyPunch(2, 2, kiosk, "07:59:41"),
+				anomalyPunch(3, 3, app, "07:59:42"),
+			},
+			want: map[uint][]string{1: {models.AnomalySharedPunchTime}, 2: {models.AnomalySharedPunchTime}},
+		},
+		{
+			name:        "one employee punching twice in a second is not shared",
+			attendances: dayRecords,
+			events: []models.PunchEvent{
+				anomalyPunch(1, 1, app, "07:59:41"),
+				anomalyPunch(2, 1, kiosk, "07:59:41"),
+			},
+		},
+		{
+			name:        "minute-resolution device punches are not compared",
+			attendances: dayRecords,
+			events: []models.PunchEvent{
+				anomalyPunch(1, 1, device, "08:00:00"),
+				anomalyPunch(2, 2, device, "08:00:00"),
+				anomalyPunch(3, 3, device, "08:00:00"),
+			},
+		},
+		{
+			name:        "device punches with seconds are compared",
+			attendances: dayRecords,
+			events: []models.PunchEvent{
+				anomalyPunch(1, 1, device, "08:00:17"),
+				anomalyPunch(2, 2, device, "08:00:17"),
+			},
+			want: map[uint][]string{1: {models.AnomalySharedPunchTime}, 2: {models.AnomalySharedPunchTime}},
+		},
+		{
+			name:        "manual punches are not compared",
+			attendances: dayRecords,
+			events: []models.PunchEvent{
+				anomalyPunch(1, 1, manual, "08:00:17"),
+				anomalyPunch(2, 2, manual, "08:00:17"),
+			},
+		},
+		{
+			name:        "Jakarta to Surabaya within an hour",
+			attendances: dayRecords,
+			events: []models.PunchEvent{
+				positioned(anomalyPunch(1, 1, app, "08:00:05"), -6.2088, 106.8456),
+				positioned(anomalyPunch(2, 1, app, "09:00:05"), -7.2575, 112.7521),
+			},
+			want: map[uint][]string{1: {models.AnomalyImpossibleTravel}},
+		},
+		{
+			name:        "across town in an hour is plausible",
+			attendances: dayRecords,
+			events: []models.PunchEvent{
+				positioned(anomalyPunch(1, 1, app, "08:00:05"), -6.2088, 106.8456),
+				positioned(anomalyPunch(2, 1, app, "09:00:05"), -6.1754, 106.8272),
+			},
+		},
+		{
+			name:        "GPS drift at the same moment is ignored",
+			attendances: dayRecords,
+			events: []models.PunchEvent{
+				positioned(anomalyPunch(1, 1, app, "08:00:05"), -6.2088, 106.8456),
+				positioned(anomalyPunch(2, 1, app, "08:00:05"), -6.2089, 106.8457),
+			},
+		},
+		{
+			name:        "distance is only measured between punches of one employee",
+			attendances: dayRecords,
+			events: []models.PunchEvent{
+				positioned(anomalyPunch(1, 1, app, "08:00:05"), -6.2088, 106.8456),
+				positioned(anomalyPunch(2, 2, app, "08:10:05"), -7.2575, 112.7521),
+			},
+		},
+		{
+			name: "recurring round manual entries",
+			attendances: []models.Attendance{
+				manualEntry(1, 1, anomalyDay, "08:00:00", "17:00:00"),
+				manualEntry(2, 1, anomalyDay.AddDate(0, 0, 1), "08:30:00", "17:30:00"),
+				manualEntry(3, 1, anomalyDay.AddDate(0, 0, 2), "08:00:00", "16:30:00"),
+				manualEntry(4, 2, anomalyDay, "08:00:00", "17:00:00"),
+				manualEntry(5, 2, anomalyDay.AddDate(0, 0, 1), "08:00:00", "17:00:00"),
+				manualEntry(6, 2, anomalyDay.AddDate(0, 0, 2), "08:07:00", "17:00:00"),
+			},
+			want: map[uint][]string{
+				1: {models.AnomalyRoundManualEntry},
+				2: {models.AnomalyRoundManualEntry},
+				3: {models.AnomalyRoundManualEntry},
+			},
+		},
+		{
+			name: "working more than 16 hours",
+			attendances: []models.Attendance{
+				anomalyAttendance(1, 1, anomalyDay, app, 16),
+				anomalyAttendance(2, 2, anomalyDay, app, 16.5),
+			},
+			want: map[uint][]string{2: {models.AnomalyLongWorkday}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := newAnomalyCheck(tt.attendances)
+			events := activePunches(tt.events)
+			check.sharedPunchTimes(events)
+			check.impossibleTravel(events)
+			check.roundManualEntries(tt.attendances)
+			check.longWorkdays(tt.attendances)
+
+			for _, anomaly := range check.records {
+				want := tt.want[anomaly.AttendanceID]
+				if len(anomaly.Reasons) != len(want) {
+					t.Errorf("attendance %d has reasons %+v, want %v", anomaly.AttendanceID, anomaly.Reasons, want)
+					continue
+				}
+				for i, reason := range anomaly.Reasons {
+					if reason.Code != want[i] {
+						t.Errorf("attendance %d reason %d = %s, want %s", anomaly.AttendanceID, i, reason.Code, want[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestAnomalyScore(t *testing.T) {
+	tests := []struct {
+		name    string
+		reasons []int
+		want    int
+	}{
+		{name: "no reasons", want: 0},
+		{name: "single reason", reasons: []int{anomalyImpossibleTravelScore}, want: anomalyImpossibleTravelScore},
+		{name: "reasons add up", reasons: []int{anomalySharedPunchScore, anomalyLongWorkdayScore}, want: anomalySharedPunchScore + anomalyLongWorkdayScore},
+		{name: "capped at the maximum", reasons: []int{anomalyImpossibleTravelScore, anomalySharedPunchScore, anomalyLongWorkdayScore}, want: anomalyMaxScore},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := newAnomalyCheck([]models.Attendance{anomalyAttendance(1, 1, anomalyDay, models.AttendanceSourceApp, 8)})
+			for _, score := range tt.reasons {
+				check.flag(1, anomalyDay, models.AttendanceAnomalyReason{Score: score})
+			}
+			if got := check.records[anomalyKey(1, anomalyDay)].Score; got != tt.want {
+				t.Errorf("score = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	// Findings for days outside the checked records are dropped
+	check := newAnomalyCheck(nil)
+	check.flag(1, anomalyDay, models.AttendanceAnomalyReason{Score: anomalyLongWorkdayScore})
+	if len(check.records) != 0 {
+		t.Errorf("flag() created records %+v for an unchecked day", check.records)
+	}
+}
+
+func TestActivePunches(t *testing.T) {
+	voided := uint(2)
+	events := []models.PunchEvent{
+		anomalyPunch(1, 1, models.AttendanceSourceApp, "08:00:05"),
+		anomalyPunch(2, 1, models.AttendanceSourceApp, "08:00:09"),
+		{ID: 3, EmployeeID: 1, Type: models.PunchTypeVoid, VoidsID: &voided},
+	}
+
+	got := activePunches(events)
+	if len(got) != 1 || got[0].ID != 1 {
+		t.Errorf("activePunches() = %+v, want only event 1", got)
+	}
+}
@@ -0,0 +1,379 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hr-backend/internal/config"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"hr-backend/internal/storage"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// correctionTimeLayouts are the accepted forms of a requested punch time, read in the employee's time zone
+var correctionTimeLayouts = []string{"2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02T15:04:05"}
+
+type AttendanceCorrectionService struct {
+	correctionRepo      *repositories.AttendanceCorrectionRepository
+	attendanceRepo      *repositories.AttendanceRepository
+	employeeRepo        *repositories.EmployeeRepository
+	attendanceService   *AttendanceService
+	notificationService *NotificationService
+	timezoneService     *TimezoneService
+	storage             *storage.LocalStorage
+	cfg                 *config.Config
+}
+
+func NewAttendanceCorrectionService(correctionRepo *repositories.AttendanceCorrectionRepository, attendanceRepo *repositories.AttendanceRepository, employeeRepo *repositories.EmployeeRepository, attendanceService *AttendanceService, notificationService *NotificationService, timezoneService *TimezoneService, storage *storage.LocalStorage, cfg *config.Config) *AttendanceCorrectionService {
+	return &AttendanceCorrectionService{
+		correctionRepo:      correctionRepo,
+		attendanceRepo:      attendanceRepo,
+		employeeRepo:        employeeRepo,
+		attendanceService:   attendanceService,
+		notificationService: notificationService,
+		timezoneService:     timezoneService,
+		storage:             storage,
+		cfg:                 cfg,
+	}
+}
+
+// SubmitCorrection files a correction for the employee linked to the account.
+// A wrong-time correction names the punch event it replaces. The requested time
+// must fall on the work date, or on the next morning for night shifts.
+func (s *AttendanceCorrectionService) SubmitCorrection(userID uint, req *models.SubmitAttendanceCorrectionRequest, evidence *multipart.FileHeader) (*models.AttendanceCorrection, error) {
+	employee, err := s.employeeRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("no employee profile is linked to this account")
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, errors.New("invalid date format")
+	}
+
+	zone := s.timezoneService.For(employee)
+	requestedTime, err := parseCorrectionTime(req.RequestedTime, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if date.After(localDate(now, zone)) || requestedTime.After(now) {
+		return nil, errors.New("corrections cannot be made for the future")
+	}
+	if punchDay := localDate(requestedTime, zone); punchDay.Before(date) || punchDay.After(date.AddDate(0, 0, 1)) {
+		return nil, errors.New("the requested time must be on the attendance date or the morning after")
+	}
+
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, errors.New("a reason is required")
+	}
+
+	correction := &models.AttendanceCorrection{
+		EmployeeID:    employee.ID,
+		Date:          date,
+		Type:          req.Type,
+		PunchType:     req.PunchType,
+		RequestedTime: requestedTime,
+		Reason:        strings.TrimSpace(req.Reason),
+		Status:        models.AttendanceCorrectionPending,
+	}
+
+	switch req.Type {
+	case models.AttendanceCorrectionWrongTime:
+		if req.PunchEventID == nil {
+			return nil, errors.New("the punch to correct is required")
+		}
+		event, err := s.attendanceRepo.FindPunchEventByID(*req.PunchEventID)
+		if err != nil || event.EmployeeID != employee.ID || !dateOnly(event.WorkDate).Equal(date) {
+			return nil, errors.New("punch not found on this date")
+		}
+		if event.Type == models.PunchTypeVoid {
+			return nil, errors.New("this punch cannot be corrected")
+		}
+		if voided, err := s.attendanceRepo.IsPunchEventVoided(event.ID); err != nil {
+			return nil, err
+		} else if voided {
+			return nil, errors.New("this punch has already been corrected")
+		}
+		correction.PunchEventID = &event.ID
+	case models.AttendanceCorrectionMissingPunch:
+		if req.PunchEventID != nil {
+			return nil, errors.New("a missing punch correction cannot refer to an existing punch")
+		}
+	}
+
+	pending, err := s.correctionRepo.CountPending(employee.ID, date, req.PunchType, correction.PunchEventID)
+	if err != nil {
+		return nil, err
+	}
+	if pending > 0 {
+		return nil, errors.New("a correction for this punch is already awaiting approval")
+	}
+
+	if evidence != nil {
+		if err := s.saveEvidence(correction, evidence); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.correctionRepo.Create(correction); err != nil {
+		if correction.EvidencePath != "" {
+			s.storage.Delete(correction.EvidencePath)
+		}
+		return nil, err
+	}
+
+	s.notifyApprovers(employee, correction)
+
+	correction.HasEvidence = correction.EvidencePath != ""
+	return correction, nil
+}
+
+func (s *AttendanceCorrectionService) GetMyCorrections(userID uint) ([]models.AttendanceCorrection, error) {
+	employee, err := s.employeeRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("no employee profile is linked to this account")
+	}
+	return s.correctionRepo.FindByEmployee(employee.ID)
+}
+
+func (s *AttendanceCorrectionService) CancelCorrection(id, userID uint) error {
+	correction, err := s.correctionRepo.FindByID(id)
+	if err != nil {
+		return errors.New("attendance correction not found")
+	}
+
+	if correction.Employee == nil || correction.Employee.UserID == nil || *correction.Employee.UserID != userID {
+		return errors.New("attendance correction not found")
+	}
+
+	if correction.Status != models.AttendanceCorrectionPending {
+		return errors.New("only pending corrections can be cancelled")
+	}
+
+	correction.Status = models.AttendanceCorrectionCancelled
+	return s.correctionRepo.Update(correction)
+}
+
+func (s *AttendanceCorrectionService) GetCorrections(status string, departmentID *uint, page, limit int) ([]models.AttendanceCorrection, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	return s.correctionRepo.FindAll(status, departmentID, page, limit)
+}
+
+func (s *AttendanceCorrectionService) GetCorrectionByID(id uint) (*models.AttendanceCorrection, error) {
+	correction, err := s.correctionRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("attendance correction not found")
+	}
+	return correction, nil
+}
+
+// GetEvidence returns the evidence file of a correction with its content type
+func (s *AttendanceCorrectionService) GetEvidence(id uint) ([]byte, string, string, error) {
+	correction, err := s.GetCorrectionByID(id)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if correction.EvidencePath == "" {
+		return nil, "", "", errors.New("no evidence was attached")
+	}
+
+	content, err := s.storage.ReadFile(correction.EvidencePath)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return content, correction.EvidenceType, correction.EvidenceName, nil
+}
+
+// ApproveCorrection applies the correction to the punch log: the replaced punch is
+// voided and the requested punch added, after which the day's clock times, working
+// hours and overtime are derived again. The day as it stood before is kept on the
+// correction.
+func (s *AttendanceCorrectionService) ApproveCorrection(id, reviewerID uint, req *models.ReviewAttendanceCorrectionRequest) (*models.AttendanceCorrection, error) {
+	correction, err := s.reviewableCorrection(id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if correction.PunchEventID != nil {
+		voided, err := s.attendanceRepo.IsPunchEventVoided(*correction.PunchEventID)
+		if err != nil {
+			return nil, err
+		}
+		if voided {
+			return nil, errors.New("the punch was corrected in the meantime; ask the employee to resubmit")
+		}
+	}
+
+	if original, err := s.attendanceRepo.FindByEmployeeAndDate(correction.EmployeeID, correction.Date); err == nil {
+		correction.OriginalClockIn = original.ClockIn
+		correction.OriginalClockOut = original.ClockOut
+		correction.OriginalWorkingHours = original.WorkingHours
+		correction.OriginalOvertimeHours = original.OvertimeHours
+		correction.OriginalStatus = original.Status
+	}
+
+	now := time.Now()
+	notes := fmt.Sprintf("Attendance correction #%d", correction.ID)
+	var events []models.PunchEvent
+	if correction.PunchEventID != nil {
+		events = append(events, models.PunchEvent{
+			EmployeeID: correction.EmployeeID,
+			WorkDate:   correction.Date,
+			Type:       models.PunchTypeVoid,
+			PunchedAt:  now,
+			Source:     models.AttendanceSourceCorrection,
+			VoidsID:    correction.PunchEventID,
+			RecordedBy: &reviewerID,
+			Notes:      notes,
+		})
+	}
+	events = append(events, models.PunchEvent{
+		EmployeeID: correction.EmployeeID,
+		WorkDate:   correction.Date,
+		Type:       correction.PunchType,
+		PunchedAt:  correction.RequestedTime,
+		Source:     models.AttendanceSourceCorrection,
+		RecordedBy: &reviewerID,
+		Notes:      notes,
+	})
+
+	attendance, err := s.attendanceService.recordPunchEvents(correction.EmployeeID, correction.Date, events)
+	if err != nil {
+		return nil, err
+	}
+	correction.AttendanceID = &attendance.ID
+
+	return s.completeReview(correction, models.AttendanceCorrectionApproved, reviewerID, req.Notes)
+}
+
+func (s *AttendanceCorrectionService) RejectCorrection(id, reviewerID uint, req *models.ReviewAttendanceCorrectionRequest) (*models.AttendanceCorrection, error) {
+	if strings.TrimSpace(req.Notes) == "" {
+		return nil, errors.New("a reason is required when rejecting a correction")
+	}
+
+	correction, err := s.reviewableCorrection(id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.completeReview(correction, models.AttendanceCorrectionRejected, reviewerID, req.Notes)
+}
+
+func (s *AttendanceCorrectionService) reviewableCorrection(id, reviewerID uint) (*models.AttendanceCorrection, error) {
+	correction, err := s.correctionRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("attendance correction not found")
+	}
+
+	if correction.Status != models.AttendanceCorrectionPending {
+		return nil, errors.New("attendance correction has already been reviewed")
+	}
+
+	if correction.Employee != nil && correction.Employee.UserID != nil && *correction.Employee.UserID == reviewerID {
+		return nil, errors.New("you cannot review your own attendance correction")
+	}
+
+	return correction, nil
+}
+
+func (s *AttendanceCorrectionService) completeReview(correction *models.AttendanceCorrection, status string, reviewerID uint, notes string) (*models.AttendanceCorrection, error) {
+	now := time.Now()
+	correction.Status = status
+	correction.ReviewedBy = &reviewerID
+	correction.ReviewedAt = &now
+	correction.ReviewNotes = notes
+
+	if err := s.correctionRepo.Update(correction); err != nil {
+		return nil, err
+	}
+
+	if correction.Employee != nil && correction.Employee.UserID != nil {
+		message := fmt.Sprintf("Your attendance correction for %s was %s", correction.Date.Format("2006-01-02"), status)
+		if notes != "" {
+			message += ": " + notes
+		}
+		s.notificationService.Notify(*correction.Employee.UserID, "attendance_correction", "Attendance correction "+status, message, "/koreksi-kehadiran/saya")
+	}
+
+	return correction, nil
+}
+
+// notifyApprovers tells the department manager, or HR when there is none, about a new correction
+func (s *AttendanceCorrectionService) notifyApprovers(employee *models.Employee, correction *models.AttendanceCorrection) {
+	title := "Attendance correction submitted"
+	message := fmt.Sprintf("%s %s requested an attendance correction for %s", employee.FirstName, employee.LastName, correction.Date.Format("2006-01-02"))
+	link := fmt.Sprintf("/koreksi-kehadiran/%d", correction.ID)
+
+	if employee.Department != nil && employee.Department.ManagerID != nil && *employee.Department.ManagerID != employee.ID {
+		manager, err := s.employeeRepo.FindByID(*employee.Department.ManagerID)
+		if err == nil && manager.UserID != nil {
+			s.notificationService.Notify(*manager.UserID, "attendance_correction", title, message, link)
+			return
+		}
+	}
+	s.notificationService.NotifyRoles([]string{"hr_manager"}, "attendance_correction", title, message, link)
+}
+
+// saveEvidence stores a PDF, JPEG or PNG supporting the correction, detected from its content
+func (s *AttendanceCorrectionService) saveEvidence(correction *models.AttendanceCorrection, header *multipart.FileHeader) error {
+	maxSize := int64(s.cfg.Storage.MaxUploadSizeMB) << 20
+	if header.Size > maxSize {
+		return fmt.Errorf("file is larger than %d MB", s.cfg.Storage.MaxUploadSizeMB)
+	}
+
+	src, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(src, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return errors.New("uploaded file is empty")
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	ext, ok := allowedScanTypes[contentType]
+	if !ok {
+		return errors.New("only PDF, JPEG and PNG files are allowed")
+	}
+
+	dir := fmt.Sprintf("employees/%d/attendance-corrections", correction.EmployeeID)
+	path, err := s.storage.Save(dir, ext, io.MultiReader(bytes.NewReader(head), src))
+	if err != nil {
+		return err
+	}
+
+	correction.EvidencePath = path
+	correction.EvidenceName = filepath.Base(header.Filename)
+	correction.EvidenceType = contentType
+	return nil
+}
+
+func parseCorrectionTime(value string, zone *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range correctionTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, zone); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("invalid requested time format")
+}
@@ -1,82 +1,452 @@
 package services
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"hr-backend/internal/config"
 	"hr-backend/internal/models"
 	"hr-backend/internal/repositories"
+	"hr-backend/internal/storage"
+	"io"
+	"math"
+	"mime/multipart"
+	"net/http"
 	"time"
 )
 
+// standardWorkingHours applies to days without a shift
+const standardWorkingHours = 8
+
+// autoClockOutGrace is how long after the end of a working day the end-of-day job
+// waits before marking absences and closing sessions left open
+const autoClockOutGrace = 4 * time.Hour
+
+// kioskRepeatWindow is how long after a punch another kiosk punch is refused
+const kioskRepeatWindow = time.Minute
+
+// allowedPhotoTypes maps the accepted clock-in selfie types to their file extension
+var allowedPhotoTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
 type AttendanceService struct {
-	attendanceRepo *repositories.AttendanceRepository
-	employeeRepo   *repositories.EmployeeRepository
+	attendanceRepo  *repositories.AttendanceRepository
+	employeeRepo    *repositories.EmployeeRepository
+	leaveRepo       *repositories.LeaveRepository
+	locationRepo    *repositories.WorkLocationRepository
+	overtimeRepo    *repositories.OvertimeRepository
+	remoteWorkRepo  *repositories.RemoteWorkRepository
+	shiftService    *ShiftService
+	timezoneService *TimezoneService
+	storage         *storage.LocalStorage
+	cfg             *config.Config
 }
 
-func NewAttendanceService(attendanceRepo *repositories.AttendanceRepository, employeeRepo *repositories.EmployeeRepository) *AttendanceService {
+func NewAttendanceService(attendanceRepo *repositories.AttendanceRepository, employeeRepo *repositories.EmployeeRepository, leaveRepo *repositories.LeaveRepository, locationRepo *repositories.WorkLocationRepository, overtimeRepo *repositories.OvertimeRepository, remoteWorkRepo *repositories.RemoteWorkRepository, shiftService *ShiftService, timezoneService *TimezoneService, storage *storage.LocalStorage, cfg *config.Config) *AttendanceService {
 	return &AttendanceService{
-		attendanceRepo: attendanceRepo,
-		employeeRepo:   employeeRepo,
+		attendanceRepo:  attendanceRepo,
+		employeeRepo:    employeeRepo,
+		leaveRepo:       leaveRepo,
+		locationRepo:    locationRepo,
+		overtimeRepo:    overtimeRepo,
+		remoteWorkRepo:  remoteWorkRepo,
+		shiftService:    shiftService,
+		timezoneService: timezoneService,
+		storage:         storage,
+		cfg:             cfg,
 	}
 }
 
-func (s *AttendanceService) ClockIn(req *models.ClockInRequest) (*models.Attendance, error) {
-	// Check if employee exists
-	_, err := s.employeeRepo.FindByID(req.EmployeeID)
+// ClockIn starts a working session for the employee linked to the account. The
+// server clock is authoritative. The reported position is checked against the
+// geofences of the employee's work location; punches failing the check are kept
+// but flagged for review. Several sessions a day are allowed, one at a time. The
+// work date is the date where the employee works.
+func (s *AttendanceService) ClockIn(userID uint, req *models.ClockInRequest, photo *multipart.FileHeader) (*models.Attendance, error) {
+	employee, err := s.employeeRepo.FindByUserID(userID)
 	if err != nil {
-		return nil, errors.New("employee not found")
+		return nil, errors.New("no employee profile is linked to this account")
+	}
+
+	event := &models.PunchEvent{
+		Source:         models.AttendanceSourceApp,
+		WorkLocationID: employee.WorkLocationID,
+		Latitude:       req.Latitude,
+		Longitude:      req.Longitude,
+		Accuracy:       req.Accuracy,
+	}
+	return s.clockIn(employee, event, photo)
+}
+
+// ClockOut ends the open working session, with the same position and photo
+// handling as ClockIn
+func (s *AttendanceService) ClockOut(userID uint, req *models.ClockOutRequest, photo *multipart.FileHeader) (*models.Attendance, error) {
+	employee, err := s.employeeRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("no employee profile is linked to this account")
+	}
+
+	event := &models.PunchEvent{
+		Source:         models.AttendanceSourceApp,
+		WorkLocationID: employee.WorkLocationID,
+		Latitude:       req.Latitude,
+		Longitude:      req.Longitude,
+		Accuracy:       req.Accuracy,
 	}
+	return s.clockOut(employee, event, photo)
+}
+
+// KioskPunch clocks the employee in at a kiosk, or out when a session is open.
+// The kiosk vouches for the position, so its punches are not checked against
+// geofences. A second punch within kioskRepeatWindow is refused, so a code
+// scanned twice does not end the session it just started.
+func (s *AttendanceService) KioskPunch(employee *models.Employee, kiosk *models.Kiosk) (*models.Attendance, string, error) {
+	now := time.Now()
+	zone := s.timezoneService.For(employee)
 
-	// Check if already clocked in today
-	existing, err := s.attendanceRepo.FindByEmployeeAndDate(req.EmployeeID, req.Date.Time)
-	if err == nil && existing.ClockIn != nil {
-		return nil, errors.New("already clocked in today")
+	events, err := s.attendanceRepo.FindPunchEvents(employee.ID, localDate(now, zone))
+	if err != nil {
+		return nil, "", err
+	}
+	if last := summarizePunches(events).last; !last.IsZero() && now.Sub(last) < kioskRepeatWindow {
+		return nil, "", errors.New("you punched a moment ago")
+	}
+
+	event := &models.PunchEvent{
+		Source:         models.AttendanceSourceKiosk,
+		KioskID:        &kiosk.ID,
+		WorkLocationID: kiosk.WorkLocationID,
+	}
+	if event.WorkLocationID == nil {
+		event.WorkLocationID = employee.WorkLocationID
 	}
 
-	attendance := &models.Attendance{
-		EmployeeID: req.EmployeeID,
-		Date:       req.Date.Time,
-		ClockIn:    &req.ClockIn,
-		Status:     "present",
+	if _, _, err := s.openWorkday(employee.ID, now, zone); err == nil {
+		attendance, err := s.clockOut(employee, event, nil)
+		return attendance, models.PunchTypeOut, err
 	}
+	attendance, err := s.clockIn(employee, event, nil)
+	return attendance, models.PunchTypeIn, err
+}
+
+// clockIn starts a session with the event, stamping it with the current time and
+// the work date where the employee works
+func (s *AttendanceService) clockIn(employee *models.Employee, event *models.PunchEvent, photo *multipart.FileHeader) (*models.Attendance, error) {
+	now := time.Now()
+	date := localDate(now, s.timezoneService.For(employee))
 
-	if err := s.attendanceRepo.Create(attendance); err != nil {
+	events, err := s.attendanceRepo.FindPunchEvents(employee.ID, date)
+	if err != nil {
 		return nil, err
 	}
+	if summarizePunches(events).state != punchStateOff {
+		return nil, errors.New("already clocked in")
+	}
 
-	return attendance, nil
+	event.EmployeeID = employee.ID
+	event.WorkDate = date
+	event.Type = models.PunchTypeIn
+	event.PunchedAt = now
+	return s.recordAppPunch(employee, event, photo)
 }
 
-func (s *AttendanceService) ClockOut(req *models.ClockOutRequest) (*models.Attendance, error) {
-	attendance, err := s.attendanceRepo.FindByEmployeeAndDate(req.EmployeeID, req.Date.Time)
+// clockOut ends the open session with the event
+func (s *AttendanceService) clockOut(employee *models.Employee, event *models.PunchEvent, photo *multipart.FileHeader) (*models.Attendance, error) {
+	now := time.Now()
+	date, _, err := s.openWorkday(employee.ID, now, s.timezoneService.For(employee))
 	if err != nil {
-		return nil, errors.New("no clock-in record found for today")
+		return nil, err
 	}
 
-	if attendance.ClockOut != nil {
-		return nil, errors.New("already clocked out")
+	event.EmployeeID = employee.ID
+	event.WorkDate = date
+	event.Type = models.PunchTypeOut
+	event.PunchedAt = now
+	return s.recordAppPunch(employee, event, photo)
+}
+
+// StartBreak pauses the open working session; break time is not working time
+func (s *AttendanceService) StartBreak(userID uint) (*models.Attendance, error) {
+	return s.recordBreak(userID, models.PunchTypeBreakStart)
+}
+
+func (s *AttendanceService) EndBreak(userID uint) (*models.Attendance, error) {
+	return s.recordBreak(userID, models.PunchTypeBreakEnd)
+}
+
+func (s *AttendanceService) recordBreak(userID uint, punchType string) (*models.Attendance, error) {
+	employee, err := s.employeeRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("no employee profile is linked to this account")
 	}
 
-	attendance.ClockOut = &req.ClockOut
+	now := time.Now()
+	date, state, err := s.openWorkday(employee.ID, now, s.timezoneService.For(employee))
+	if err != nil {
+		return nil, err
+	}
+	if punchType == models.PunchTypeBreakStart && state != punchStateWorking {
+		return nil, errors.New("already on a break")
+	}
+	if punchType == models.PunchTypeBreakEnd && state != punchStateOnBreak {
+		return nil, errors.New("no break in progress")
+	}
 
-	// Calculate working hours
-	if attendance.ClockIn != nil {
-		duration := req.ClockOut.Sub(*attendance.ClockIn)
-		hours := duration.Hours()
-		attendance.WorkingHours = hours
+	event := &models.PunchEvent{
+		EmployeeID:     employee.ID,
+		WorkDate:       date,
+		Type:           punchType,
+		PunchedAt:      now,
+		Source:         models.AttendanceSourceApp,
+		WorkLocationID: employee.WorkLocationID,
+	}
+	if err := s.attendanceRepo.CreatePunchEvent(event); err != nil {
+		return nil, err
+	}
 
-		// Calculate overtime (assuming 8 hours is standard)
-		if hours > 8 {
-			attendance.OvertimeHours = hours - 8
+	attendance, exists, err := s.deriveAttendance(employee.ID, date)
+	if err != nil {
+		return nil, err
+	}
+	return attendance, s.saveAttendance(attendance, exists)
+}
+
+// openWorkday returns the work date of the employee's open session: today's, or
+// that of a night shift started the day before
+func (s *AttendanceService) openWorkday(employeeID uint, now time.Time, zone *time.Location) (time.Time, string, error) {
+	date := localDate(now, zone)
+
+	events, err := s.attendanceRepo.FindPunchEvents(employeeID, date)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	today := summarizePunches(events)
+	if today.state != punchStateOff {
+		return date, today.state, s.ensureAfter(today, now)
+	}
+
+	// A night shift is clocked out on the day after it started
+	if previous, err := s.findOpenNightShift(employeeID, date); err == nil {
+		events, err := s.attendanceRepo.FindPunchEvents(employeeID, previous.Date)
+		if err != nil {
+			return time.Time{}, "", err
+		}
+		summary := summarizePunches(events)
+		if summary.state != punchStateOff {
+			return dateOnly(previous.Date), summary.state, s.ensureAfter(summary, now)
 		}
 	}
 
-	if err := s.attendanceRepo.Update(attendance); err != nil {
+	if today.clockIn != nil {
+		return time.Time{}, "", errors.New("already clocked out")
+	}
+	return time.Time{}, "", errors.New("no clock-in record found for today")
+}
+
+func (s *AttendanceService) ensureAfter(summary punchSummary, now time.Time) error {
+	if !now.After(summary.last) {
+		return errors.New("clock-out must be after clock-in")
+	}
+	return nil
+}
+
+// recordAppPunch checks the position, stores the selfie and the event, and derives the day.
+// Positions are not checked for kiosk punches or on approved remote work days.
+func (s *AttendanceService) recordAppPunch(employee *models.Employee, event *models.PunchEvent, photo *multipart.FileHeader) (*models.Attendance, error) {
+	var err error
+	if event.KioskID != nil {
+		event.Geofence = models.GeofenceKiosk
+	} else if s.isRemoteDay(employee.ID, event.WorkDate) {
+		event.Geofence = models.GeofenceRemote
+	} else if event.Geofence, err = s.checkGeofence(employee, event.Latitude, event.Longitude, event.Accuracy); err != nil {
+		return nil, err
+	}
+
+	if photo != nil {
+		if event.Photo, err = s.savePhoto(employee.ID, photo); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.attendanceRepo.CreatePunchEvent(event); err != nil {
+		s.deletePhoto(event.Photo)
+		return nil, err
+	}
+
+	attendance, exists, err := s.deriveAttendance(employee.ID, event.WorkDate)
+	if err != nil {
+		return nil, err
+	}
+	flagForReview(attendance, event.Geofence)
+
+	if err := s.saveAttendance(attendance, exists); err != nil {
+		return nil, err
+	}
+	return attendance, nil
+}
+
+// deriveAttendance recomputes the attendance of a work date from its punch events.
+// Status, notes and review fields of an existing record are kept.
+func (s *AttendanceService) deriveAttendance(employeeID uint, date time.Time) (*models.Attendance, bool, error) {
+	date = dateOnly(date)
+
+	events, err := s.attendanceRepo.FindPunchEvents(employeeID, date)
+	if err != nil {
+		return nil, false, err
+	}
+
+	attendance, err := s.attendanceRepo.FindByEmployeeAndDate(employeeID, date)
+	exists := err == nil && attendance.ID > 0
+	if !exists {
+		attendance = &models.Attendance{EmployeeID: employeeID, Date: date, Status: "present"}
+	}
+
+	applyPunchSummary(attendance, summarizePunches(events))
+
+	if attendance.ClockIn == nil {
+		// Every punch of the day was voided
+		attendance.WorkingHours = 0
+		attendance.OvertimeHours = 0
+		attendance.LateMinutes = 0
+		attendance.EarlyLeaveMinutes = 0
+		attendance.Status = "absent"
+		return attendance, exists, nil
+	}
+
+	// Lateness and remote work are decided again from the derived clock-in
+	if attendance.Status == "late" || attendance.Status == models.AttendanceDayRemote ||
+		(attendance.Status == "absent" && attendance.ReviewStatus != models.AttendanceReviewRejected) {
+		attendance.Status = "present"
+	}
+	if attendance.Status == "present" && s.isRemoteDay(employeeID, date) {
+		attendance.Status = models.AttendanceDayRemote
+	}
+
+	if err := s.evaluateAttendance(attendance); err != nil {
+		return nil, false, err
+	}
+	return attendance, exists, nil
+}
+
+// recordPunchEvents appends the events to the punch log and derives the day again
+func (s *AttendanceService) recordPunchEvents(employeeID uint, date time.Time, events []models.PunchEvent) (*models.Attendance, error) {
+	if _, err := s.attendanceRepo.CreatePunchEvents(events); err != nil {
+		return nil, err
+	}
+
+	attendance, exists, err := s.deriveAttendance(employeeID, date)
+	if err != nil {
 		return nil, err
 	}
+	if err := s.saveAttendance(attendance, exists); err != nil {
+		return nil, err
+	}
+	return attendance, nil
+}
+
+// isRemoteDay reports whether the employee has approved remote work on the date
+func (s *AttendanceService) isRemoteDay(employeeID uint, date time.Time) bool {
+	_, err := s.remoteWorkRepo.FindApprovedOn(employeeID, dateOnly(date))
+	return err == nil
+}
+
+// refreshAttendance evaluates a recorded day again, for instance after its overtime
+// or remote work was approved. Days without a clock-in have nothing to evaluate.
+func (s *AttendanceService) refreshAttendance(employeeID uint, date time.Time) error {
+	existing, err := s.attendanceRepo.FindByEmployeeAndDate(employeeID, dateOnly(date))
+	if err != nil || existing.ClockIn == nil {
+		return nil
+	}
+
+	attendance, exists, err := s.deriveAttendance(employeeID, date)
+	if err != nil {
+		return err
+	}
+	return s.saveAttendance(attendance, exists)
+}
+
+func (s *AttendanceService) saveAttendance(attendance *models.Attendance, exists bool) error {
+	if exists {
+		return s.attendanceRepo.Update(attendance)
+	}
+	return s.attendanceRepo.Create(attendance)
+}
+
+// GetPunchEvents returns the punch log behind an attendance record, voided events included
+func (s *AttendanceService) GetPunchEvents(id uint) ([]models.PunchEvent, error) {
+	attendance, err := s.attendanceRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("attendance not found")
+	}
+	return s.attendanceRepo.FindPunchEvents(attendance.EmployeeID, dateOnly(attendance.Date))
+}
+
+func (s *AttendanceService) GetPendingReviews(departmentID *uint, page, limit int) ([]models.Attendance, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	return s.attendanceRepo.FindPendingReview(departmentID, page, limit)
+}
+
+// ReviewAttendance settles a punch flagged because of its position. A rejected
+// punch marks the day absent.
+func (s *AttendanceService) ReviewAttendance(id, reviewerID uint, req *models.ReviewAttendanceRequest) (*models.Attendance, error) {
+	attendance, err := s.attendanceRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("attendance record not found")
+	}
+
+	if attendance.ReviewStatus != models.AttendanceReviewPending {
+		return nil, errors.New("attendance record is not awaiting review")
+	}
+
+	if attendance.Employee != nil && attendance.Employee.UserID != nil && *attendance.Employee.UserID == reviewerID {
+		return nil, errors.New("you cannot review your own attendance")
+	}
+
+	now := time.Now()
+	attendance.ReviewStatus = req.Status
+	attendance.ReviewedBy = &reviewerID
+	attendance.ReviewedAt = &now
+	attendance.ReviewNotes = req.Notes
+	if req.Status == models.AttendanceReviewRejected {
+		attendance.Status = models.AttendanceDayAbsent
+	}
 
+	if err := s.attendanceRepo.Update(attendance); err != nil {
+		return nil, err
+	}
 	return attendance, nil
 }
 
+// GetPhoto returns the clock-in or clock-out selfie of an attendance record with its content type
+func (s *AttendanceService) GetPhoto(id uint, clockOut bool) ([]byte, string, error) {
+	attendance, err := s.attendanceRepo.FindByID(id)
+	if err != nil {
+		return nil, "", errors.New("attendance record not found")
+	}
+
+	path := attendance.ClockInPhoto
+	if clockOut {
+		path = attendance.ClockOutPhoto
+	}
+	if path == "" {
+		return nil, "", errors.New("no photo was taken")
+	}
+
+	content, err := s.storage.ReadFile(path)
+	if err != nil {
+		return nil, "", errors.New("photo is missing from storage")
+	}
+	return content, http.DetectContentType(content), nil
+}
+
 func (s *AttendanceService) GetAttendanceByEmployee(employeeID uint, month, year int) ([]models.Attendance, error) {
 	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
 	endDate := startDate.AddDate(0, 1, -1)
@@ -84,26 +454,654 @@ func (s *AttendanceService) GetAttendanceByEmployee(employeeID uint, month, year
 	return s.attendanceRepo.FindByEmployee(employeeID, startDate, endDate)
 }
 
-func (s *AttendanceService) GetAttendanceReport(startDate, endDate time.Time) ([]models.Attendance, error) {
-	return s.attendanceRepo.FindByDateRange(startDate, endDate)
+// GetAttendanceReport totals attendance per employee over the range. Each day is checked
+// against the employee's schedule and approved leaves; scheduled days without attendance
+// or leave count as absent once they have passed.
+func (s *AttendanceService) GetAttendanceReport(filter models.AttendanceReportFilter) ([]models.AttendanceReport, int64, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit < 1 || filter.Limit > 100 {
+		filter.Limit = 10
+	}
+
+	filter.StartDate = dateOnly(filter.StartDate)
+	filter.EndDate = dateOnly(filter.EndDate)
+	if err := validateDateRange(filter.StartDate, filter.EndDate); err != nil {
+		return nil, 0, err
+	}
+
+	employees, total, err := s.attendanceRepo.FindReportEmployees(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	employeeIDs := make([]uint, 0, len(employees))
+	for _, employee := range employees {
+		employeeIDs = append(employeeIDs, employee.ID)
+	}
+
+	attendances, err := s.attendanceRepo.FindByEmployeesAndDateRange(employeeIDs, filter.StartDate, filter.EndDate)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	leaves, err := s.leaveRepo.FindApprovedInRange(employeeIDs, filter.StartDate, filter.EndDate)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	remoteWork, err := s.remoteWorkRepo.FindApprovedInRange(employeeIDs, filter.StartDate, filter.EndDate)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	schedules, err := s.shiftService.Schedules(employees, filter.StartDate, filter.EndDate)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sources := &attendanceReportSources{
+		attendances: map[uint]map[string]*models.Attendance{},
+		schedules:   schedules,
+		leaves:      map[uint][]models.Leave{},
+		remoteWork:  map[uint][]models.RemoteWorkRequest{},
+	}
+	for i := range attendances {
+		attendance := &attendances[i]
+		if sources.attendances[attendance.EmployeeID] == nil {
+			sources.attendances[attendance.EmployeeID] = map[string]*models.Attendance{}
+		}
+		sources.attendances[attendance.EmployeeID][attendance.Date.Format("2006-01-02")] = attendance
+	}
+	for _, leave := range leaves {
+		sources.leaves[leave.EmployeeID] = append(sources.leaves[leave.EmployeeID], leave)
+	}
+	for _, request := range remoteWork {
+		sources.remoteWork[request.EmployeeID] = append(sources.remoteWork[request.EmployeeID], request)
+	}
+
+	zones, err := s.timezoneService.ForEmployees(employees)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	now := time.Now()
+	reports := make([]models.AttendanceReport, 0, len(employees))
+	for i := range employees {
+		today := localDate(now, zones[employees[i].ID])
+		reports = append(reports, sources.report(&employees[i], filter, today))
+	}
+
+	return reports, total, nil
 }
 
-func (s *AttendanceService) CreateManualAttendance(attendance *models.Attendance) (*models.Attendance, error) {
+// CreateManualAttendance records a day keyed in by HR. Its times go into the punch
+// log as manual events, which take precedence over other punches of the day.
+func (s *AttendanceService) CreateManualAttendance(attendance *models.Attendance, recordedBy uint) (*models.Attendance, error) {
 	// Check if employee exists
 	_, err := s.employeeRepo.FindByID(attendance.EmployeeID)
 	if err != nil {
 		return nil, errors.New("employee not found")
 	}
 
-	// Check if attendance already exists
+	attendance.Source = models.AttendanceSourceManual
+
+	// Check if attendance already exists. An absence recorded by the end-of-day job
+	// gives way to the day keyed in by HR.
 	existing, err := s.attendanceRepo.FindByEmployeeAndDate(attendance.EmployeeID, attendance.Date)
-	if err == nil && existing.ID > 0 {
-		return nil, errors.New("attendance record already exists for this date")
+	exists := err == nil && existing.ID > 0
+	if exists {
+		if existing.Source != models.AttendanceSourceSystem || existing.ClockIn != nil {
+			return nil, errors.New("attendance record already exists for this date")
+		}
+		attendance.ID = existing.ID
+		attendance.CreatedAt = existing.CreatedAt
+	}
+
+	if attendance.ClockIn != nil && attendance.ClockOut != nil && !attendance.ClockOut.After(*attendance.ClockIn) {
+		return nil, errors.New("clock-out must be after clock-in")
+	}
+
+	attendance.Date = dateOnly(attendance.Date)
+	attendance.BreakMinutes = 0
+
+	if attendance.ClockIn != nil {
+		events := []models.PunchEvent{{
+			EmployeeID: attendance.EmployeeID,
+			WorkDate:   attendance.Date,
+			Type:       models.PunchTypeIn,
+			PunchedAt:  *attendance.ClockIn,
+			Source:     models.AttendanceSourceManual,
+			RecordedBy: &recordedBy,
+			Notes:      attendance.Notes,
+		}}
+		if attendance.ClockOut != nil {
+			events = append(events, models.PunchEvent{
+				EmployeeID: attendance.EmployeeID,
+				WorkDate:   attendance.Date,
+				Type:       models.PunchTypeOut,
+				PunchedAt:  *attendance.ClockOut,
+				Source:     models.AttendanceSourceManual,
+				RecordedBy: &recordedBy,
+				Notes:      attendance.Notes,
+			})
+		}
+		if _, err := s.attendanceRepo.CreatePunchEvents(events); err != nil {
+			return nil, err
+		}
+
+		if err := s.evaluateAttendance(attendance); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := s.attendanceRepo.Create(attendance); err != nil {
+	if err := s.saveAttendance(attendance, exists); err != nil {
 		return nil, err
 	}
 
 	return attendance, nil
 }
+
+// Outcomes of recording device punches for a day
+const (
+	punchMergeCreated   = "created"
+	punchMergeUpdated   = "updated"
+	punchMergeUnchanged = "unchanged"
+	punchMergeSkipped   = "skipped"
+)
+
+// recordDevicePunches stores the device events of one work date and derives the
+// day again. Events already in the log are skipped, so importing overlapping logs
+// again changes nothing. Days keyed in by HR keep their manual times.
+func (s *AttendanceService) recordDevicePunches(employeeID uint, date time.Time, events []models.PunchEvent) (*models.Attendance, string, error) {
+	inserted, err := s.attendanceRepo.CreatePunchEvents(events)
+	if err != nil {
+		return nil, "", err
+	}
+
+	attendance, exists, err := s.deriveAttendance(employeeID, date)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch {
+	case attendance.Source == models.AttendanceSourceManual:
+		return attendance, punchMergeSkipped, nil
+	case inserted == 0 && exists:
+		return attendance, punchMergeUnchanged, nil
+	}
+
+	if err := s.saveAttendance(attendance, exists); err != nil {
+		return nil, "", err
+	}
+	if exists {
+		return attendance, punchMergeUpdated, nil
+	}
+	return attendance, punchMergeCreated, nil
+}
+
+// MarkAbsences is the end-of-day job. It settles each day of the lookback window
+// whose working day has ended, so days missed while the service was down are caught
+// up on the next run.
+func (s *AttendanceService) MarkAbsences() error {
+	now := time.Now()
+	today := s.timezoneService.Today()
+	for days := s.cfg.Attendance.AbsenceLookbackDays; days >= 0; days-- {
+		if _, err := s.closeDay(today.AddDate(0, 0, -days), now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarkAbsencesOn runs the end-of-day job again for a past date
+func (s *AttendanceService) MarkAbsencesOn(date time.Time) (*models.AbsenceMarkingResult, error) {
+	date = dateOnly(date)
+	if date.After(s.timezoneService.Today()) {
+		return nil, errors.New("date cannot be in the future")
+	}
+	return s.closeDay(date, time.Now())
+}
+
+// closeDay records an absence for every employee scheduled to work on the date who
+// neither punched nor was on approved leave or remote work, and clocks out sessions left open at
+// the end of their shift. Days are only settled once their working day has ended,
+// and settled days are left alone, so running it again changes nothing.
+func (s *AttendanceService) closeDay(date, now time.Time) (*models.AbsenceMarkingResult, error) {
+	result := &models.AbsenceMarkingResult{Date: date}
+
+	employees, err := s.attendanceRepo.FindEmployedOn(date)
+	if err != nil || len(employees) == 0 {
+		return result, err
+	}
+
+	employeeIDs := make([]uint, 0, len(employees))
+	for _, employee := range employees {
+		employeeIDs = append(employeeIDs, employee.ID)
+	}
+
+	attendances, err := s.attendanceRepo.FindByEmployeesAndDateRange(employeeIDs, date, date)
+	if err != nil {
+		return nil, err
+	}
+	recorded := make(map[uint]*models.Attendance, len(attendances))
+	for i := range attendances {
+		recorded[attendances[i].EmployeeID] = &attendances[i]
+	}
+
+	punchedIDs, err := s.attendanceRepo.FindPunchedEmployeeIDs(date)
+	if err != nil {
+		return nil, err
+	}
+	punched := make(map[uint]bool, len(punchedIDs))
+	for _, id := range punchedIDs {
+		punched[id] = true
+	}
+
+	leaves, err := s.leaveRepo.FindApprovedInRange(employeeIDs, date, date)
+	if err != nil {
+		return nil, err
+	}
+	onLeave := map[uint][]models.Leave{}
+	for _, leave := range leaves {
+		onLeave[leave.EmployeeID] = append(onLeave[leave.EmployeeID], leave)
+	}
+
+	remoteWork, err := s.remoteWorkRepo.FindApprovedInRange(employeeIDs, date, date)
+	if err != nil {
+		return nil, err
+	}
+	remote := make(map[uint]bool, len(remoteWork))
+	for _, request := range remoteWork {
+		remote[request.EmployeeID] = true
+	}
+
+	schedules, err := s.shiftService.Schedules(employees, date, date)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := s.timezoneService.ForEmployees(employees)
+	if err != nil {
+		return nil, err
+	}
+
+	key := date.Format("2006-01-02")
+	for _, employee := range employees {
+		schedule := schedules[employee.ID][key]
+
+		switch attendance := recorded[employee.ID]; {
+		case attendance != nil && attendance.ClockIn != nil && attendance.ClockOut == nil:
+			closed, err := s.autoClockOut(attendance, now)
+			if err != nil {
+				return nil, err
+			}
+			if closed {
+				result.AutoClosed++
+			}
+		case attendance != nil || punched[employee.ID]:
+		case schedule.DayOff || findLeaveOn(onLeave[employee.ID], date) != nil || remote[employee.ID]:
+		case now.Before(workdayEnd(schedule, zones[employee.ID]).Add(autoClockOutGrace)):
+		default:
+			absence := &models.Attendance{
+				EmployeeID: employee.ID,
+				Date:       date,
+				DayType:    schedule.DayType,
+				Status:     models.AttendanceDayAbsent,
+				Source:     models.AttendanceSourceSystem,
+				Notes:      "No punches recorded",
+			}
+			if schedule.Shift != nil {
+				absence.ShiftID = &schedule.Shift.ID
+			}
+			if err := s.attendanceRepo.Create(absence); err != nil {
+				return nil, err
+			}
+			result.Absent++
+		}
+	}
+
+	return result, nil
+}
+
+// autoClockOut ends a session left open at its scheduled end, or after the standard
+// working hours when there is no shift. The clock-out is a system punch event, so
+// the record is flagged and a correction request can replace it.
+func (s *AttendanceService) autoClockOut(attendance *models.Attendance, now time.Time) (bool, error) {
+	closeAt := attendance.ClockIn.Add(standardWorkingHours * time.Hour)
+	if attendance.ScheduledEnd != nil && attendance.ScheduledEnd.After(*attendance.ClockIn) {
+		closeAt = *attendance.ScheduledEnd
+	}
+
+	events, err := s.attendanceRepo.FindPunchEvents(attendance.EmployeeID, dateOnly(attendance.Date))
+	if err != nil {
+		return false, err
+	}
+	// Days keyed in by HR are closed by HR
+	summary := summarizePunches(events)
+	if summary.state == punchStateOff || summary.manual {
+		return false, nil
+	}
+	if closeAt.Before(summary.last) {
+		closeAt = summary.last
+	}
+	if now.Before(closeAt.Add(autoClockOutGrace)) {
+		return false, nil
+	}
+
+	_, err = s.recordPunchEvents(attendance.EmployeeID, attendance.Date, []models.PunchEvent{{
+		EmployeeID: attendance.EmployeeID,
+		WorkDate:   dateOnly(attendance.Date),
+		Type:       models.PunchTypeOut,
+		PunchedAt:  closeAt,
+		Source:     models.AttendanceSourceSystem,
+		Notes:      "No clock-out recorded; closed at the end of the working day",
+	}})
+	return err == nil, err
+}
+
+// workdayEnd returns when the scheduled working day ends in the employee's time zone:
+// at the end of its shift, or at midnight when there is no shift
+func workdayEnd(schedule models.DaySchedule, zone *time.Location) time.Time {
+	if schedule.Shift != nil {
+		_, end := schedule.Shift.Window(schedule.Date, zone)
+		return end
+	}
+	y, m, d := schedule.Date.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, zone)
+}
+
+// findOpenNightShift returns the previous day's attendance when it belongs to a
+// shift crossing midnight that has not been clocked out yet
+func (s *AttendanceService) findOpenNightShift(employeeID uint, date time.Time) (*models.Attendance, error) {
+	previous, err := s.attendanceRepo.FindByEmployeeAndDate(employeeID, date.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+	if previous.ClockIn == nil || previous.ClockOut != nil || previous.ShiftID == nil {
+		return nil, errors.New("no open night shift")
+	}
+
+	shift, err := s.shiftService.GetShiftByID(*previous.ShiftID)
+	if err != nil || !shift.CrossesMidnight() {
+		return nil, errors.New("no open night shift")
+	}
+	return previous, nil
+}
+
+// evaluateAttendance compares the clock times against the employee's shift for the day.
+// Clock-ins after the grace period are late; hours beyond the shift, or any hours
+// on a rest day or holiday, are overtime. Unscheduled days use the standard 8 hours.
+// Overtime up to the hours of an approved overtime request counts as approved.
+func (s *AttendanceService) evaluateAttendance(attendance *models.Attendance) error {
+	schedule, err := s.shiftService.ScheduleFor(attendance.EmployeeID, attendance.Date)
+	if err != nil {
+		return err
+	}
+	shift := schedule.Shift
+
+	attendance.ShiftID = nil
+	attendance.ScheduledStart = nil
+	attendance.ScheduledEnd = nil
+	attendance.LateMinutes = 0
+	attendance.EarlyLeaveMinutes = 0
+	attendance.WorkingHours = 0
+	attendance.OvertimeHours = 0
+	attendance.ApprovedOvertimeHours = 0
+	attendance.OvertimeRequestID = nil
+	attendance.DayType = schedule.DayType
+
+	var scheduledStart, scheduledEnd time.Time
+	if shift != nil {
+		// Shift times are wall-clock times where the employee works
+		scheduledStart, scheduledEnd = shift.Window(attendance.Date, s.timezoneService.ForEmployeeID(attendance.EmployeeID))
+		attendance.ShiftID = &shift.ID
+		attendance.ScheduledStart = &scheduledStart
+		attendance.ScheduledEnd = &scheduledEnd
+
+		grace := time.Duration(shift.GraceMinutes) * time.Minute
+		if attendance.ClockIn.After(scheduledStart.Add(grace)) {
+			attendance.LateMinutes = int(attendance.ClockIn.Sub(scheduledStart).Minutes())
+			if attendance.Status == "" || attendance.Status == "present" {
+				attendance.Status = "late"
+			}
+		}
+	}
+
+	if attendance.ClockOut == nil {
+		return nil
+	}
+
+	// Punched breaks are unpaid; a shift's break is deducted even when not punched
+	breakMinutes := attendance.BreakMinutes
+	if shift != nil && shift.BreakMinutes > breakMinutes {
+		breakMinutes = shift.BreakMinutes
+	}
+	hours := math.Max(attendance.ClockOut.Sub(*attendance.ClockIn).Hours()-float64(breakMinutes)/60, 0)
+	if shift != nil {
+		if attendance.ClockOut.Before(scheduledEnd) {
+			attendance.EarlyLeaveMinutes = int(scheduledEnd.Sub(*attendance.ClockOut).Minutes())
+		}
+	}
+	attendance.WorkingHours = roundHours(hours)
+
+	switch {
+	case schedule.DayType != models.DayTypeWorkday:
+		attendance.OvertimeHours = attendance.WorkingHours
+	case shift != nil:
+		attendance.OvertimeHours = roundHours(math.Max(hours-shift.ScheduledHours(), 0))
+	default:
+		attendance.OvertimeHours = roundHours(math.Max(hours-standardWorkingHours, 0))
+	}
+
+	// Only overtime covered by an approved request is paid
+	if attendance.OvertimeHours > 0 {
+		if request, err := s.overtimeRepo.FindApproved(attendance.EmployeeID, dateOnly(attendance.Date)); err == nil {
+			attendance.OvertimeRequestID = &request.ID
+			attendance.ApprovedOvertimeHours = math.Min(attendance.OvertimeHours, request.Hours)
+		}
+	}
+
+	return nil
+}
+
+// roundHours keeps two decimals, enough for minute precision in reports
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
+
+// attendanceReportSources holds the records of one report page, keyed by employee and date
+type attendanceReportSources struct {
+	attendances map[uint]map[string]*models.Attendance
+	schedules   map[uint]map[string]models.DaySchedule
+	leaves      map[uint][]models.Leave
+	remoteWork  map[uint][]models.RemoteWorkRequest
+}
+
+// report classifies each day the employee was employed within the range.
+// Attendance wins over leave, and leave only counts on scheduled working days.
+// Approved remote work days are remote whether or not the employee punched;
+// punched ones also count as present.
+func (src *attendanceReportSources) report(employee *models.Employee, filter models.AttendanceReportFilter, today time.Time) models.AttendanceReport {
+	report := models.AttendanceReport{
+		EmployeeID:   employee.ID,
+		EmployeeCode: employee.EmployeeCode,
+		EmployeeName: employee.FirstName + " " + employee.LastName,
+		DepartmentID: employee.DepartmentID,
+	}
+	if employee.Department != nil {
+		report.DepartmentName = employee.Department.Name
+	}
+
+	from, to := filter.StartDate, filter.EndDate
+	if hired := dateOnly(employee.HireDate); hired.After(from) {
+		from = hired
+	}
+	if employee.TerminationDate != nil && dateOnly(*employee.TerminationDate).Before(to) {
+		to = dateOnly(*employee.TerminationDate)
+	}
+
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		key := date.Format("2006-01-02")
+		schedule := src.schedules[employee.ID][key]
+		attendance := src.attendances[employee.ID][key]
+
+		day := models.AttendanceReportDay{Date: date}
+		if schedule.Shift != nil {
+			day.ShiftID = &schedule.Shift.ID
+		}
+		if schedule.Holiday != nil {
+			day.HolidayName = schedule.Holiday.Name
+		}
+
+		switch leave := findLeaveOn(src.leaves[employee.ID], date); {
+		case attendance != nil && attendance.Status != models.AttendanceDayAbsent:
+			day.Status = models.AttendanceDayPresent
+			if attendance.Status == models.AttendanceDayRemote {
+				day.Status = models.AttendanceDayRemote
+				report.RemoteDays++
+			}
+			if attendance.ShiftID != nil {
+				day.ShiftID = attendance.ShiftID
+			}
+			day.LateMinutes = attendance.LateMinutes
+			day.EarlyLeaveMinutes = attendance.EarlyLeaveMinutes
+			day.Late = attendance.LateMinutes > 0 || attendance.Status == "late"
+			day.EarlyLeave = attendance.EarlyLeaveMinutes > 0
+			day.WorkingHours = attendance.WorkingHours
+			day.AutoClosed = attendance.AutoClosed
+
+			report.PresentDays++
+			if day.Late {
+				report.LateDays++
+			}
+			if day.EarlyLeave {
+				report.EarlyLeaveDays++
+			}
+			report.LateMinutes += attendance.LateMinutes
+			report.EarlyLeaveMinutes += attendance.EarlyLeaveMinutes
+			report.TotalHours += attendance.WorkingHours
+			report.OvertimeHours += attendance.OvertimeHours
+		case schedule.DayOff && schedule.Holiday != nil:
+			day.Status = models.AttendanceDayHoliday
+		case schedule.DayOff:
+			day.Status = models.AttendanceDayOff
+		case leave != nil:
+			day.Status = models.AttendanceDayOnLeave
+			day.LeaveType = leave.LeaveType
+			report.LeaveDays++
+		case findRemoteWorkOn(src.remoteWork[employee.ID], date) != nil:
+			day.Status = models.AttendanceDayRemote
+			report.RemoteDays++
+		case attendance != nil || date.Before(today):
+			day.Status = models.AttendanceDayAbsent
+			report.AbsentDays++
+		default:
+			day.Status = models.AttendanceDayUpcoming
+		}
+
+		if !schedule.DayOff && day.Status != models.AttendanceDayUpcoming {
+			report.TotalDays++
+		}
+		if filter.IncludeDays {
+			report.Days = append(report.Days, day)
+		}
+	}
+
+	report.TotalHours = roundHours(report.TotalHours)
+	report.OvertimeHours = roundHours(report.OvertimeHours)
+	return report
+}
+
+func findRemoteWorkOn(requests []models.RemoteWorkRequest, date time.Time) *models.RemoteWorkRequest {
+	for i := range requests {
+		if !date.Before(dateOnly(requests[i].StartDate)) && !date.After(dateOnly(requests[i].EndDate)) {
+			return &requests[i]
+		}
+	}
+	return nil
+}
+
+func findLeaveOn(leaves []models.Leave, date time.Time) *models.Leave {
+	for i := range leaves {
+		if !date.Before(dateOnly(leaves[i].StartDate)) && !date.After(dateOnly(leaves[i].EndDate)) {
+			return &leaves[i]
+		}
+	}
+	return nil
+}
+
+// checkGeofence classifies a reported position against the active geofences of the
+// employee's work location
+func (s *AttendanceService) checkGeofence(employee *models.Employee, latitude, longitude, accuracy *float64) (string, error) {
+	if employee.WorkLocationID == nil {
+		return models.GeofenceNotConfigured, nil
+	}
+
+	geofences, err := s.locationRepo.FindActiveGeofences(*employee.WorkLocationID)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case len(geofences) == 0:
+		return models.GeofenceNotConfigured, nil
+	case latitude == nil || longitude == nil:
+		return models.GeofenceNoPosition, nil
+	case accuracy != nil && *accuracy > s.cfg.Attendance.GeofenceMaxAccuracyMeters:
+		return models.GeofenceLowAccuracy, nil
+	}
+
+	for i := range geofences {
+		if geofences[i].Contains(*latitude, *longitude) {
+			return models.GeofenceInside, nil
+		}
+	}
+	return models.GeofenceOutside, nil
+}
+
+// savePhoto stores a clock-in or clock-out selfie. Only JPEG and PNG images are
+// accepted, detected from the content rather than the file name.
+func (s *AttendanceService) savePhoto(employeeID uint, header *multipart.FileHeader) (string, error) {
+	maxSize := int64(s.cfg.Storage.MaxUploadSizeMB) << 20
+	if header.Size > maxSize {
+		return "", fmt.Errorf("photo is larger than %d MB", s.cfg.Storage.MaxUploadSizeMB)
+	}
+
+	src, err := header.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(src, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", errors.New("uploaded photo is empty")
+	}
+	head = head[:n]
+
+	ext, ok := allowedPhotoTypes[http.DetectContentType(head)]
+	if !ok {
+		return "", errors.New("only JPEG and PNG photos are allowed")
+	}
+
+	return s.storage.Save(fmt.Sprintf("employees/%d/attendance", employeeID), ext, io.MultiReader(bytes.NewReader(head), src))
+}
+
+func (s *AttendanceService) deletePhoto(path string) {
+	if path != "" {
+		s.storage.Delete(path)
+	}
+}
+
+// flagForReview queues the record for a manager when a position check failed
+func flagForReview(attendance *models.Attendance, geofence string) {
+	switch geofence {
+	case models.GeofenceOutside, models.GeofenceLowAccuracy, models.GeofenceNoPosition:
+		if attendance.ReviewStatus != models.AttendanceReviewRejected {
+			attendance.ReviewStatus = models.AttendanceReviewPending
+		}
+	}
+}
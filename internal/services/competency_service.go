@@ -0,0 +1,400 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hr-backend/internal/config"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"hr-backend/internal/storage"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// allowedScanTypes maps the accepted certificate scan types to their file extension
+var allowedScanTypes = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+type CompetencyService struct {
+	competencyRepo      *repositories.CompetencyRepository
+	employeeRepo        *repositories.EmployeeRepository
+	notificationService *NotificationService
+	storage             *storage.LocalStorage
+	cfg                 *config.Config
+}
+
+func NewCompetencyService(competencyRepo *repositories.CompetencyRepository, employeeRepo *repositories.EmployeeRepository, notificationService *NotificationService, storage *storage.LocalStorage, cfg *config.Config) *CompetencyService {
+	return &CompetencyService{
+		competencyRepo:      competencyRepo,
+		employeeRepo:        employeeRepo,
+		notificationService: notificationService,
+		storage:             storage,
+		cfg:                 cfg,
+	}
+}
+
+func (s *CompetencyService) GetSkills(employeeID uint) ([]models.EmployeeSkill, error) {
+	if _, err := s.employeeRepo.FindByID(employeeID); err != nil {
+		return nil, errors.New("employee not found")
+	}
+	return s.competencyRepo.FindSkillsByEmployee(employeeID)
+}
+
+func (s *CompetencyService) AddSkill(employeeID uint, req *models.SkillRequest) (*models.EmployeeSkill, error) {
+	if _, err := s.employeeRepo.FindByID(employeeID); err != nil {
+		return nil, errors.New("employee not found")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if _, err := s.competencyRepo.FindSkillByName(employeeID, name); err == nil {
+		return nil, errors.New("employee already has this skill; update its level instead")
+	}
+
+	skill := &models.EmployeeSkill{
+		EmployeeID: employeeID,
+		Name:       name,
+		Level:      req.Level,
+		Notes:      req.Notes,
+	}
+	if err := s.competencyRepo.CreateSkill(skill); err != nil {
+		return nil, err
+	}
+	return skill, nil
+}
+
+func (s *CompetencyService) UpdateSkill(employeeID, skillID uint, req *models.SkillRequest) (*models.EmployeeSkill, error) {
+	skill, err := s.competencyRepo.FindSkillByID(skillID)
+	if err != nil || skill.EmployeeID != employeeID {
+		return nil, errors.New("skill not found")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if existing, err := s.competencyRepo.FindSkillByName(employeeID, name); err == nil && existing.ID != skill.ID {
+		return nil, errors.New("employee already has this skill")
+	}
+
+	skill.Name = name
+	skill.Level = req.Level
+	skill.Notes = req.Notes
+	if err := s.competencyRepo.UpdateSkill(skill); err != nil {
+		return nil, err
+	}
+	return skill, nil
+}
+
+func (s *CompetencyService) DeleteSkill(employeeID, skillID uint) error {
+	skill, err := s.competencyRepo.FindSkillByID(skillID)
+	if err != nil || skill.EmployeeID != employeeID {
+		return errors.New("skill not found")
+	}
+	return s.competencyRepo.DeleteSkill(skill.ID)
+}
+
+// SearchSkills finds employees with a skill at or above the given proficiency level
+func (s *CompetencyService) SearchSkills(name, minLevel string, departmentID *uint) ([]models.EmployeeSkill, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("skill name is required")
+	}
+
+	var levels []string
+	if minLevel != "" {
+		levels = models.SkillLevelsAtLeast(minLevel)
+		if levels == nil {
+			return nil, errors.New("invalid skill level")
+		}
+	}
+
+	return s.competencyRepo.SearchSkills(name, levels, departmentID)
+}
+
+func (s *CompetencyService) GetCertifications(employeeID uint) ([]models.Certification, error) {
+	if _, err := s.employeeRepo.FindByID(employeeID); err != nil {
+		return nil, errors.New("employee not found")
+	}
+
+	certifications, err := s.competencyRepo.FindCertificationsByEmployee(employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.setStatuses(certifications)
+	return certifications, nil
+}
+
+// SearchCertifications answers questions like "who holds certificate X valid next month"
+func (s *CompetencyService) SearchCertifications(filter models.CertificationFilter, page, limit int) ([]models.Certification, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter.Name = strings.TrimSpace(filter.Name)
+
+	certifications, total, err := s.competencyRepo.SearchCertifications(filter, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s.setStatuses(certifications)
+	return certifications, total, nil
+}
+
+func (s *CompetencyService) GetCertificationByID(id uint) (*models.Certification, error) {
+	certification, err := s.competencyRepo.FindCertificationByID(id)
+	if err != nil {
+		return nil, errors.New("certification not found")
+	}
+
+	certification.Status = s.certificationStatus(certification, dateOnly(time.Now()))
+	return certification, nil
+}
+
+func (s *CompetencyService) AddCertification(employeeID uint, req *models.CertificationRequest) (*models.Certification, error) {
+	if _, err := s.employeeRepo.FindByID(employeeID); err != nil {
+		return nil, errors.New("employee not found")
+	}
+
+	certification := &models.Certification{EmployeeID: employeeID}
+	if err := applyCertificationRequest(certification, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.competencyRepo.CreateCertification(certification); err != nil {
+		return nil, err
+	}
+
+	return s.GetCertificationByID(certification.ID)
+}
+
+func (s *CompetencyService) UpdateCertification(id uint, req *models.CertificationRequest) (*models.Certification, error) {
+	certification, err := s.competencyRepo.FindCertificationByID(id)
+	if err != nil {
+		return nil, errors.New("certification not found")
+	}
+
+	previousExpiry := certification.ExpiryDate
+	if err := applyCertificationRequest(certification, req); err != nil {
+		return nil, err
+	}
+
+	// A renewed certificate gets a fresh reminder before its new expiry
+	if !sameDate(previousExpiry, certification.ExpiryDate) {
+		certification.RemindedAt = nil
+	}
+
+	if err := s.competencyRepo.UpdateCertification(certification); err != nil {
+		return nil, err
+	}
+
+	return s.GetCertificationByID(id)
+}
+
+func (s *CompetencyService) DeleteCertification(id uint) error {
+	certification, err := s.competencyRepo.FindCertificationByID(id)
+	if err != nil {
+		return errors.New("certification not found")
+	}
+
+	if err := s.competencyRepo.DeleteCertification(id); err != nil {
+		return err
+	}
+
+	for _, file := range certification.Files {
+		if err := s.storage.Delete(file.StoragePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UploadFile stores a scan of the certificate. Only PDF, JPEG and PNG files are
+// accepted, detected from the content rather than the file name.
+func (s *CompetencyService) UploadFile(certificationID uint, header *multipart.FileHeader) (*models.CertificationFile, error) {
+	certification, err := s.competencyRepo.FindCertificationByID(certificationID)
+	if err != nil {
+		return nil, errors.New("certification not found")
+	}
+
+	maxSize := int64(s.cfg.Storage.MaxUploadSizeMB) << 20
+	if header.Size > maxSize {
+		return nil, fmt.Errorf("file is larger than %d MB", s.cfg.Storage.MaxUploadSizeMB)
+	}
+
+	src, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(src, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, errors.New("uploaded file is empty")
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	ext, ok := allowedScanTypes[contentType]
+	if !ok {
+		return nil, errors.New("only PDF, JPEG and PNG files are allowed")
+	}
+
+	dir := fmt.Sprintf("employees/%d/certifications/%d", certification.EmployeeID, certification.ID)
+	path, err := s.storage.Save(dir, ext, io.MultiReader(bytes.NewReader(head), src))
+	if err != nil {
+		return nil, err
+	}
+
+	file := &models.CertificationFile{
+		CertificationID: certification.ID,
+		FileName:        filepath.Base(header.Filename),
+		ContentType:     contentType,
+		Size:            header.Size,
+		StoragePath:     path,
+	}
+	if err := s.competencyRepo.CreateFile(file); err != nil {
+		s.storage.Delete(path)
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// GetFile returns the file record and its content for download
+func (s *CompetencyService) GetFile(certificationID, fileID uint) (*models.CertificationFile, []byte, error) {
+	file, err := s.competencyRepo.FindFile(certificationID, fileID)
+	if err != nil {
+		return nil, nil, errors.New("file not found")
+	}
+
+	content, err := s.storage.ReadFile(file.StoragePath)
+	if err != nil {
+		return nil, nil, errors.New("file content is missing from storage")
+	}
+
+	return file, content, nil
+}
+
+func (s *CompetencyService) DeleteFile(certificationID, fileID uint) error {
+	file, err := s.competencyRepo.FindFile(certificationID, fileID)
+	if err != nil {
+		return errors.New("file not found")
+	}
+
+	if err := s.competencyRepo.DeleteFile(file.ID); err != nil {
+		return err
+	}
+	return s.storage.Delete(file.StoragePath)
+}
+
+// SendExpiryReminders notifies the employee and their department manager once
+// when a certificate is about to expire
+func (s *CompetencyService) SendExpiryReminders() error {
+	today := dateOnly(time.Now())
+
+	certifications, err := s.competencyRepo.FindCertificationsToRemind(today.AddDate(0, 0, s.cfg.Certification.ReminderDays))
+	if err != nil {
+		return err
+	}
+
+	for i := range certifications {
+		certification := &certifications[i]
+		employee := certification.Employee
+		if employee == nil {
+			continue
+		}
+
+		title := "Certification expiring"
+		if certification.ExpiryDate.Before(today) {
+			title = "Certification expired"
+		}
+		link := fmt.Sprintf("/sertifikasi/%d", certification.ID)
+
+		if employee.UserID != nil {
+			s.notificationService.Notify(*employee.UserID, "certification", title,
+				fmt.Sprintf("Your %s certificate expires on %s", certification.Name, certification.ExpiryDate.Format("2006-01-02")), link)
+		}
+
+		if employee.Department != nil && employee.Department.ManagerID != nil && *employee.Department.ManagerID != employee.ID {
+			manager, err := s.employeeRepo.FindByID(*employee.Department.ManagerID)
+			if err == nil && manager.UserID != nil {
+				s.notificationService.Notify(*manager.UserID, "certification", title,
+					fmt.Sprintf("The %s certificate of %s %s expires on %s", certification.Name, employee.FirstName, employee.LastName,
+						certification.ExpiryDate.Format("2006-01-02")), link)
+			}
+		}
+
+		if err := s.competencyRepo.MarkCertificationReminded(certification.ID, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *CompetencyService) setStatuses(certifications []models.Certification) {
+	today := dateOnly(time.Now())
+	for i := range certifications {
+		certifications[i].Status = s.certificationStatus(&certifications[i], today)
+	}
+}
+
+func (s *CompetencyService) certificationStatus(certification *models.Certification, today time.Time) string {
+	if certification.ExpiryDate == nil {
+		return models.CertificationValid
+	}
+	expiry := dateOnly(*certification.ExpiryDate)
+	if expiry.Before(today) {
+		return models.CertificationExpired
+	}
+	if !expiry.After(today.AddDate(0, 0, s.cfg.Certification.ReminderDays)) {
+		return models.CertificationExpiring
+	}
+	return models.CertificationValid
+}
+
+func applyCertificationRequest(certification *models.Certification, req *models.CertificationRequest) error {
+	issueDate := dateOnly(req.IssueDate.Time)
+	if issueDate.After(dateOnly(time.Now())) {
+		return errors.New("issue date cannot be in the future")
+	}
+
+	var expiryDate *time.Time
+	if req.ExpiryDate != nil {
+		expiry := dateOnly(req.ExpiryDate.Time)
+		if !expiry.After(issueDate) {
+			return errors.New("expiry date must be after issue date")
+		}
+		expiryDate = &expiry
+	}
+
+	certification.Name = strings.TrimSpace(req.Name)
+	certification.Issuer = strings.TrimSpace(req.Issuer)
+	certification.CertificateNumber = strings.TrimSpace(req.CertificateNumber)
+	certification.IssueDate = issueDate
+	certification.ExpiryDate = expiryDate
+	return nil
+}
+
+func sameDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return dateOnly(*a).Equal(dateOnly(*b))
+}
+
+// deleteEmployeeFiles removes every uploaded file stored for an employee
+func deleteEmployeeFiles(store *storage.LocalStorage, employeeID uint) error {
+	return store.DeleteDir(fmt.Sprintf("employees/%d", employeeID))
+}
@@ -0,0 +1,313 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+type CustomFieldService struct {
+	customFieldRepo *repositories.CustomFieldRepository
+}
+
+func NewCustomFieldService(customFieldRepo *repositories.CustomFieldRepository) *CustomFieldService {
+	return &CustomFieldService{
+		customFieldRepo: customFieldRepo,
+	}
+}
+
+func (s *CustomFieldService) CreateDefinition(req *models.CreateCustomFieldRequest) (*models.CustomFieldDefinition, error) {
+	if !customFieldKeyPattern.MatchString(req.Key) {
+		return nil, errors.New("key must start with a letter and contain only lowercase letters, digits and underscores")
+	}
+
+	if _, err := s.customFieldRepo.FindByEntityAndKey(req.EntityType, req.Key); err == nil {
+		return nil, errors.New("custom field key already exists")
+	}
+
+	definition := &models.CustomFieldDefinition{
+		EntityType:      req.EntityType,
+		Key:             req.Key,
+		Label:           req.Label,
+		FieldType:       req.FieldType,
+		Required:        req.Required,
+		Options:         req.Options,
+		ValidationRegex: req.ValidationRegex,
+		VisibleToRoles:  req.VisibleToRoles,
+		SortOrder:       req.SortOrder,
+	}
+
+	if err := validateDefinition(definition); err != nil {
+		return nil, err
+	}
+
+	if err := s.customFieldRepo.Create(definition); err != nil {
+		return nil, err
+	}
+
+	return definition, nil
+}
+
+func (s *CustomFieldService) GetDefinitions(entityType, role string) ([]models.CustomFieldDefinition, error) {
+	definitions, err := s.customFieldRepo.FindByEntity(entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]models.CustomFieldDefinition, 0, len(definitions))
+	for _, definition := range definitions {
+		if definition.IsVisibleTo(role) {
+			visible = append(visible, definition)
+		}
+	}
+
+	return visible, nil
+}
+
+func (s *CustomFieldService) UpdateDefinition(id uint, req *models.UpdateCustomFieldRequest) (*models.CustomFieldDefinition, error) {
+	definition, err := s.customFieldRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Label != "" {
+		definition.Label = req.Label
+	}
+	if req.Required != nil {
+		definition.Required = *req.Required
+	}
+	if req.Options != nil {
+		definition.Options = req.Options
+	}
+	if req.ValidationRegex != nil {
+		definition.ValidationRegex = *req.ValidationRegex
+	}
+	if req.VisibleToRoles != nil {
+		definition.VisibleToRoles = req.VisibleToRoles
+	}
+	if req.SortOrder != nil {
+		definition.SortOrder = *req.SortOrder
+	}
+
+	if err := validateDefinition(definition); err != nil {
+		return nil, err
+	}
+
+	if err := s.customFieldRepo.Update(definition); err != nil {
+		return nil, err
+	}
+
+	return definition, nil
+}
+
+func (s *CustomFieldService) DeleteDefinition(id uint) error {
+	if _, err := s.customFieldRepo.FindByID(id); err != nil {
+		return err
+	}
+	return s.customFieldRepo.Delete(id)
+}
+
+// ValidateValues merges the submitted values into the existing ones and checks
+// the result against the entity's field definitions. A null value removes the field.
+func (s *CustomFieldService) ValidateValues(entityType string, existing, submitted models.JSONMap) (models.JSONMap, error) {
+	definitions, err := s.customFieldRepo.FindByEntity(entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]*models.CustomFieldDefinition, len(definitions))
+	for i := range definitions {
+		byKey[definitions[i].Key] = &definitions[i]
+	}
+
+	merged := models.JSONMap{}
+	for key, value := range existing {
+		merged[key] = value
+	}
+
+	for key, value := range submitted {
+		definition, ok := byKey[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown custom field: %s", key)
+		}
+
+		if isEmptyCustomValue(value) {
+			delete(merged, key)
+			continue
+		}
+
+		normalized, err := validateCustomValue(definition, value)
+		if err != nil {
+			return nil, err
+		}
+		merged[key] = normalized
+	}
+
+	for _, definition := range definitions {
+		if definition.Required && isEmptyCustomValue(merged[definition.Key]) {
+			return nil, fmt.Errorf("custom field %s is required", definition.Key)
+		}
+	}
+
+	return merged, nil
+}
+
+// FilterValues strips values of fields the given role is not allowed to see
+func (s *CustomFieldService) FilterValues(entityType, role string, values models.JSONMap) (models.JSONMap, error) {
+	definitions, err := s.customFieldRepo.FindByEntity(entityType)
+	if err != nil {
+		return nil, err
+	}
+	return filterCustomValues(definitions, role, values), nil
+}
+
+func filterCustomValues(definitions []models.CustomFieldDefinition, role string, values models.JSONMap) models.JSONMap {
+	if values == nil {
+		return nil
+	}
+
+	filtered := models.JSONMap{}
+	for _, definition := range definitions {
+		if value, ok := values[definition.Key]; ok && definition.IsVisibleTo(role) {
+			filtered[definition.Key] = value
+		}
+	}
+	return filtered
+}
+
+// ErrInvalidCustomFieldFilter is returned for list filters on fields that do not
+// exist or that the caller's role may not see
+var ErrInvalidCustomFieldFilter = errors.New("unknown custom field filter")
+
+// ValidateFilterKeys ensures list filters only reference fields visible to the
+// role; filtering on a hidden field would reveal its values through the results
+func (s *CustomFieldService) ValidateFilterKeys(entityType, role string, filters map[string]string) error {
+	if len(filters) == 0 {
+		return nil
+	}
+
+	definitions, err := s.customFieldRepo.FindByEntity(entityType)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]bool, len(definitions))
+	for _, definition := range definitions {
+		known[definition.Key] = definition.IsVisibleTo(role)
+	}
+
+	// Hidden fields are reported as unknown so the error does not confirm they exist
+	for key := range filters {
+		if !known[key] {
+			return fmt.Errorf("%w: %s", ErrInvalidCustomFieldFilter, key)
+		}
+	}
+
+	return nil
+}
+
+func validateDefinition(definition *models.CustomFieldDefinition) error {
+	isSelect := definition.FieldType == models.CustomFieldTypeSelect || definition.FieldType == models.CustomFieldTypeMultiSelect
+	if isSelect && len(definition.Options) == 0 {
+		return errors.New("select fields require at least one option")
+	}
+	if !isSelect && len(definition.Options) > 0 {
+		return errors.New("options are only allowed for select fields")
+	}
+
+	if definition.ValidationRegex != "" {
+		if definition.FieldType != models.CustomFieldTypeText {
+			return errors.New("validation regex is only allowed for text fields")
+		}
+		if _, err := regexp.Compile(definition.ValidationRegex); err != nil {
+			return fmt.Errorf("invalid validation regex: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func validateCustomValue(definition *models.CustomFieldDefinition, value interface{}) (interface{}, error) {
+	switch definition.FieldType {
+	case models.CustomFieldTypeText:
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("custom field %s must be text", definition.Key)
+		}
+		if definition.ValidationRegex != "" {
+			matched, err := regexp.MatchString(definition.ValidationRegex, text)
+			if err != nil || !matched {
+				return nil, fmt.Errorf("custom field %s has an invalid format", definition.Key)
+			}
+		}
+		return text, nil
+
+	case models.CustomFieldTypeNumber:
+		number, ok := value.(float64)
+		if !ok {
+			return nil, fmt.Errorf("custom field %s must be a number", definition.Key)
+		}
+		return number, nil
+
+	case models.CustomFieldTypeDate:
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("custom field %s must be a date (YYYY-MM-DD)", definition.Key)
+		}
+		if _, err := time.Parse("2006-01-02", text); err != nil {
+			return nil, fmt.Errorf("custom field %s must be a date (YYYY-MM-DD)", definition.Key)
+		}
+		return text, nil
+
+	case models.CustomFieldTypeBoolean:
+		flag, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("custom field %s must be true or false", definition.Key)
+		}
+		return flag, nil
+
+	case models.CustomFieldTypeSelect:
+		text, ok := value.(string)
+		if !ok || !definition.Options.Contains(text) {
+			return nil, fmt.Errorf("custom field %s must be one of: %s", definition.Key, strings.Join(definition.Options, ", "))
+		}
+		return text, nil
+
+	case models.CustomFieldTypeMultiSelect:
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("custom field %s must be a list", definition.Key)
+		}
+		selected := make([]string, 0, len(items))
+		for _, item := range items {
+			text, ok := item.(string)
+			if !ok || !definition.Options.Contains(text) {
+				return nil, fmt.Errorf("custom field %s values must be among: %s", definition.Key, strings.Join(definition.Options, ", "))
+			}
+			selected = append(selected, text)
+		}
+		return selected, nil
+	}
+
+	return nil, fmt.Errorf("custom field %s has unsupported type %s", definition.Key, definition.FieldType)
+}
+
+func isEmptyCustomValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []interface{}:
+		return len(v) == 0
+	case []string:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxDateRangeDays bounds roster reads, pattern roll-outs and reports
+const maxDateRangeDays = 366
+
+// dateOnly returns the calendar date of t as midnight UTC, the form used for DATE columns
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// localDate returns the calendar date of t in the time zone, as midnight UTC
+func localDate(t time.Time, zone *time.Location) time.Time {
+	return dateOnly(t.In(zone))
+}
+
+// startOfWeek returns the Monday of the week containing the date
+func startOfWeek(date time.Time) time.Time {
+	return date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+}
+
+func validateDateRange(startDate, endDate time.Time) error {
+	if endDate.Before(startDate) {
+		return errors.New("end date must be on or after start date")
+	}
+	if endDate.Sub(startDate).Hours()/24 >= maxDateRangeDays {
+		return fmt.Errorf("date range cannot exceed %d days", maxDateRangeDays)
+	}
+	return nil
+}
@@ -6,12 +6,14 @@ import (
 )
 
 type DepartmentService struct {
-	deptRepo *repositories.DepartmentRepository
+	deptRepo     *repositories.DepartmentRepository
+	employeeRepo *repositories.EmployeeRepository
 }
 
-func NewDepartmentService(deptRepo *repositories.DepartmentRepository) *DepartmentService {
+func NewDepartmentService(deptRepo *repositories.DepartmentRepository, employeeRepo *repositories.EmployeeRepository) *DepartmentService {
 	return &DepartmentService{
-		deptRepo: deptRepo,
+		deptRepo:     deptRepo,
+		employeeRepo: employeeRepo,
 	}
 }
 
@@ -39,11 +41,18 @@ func (s *DepartmentService) UpdateDepartment(id uint, dept *models.Department) (
 	existing.Name = dept.Name
 	existing.Description = dept.Description
 	existing.ManagerID = dept.ManagerID
+	existing.RemoteWorkPolicyID = dept.RemoteWorkPolicyID
+	existing.RemoteWorkPolicy = nil
 
 	if err := s.deptRepo.Update(existing); err != nil {
 		return nil, err
 	}
 
+	// Department names are part of the employee search document
+	if err := s.employeeRepo.RefreshSearchTextByDepartment(id); err != nil {
+		return nil, err
+	}
+
 	return s.deptRepo.FindByID(id)
 }
 
@@ -0,0 +1,433 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hr-backend/internal/config"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"hr-backend/internal/utils"
+	"io"
+	"mime/multipart"
+	"sort"
+	"strings"
+	"time"
+)
+
+// duplicatePunchWindow merges repeated scans of the same finger into one punch
+const duplicatePunchWindow = 2 * time.Minute
+
+// overnightPunchSlack is how long after a night shift's scheduled end a punch
+// still counts as the clock-out of that shift rather than the next day's clock-in
+const overnightPunchSlack = 4 * time.Hour
+
+type DeviceService struct {
+	deviceRepo        *repositories.DeviceRepository
+	employeeRepo      *repositories.EmployeeRepository
+	locationRepo      *repositories.WorkLocationRepository
+	attendanceService *AttendanceService
+	shiftService      *ShiftService
+	timezoneService   *TimezoneService
+	cfg               *config.Config
+}
+
+func NewDeviceService(deviceRepo *repositories.DeviceRepository, employeeRepo *repositories.EmployeeRepository, locationRepo *repositories.WorkLocationRepository, attendanceService *AttendanceService, shiftService *ShiftService, timezoneService *TimezoneService, cfg *config.Config) *DeviceService {
+	return &DeviceService{
+		deviceRepo:        deviceRepo,
+		employeeRepo:      employeeRepo,
+		locationRepo:      locationRepo,
+		attendanceService: attendanceService,
+		shiftService:      shiftService,
+		timezoneService:   timezoneService,
+		cfg:               cfg,
+	}
+}
+
+func (s *DeviceService) GetDevices() ([]models.AttendanceDevice, error) {
+	return s.deviceRepo.FindAll()
+}
+
+func (s *DeviceService) GetDeviceByID(id uint) (*models.AttendanceDevice, error) {
+	device, err := s.deviceRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("device not found")
+	}
+	return device, nil
+}
+
+func (s *DeviceService) CreateDevice(req *models.AttendanceDeviceRequest) (*models.AttendanceDevice, error) {
+	device := &models.AttendanceDevice{IsActive: true}
+	if err := s.applyDeviceRequest(device, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.deviceRepo.Save(device); err != nil {
+		return nil, err
+	}
+	return s.deviceRepo.FindByID(device.ID)
+}
+
+func (s *DeviceService) UpdateDevice(id uint, req *models.AttendanceDeviceRequest) (*models.AttendanceDevice, error) {
+	device, err := s.GetDeviceByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyDeviceRequest(device, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.deviceRepo.Save(device); err != nil {
+		return nil, err
+	}
+	return s.deviceRepo.FindByID(device.ID)
+}
+
+func (s *DeviceService) DeleteDevice(id uint) error {
+	if _, err := s.GetDeviceByID(id); err != nil {
+		return err
+	}
+	return s.deviceRepo.Delete(id)
+}
+
+func (s *DeviceService) GetDeviceUsers(deviceID uint) ([]models.DeviceUser, error) {
+	if _, err := s.GetDeviceByID(deviceID); err != nil {
+		return nil, err
+	}
+	return s.deviceRepo.FindUsers(deviceID)
+}
+
+// SetDeviceUsers maps device user IDs to employees, re-pointing IDs that are already mapped
+func (s *DeviceService) SetDeviceUsers(deviceID uint, req *models.SetDeviceUsersRequest) ([]models.DeviceUser, error) {
+	if _, err := s.GetDeviceByID(deviceID); err != nil {
+		return nil, err
+	}
+
+	users := make([]models.DeviceUser, 0, len(req.Users))
+	seen := map[string]bool{}
+	for _, item := range req.Users {
+		userID := utils.NormalizeDeviceUserID(item.DeviceUserID)
+		if userID == "" {
+			return nil, errors.New("device user ID cannot be empty")
+		}
+		if seen[userID] {
+			return nil, fmt.Errorf("device user ID %s is listed more than once", userID)
+		}
+		seen[userID] = true
+
+		if _, err := s.employeeRepo.FindByID(item.EmployeeID); err != nil {
+			return nil, fmt.Errorf("employee %d not found", item.EmployeeID)
+		}
+
+		users = append(users, models.DeviceUser{
+			DeviceID:     deviceID,
+			DeviceUserID: userID,
+			EmployeeID:   item.EmployeeID,
+		})
+	}
+
+	if err := s.deviceRepo.UpsertUsers(users); err != nil {
+		return nil, err
+	}
+	return s.deviceRepo.FindUsers(deviceID)
+}
+
+func (s *DeviceService) DeleteDeviceUser(deviceID, id uint) error {
+	user, err := s.deviceRepo.FindUserByID(id)
+	if err != nil || user.DeviceID != deviceID {
+		return errors.New("device user not found")
+	}
+	return s.deviceRepo.DeleteUser(id)
+}
+
+func (s *DeviceService) GetImports(deviceID uint, page, limit int) ([]models.DeviceImport, int64, error) {
+	if _, err := s.GetDeviceByID(deviceID); err != nil {
+		return nil, 0, err
+	}
+	return s.deviceRepo.FindImports(deviceID, page, limit)
+}
+
+// devicePunchDay collects the punches of one employee assigned to one workday
+type devicePunchDay struct {
+	employeeID uint
+	date       time.Time
+	punches    []time.Time
+}
+
+// ImportPunchLog applies a punch log exported by the device to attendance. Rows are
+// matched to employees through the device user mappings; unmatched and unreadable
+// rows are reported and otherwise ignored. Repeated scans within two minutes count
+// once. Each punch is assigned to a workday, where punches shortly after a night
+// shift belong to the day the shift started, and the first and last punch of the
+// day become the clock-in and clock-out, with the punches between them as breaks.
+// Punches are kept in the punch event log. A file that was imported before changes
+// nothing, and punches already logged from overlapping files are skipped.
+func (s *DeviceService) ImportPunchLog(deviceID, importedBy uint, header *multipart.FileHeader) (*models.DeviceImportResult, error) {
+	device, err := s.GetDeviceByID(deviceID)
+	if err != nil {
+		return nil, err
+	}
+	if !device.IsActive {
+		return nil, errors.New("device is inactive")
+	}
+
+	maxSize := int64(s.cfg.Storage.MaxUploadSizeMB) << 20
+	if header.Size > maxSize {
+		return nil, fmt.Errorf("file is larger than %d MB", s.cfg.Storage.MaxUploadSizeMB)
+	}
+
+	src, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	content, err := io.ReadAll(src)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+	if previous, err := s.deviceRepo.FindImportByHash(deviceID, hash); err == nil {
+		return &models.DeviceImportResult{
+			Import:          previous,
+			AlreadyImported: true,
+			Unmatched:       []models.DeviceImportIssue{},
+			Invalid:         []models.DeviceImportIssue{},
+			Warnings:        []models.DeviceImportIssue{},
+		}, nil
+	}
+
+	// Devices log the wall-clock time of the location they are installed at
+	zone := s.timezoneService.ForLocation(device.WorkLocationID)
+	punches, parseErrors, err := utils.ParseDevicePunchLog(bytes.NewReader(content), zone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid punch log: %w", err)
+	}
+	if len(punches) == 0 {
+		return nil, errors.New("the file contains no punches")
+	}
+
+	record := &models.DeviceImport{
+		DeviceID:   deviceID,
+		FileName:   header.Filename,
+		FileHash:   hash,
+		ImportedBy: importedBy,
+		ImportedAt: time.Now(),
+		Rows:       len(punches) + len(parseErrors),
+	}
+	result := &models.DeviceImportResult{
+		Import:    record,
+		Unmatched: []models.DeviceImportIssue{},
+		Invalid:   []models.DeviceImportIssue{},
+		Warnings:  []models.DeviceImportIssue{},
+	}
+
+	for _, parseError := range parseErrors {
+		result.Invalid = append(result.Invalid, models.DeviceImportIssue{
+			Line:   parseError.Line,
+			Text:   parseError.Text,
+			Reason: parseError.Reason,
+		})
+	}
+
+	mappings, err := s.deviceRepo.FindUsers(deviceID)
+	if err != nil {
+		return nil, err
+	}
+	employeeByPIN := make(map[string]uint, len(mappings))
+	for _, mapping := range mappings {
+		employeeByPIN[mapping.DeviceUserID] = mapping.EmployeeID
+	}
+
+	byEmployee := map[uint][]time.Time{}
+	for _, punch := range punches {
+		employeeID, ok := employeeByPIN[punch.DeviceUserID]
+		if !ok {
+			result.Unmatched = append(result.Unmatched, models.DeviceImportIssue{
+				Line:         punch.Line,
+				DeviceUserID: punch.DeviceUserID,
+				Date:         punch.Time.Format("2006-01-02 15:04:05"),
+				Reason:       "device user ID is not mapped to an employee",
+			})
+			continue
+		}
+		byEmployee[employeeID] = append(byEmployee[employeeID], punch.Time)
+	}
+
+	days, duplicates, err := s.groupPunches(byEmployee)
+	if err != nil {
+		return nil, err
+	}
+
+	today := localDate(time.Now(), zone)
+	for _, day := range days {
+		label := day.date.Format("2006-01-02")
+		events := make([]models.PunchEvent, 0, len(day.punches))
+		for _, punch := range day.punches {
+			events = append(events, models.PunchEvent{
+				EmployeeID:     day.employeeID,
+				WorkDate:       day.date,
+				Type:           models.PunchTypeAuto,
+				PunchedAt:      punch,
+				Source:         models.AttendanceSourceDevice,
+				DeviceID:       &device.ID,
+				WorkLocationID: device.WorkLocationID,
+				RecordedBy:     &importedBy,
+			})
+		}
+
+		attendance, outcome, err := s.attendanceService.recordDevicePunches(day.employeeID, day.date, events)
+		if err != nil {
+			return nil, fmt.Errorf("employee %d on %s: %w", day.employeeID, label, err)
+		}
+
+		switch outcome {
+		case punchMergeCreated:
+			record.Created++
+		case punchMergeUpdated:
+			record.Updated++
+		case punchMergeUnchanged:
+			record.Unchanged++
+		case punchMergeSkipped:
+			result.Warnings = append(result.Warnings, models.DeviceImportIssue{
+				EmployeeID: day.employeeID,
+				Date:       label,
+				Reason:     "kept the attendance entered manually",
+			})
+			continue
+		}
+
+		if attendance.ClockOut == nil && day.date.Before(today) {
+			result.Warnings = append(result.Warnings, models.DeviceImportIssue{
+				EmployeeID: day.employeeID,
+				Date:       label,
+				Reason:     "missing clock-out",
+			})
+		}
+	}
+
+	record.Punches = len(punches) - len(result.Unmatched)
+	record.Duplicates = duplicates
+	record.UnmatchedRows = len(result.Unmatched)
+	record.InvalidRows = len(result.Invalid)
+
+	// The log is recorded last, so an import interrupted halfway can simply be repeated
+	if err := s.deviceRepo.CreateImport(record); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// groupPunches drops repeated scans and assigns each punch to a workday using the
+// employee's schedule, returning the days in employee and date order
+func (s *DeviceService) groupPunches(byEmployee map[uint][]time.Time) ([]devicePunchDay, int, error) {
+	if len(byEmployee) == 0 {
+		return nil, 0, nil
+	}
+
+	employeeIDs := make([]uint, 0, len(byEmployee))
+	var first, last time.Time
+	for employeeID, times := range byEmployee {
+		employeeIDs = append(employeeIDs, employeeID)
+		sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+		if first.IsZero() || times[0].Before(first) {
+			first = times[0]
+		}
+		if last.IsZero() || times[len(times)-1].After(last) {
+			last = times[len(times)-1]
+		}
+	}
+	sort.Slice(employeeIDs, func(i, j int) bool { return employeeIDs[i] < employeeIDs[j] })
+
+	startDate, endDate := dateOnly(first).AddDate(0, 0, -1), dateOnly(last)
+	if err := validateDateRange(startDate, endDate); err != nil {
+		return nil, 0, fmt.Errorf("the log spans too long a period: %w", err)
+	}
+
+	employees, err := s.employeeRepo.FindByIDs(employeeIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	schedules, err := s.shiftService.Schedules(employees, startDate, endDate)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var days []devicePunchDay
+	duplicates := 0
+	for _, employeeID := range employeeIDs {
+		var previous time.Time
+		index := map[string]int{}
+
+		for _, punch := range byEmployee[employeeID] {
+			if !previous.IsZero() && punch.Sub(previous) <= duplicatePunchWindow {
+				duplicates++
+				continue
+			}
+			previous = punch
+
+			date := workdayOf(punch, schedules[employeeID])
+			key := date.Format("2006-01-02")
+			i, ok := index[key]
+			if !ok {
+				i = len(days)
+				index[key] = i
+				days = append(days, devicePunchDay{employeeID: employeeID, date: date})
+			}
+			days[i].punches = append(days[i].punches, punch)
+		}
+	}
+
+	sort.SliceStable(days, func(i, j int) bool {
+		if days[i].employeeID != days[j].employeeID {
+			return days[i].employeeID < days[j].employeeID
+		}
+		return days[i].date.Before(days[j].date)
+	})
+	return days, duplicates, nil
+}
+
+// workdayOf returns the day a punch belongs to: the previous day while the night
+// shift that started then is still running, otherwise the calendar day of the punch
+func workdayOf(punch time.Time, schedule map[string]models.DaySchedule) time.Time {
+	date := dateOnly(punch)
+	previous := date.AddDate(0, 0, -1)
+
+	day, ok := schedule[previous.Format("2006-01-02")]
+	if ok && day.Shift != nil && day.Shift.CrossesMidnight() {
+		_, end := day.Shift.Window(previous, punch.Location())
+		if punch.Before(end.Add(overnightPunchSlack)) {
+			return previous
+		}
+	}
+	return date
+}
+
+func (s *DeviceService) applyDeviceRequest(device *models.AttendanceDevice, req *models.AttendanceDeviceRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return errors.New("device name is required")
+	}
+	if existing, err := s.deviceRepo.FindByName(name); err == nil && existing.ID != device.ID {
+		return errors.New("a device with this name already exists")
+	}
+
+	if req.WorkLocationID != nil {
+		if _, err := s.locationRepo.FindByID(*req.WorkLocationID); err != nil {
+			return errors.New("work location not found")
+		}
+	}
+
+	device.Name = name
+	device.SerialNumber = strings.TrimSpace(req.SerialNumber)
+	device.WorkLocationID = req.WorkLocationID
+	if req.IsActive != nil {
+		device.IsActive = *req.IsActive
+	}
+	return nil
+}
@@ -6,21 +6,33 @@ import (
 	"hr-backend/internal/models"
 	"hr-backend/internal/repositories"
 	"hr-backend/internal/utils"
+	"log"
+	"strings"
 
 	"gorm.io/gorm"
 )
 
 type EmployeeService struct {
-	employeeRepo *repositories.EmployeeRepository
-	userRepo     *repositories.UserRepository
-	db           *gorm.DB
+	employeeRepo       *repositories.EmployeeRepository
+	userRepo           *repositories.UserRepository
+	attendanceRepo     *repositories.AttendanceRepository
+	leaveRepo          *repositories.LeaveRepository
+	customFieldService *CustomFieldService
+	onboardingService  *OnboardingService
+	timezoneService    *TimezoneService
+	db                 *gorm.DB
 }
 
-func NewEmployeeService(employeeRepo *repositories.EmployeeRepository, userRepo *repositories.UserRepository, db *gorm.DB) *EmployeeService {
+func NewEmployeeService(employeeRepo *repositories.EmployeeRepository, userRepo *repositories.UserRepository, attendanceRepo *repositories.AttendanceRepository, leaveRepo *repositories.LeaveRepository, customFieldService *CustomFieldService, onboardingService *OnboardingService, timezoneService *TimezoneService, db *gorm.DB) *EmployeeService {
 	return &EmployeeService{
-		employeeRepo: employeeRepo,
-		userRepo:     userRepo,
-		db:           db,
+		employeeRepo:       employeeRepo,
+		userRepo:           userRepo,
+		attendanceRepo:     attendanceRepo,
+		leaveRepo:          leaveRepo,
+		customFieldService: customFieldService,
+		onboardingService:  onboardingService,
+		timezoneService:    timezoneService,
+		db:                 db,
 	}
 }
 
@@ -37,6 +49,23 @@ func (s *EmployeeService) CreateEmployee(req *models.CreateEmployeeRequest) (*mo
 		return nil, errors.New("employee code already exists")
 	}
 
+	// Check if national ID is already registered
+	if req.NationalID != "" {
+		if _, err := s.employeeRepo.FindByNationalID(req.NationalID); err == nil {
+			return nil, errors.New("national ID already registered")
+		}
+	}
+
+	if req.Timezone != "" && !models.ValidTimezone(req.Timezone) {
+		return nil, errors.New("invalid time zone")
+	}
+
+	// Validate custom fields
+	customFields, err := s.customFieldService.ValidateValues(models.CustomFieldEntityEmployee, nil, req.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+
 	// Hash password
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
@@ -64,32 +93,67 @@ func (s *EmployeeService) CreateEmployee(req *models.CreateEmployeeRequest) (*mo
 			EmployeeCode:     req.EmployeeCode,
 			FirstName:        req.FirstName,
 			LastName:         req.LastName,
-			DateOfBirth:      req.DateOfBirth,
+			DateOfBirth:      models.NewEncryptedDate(req.DateOfBirth),
 			Gender:           req.Gender,
-			Phone:            req.Phone,
-			Address:          req.Address,
+			Phone:            models.EncryptedString(req.Phone),
+			Address:          models.EncryptedString(req.Address),
+			NationalID:       models.EncryptedString(req.NationalID),
 			DepartmentID:     req.DepartmentID,
+			WorkLocationID:   req.WorkLocationID,
+			Timezone:         req.Timezone,
 			Position:         req.Position,
 			HireDate:         req.HireDate,
 			EmploymentStatus: req.EmploymentStatus,
-			Salary:           req.Salary,
+			ProbationEndDate: req.ProbationEndDate,
+			ContractType:     req.ContractType,
+			ContractEndDate:  req.ContractEndDate,
+			Salary:           models.EncryptedFloat(req.Salary),
+			CustomFields:     customFields,
 		}
 
 		if employee.EmploymentStatus == "" {
-			employee.EmploymentStatus = "active"
+			employee.EmploymentStatus = models.EmploymentStatusActive
+			if employee.ProbationEndDate != nil {
+				employee.EmploymentStatus = models.EmploymentStatusProbation
+			}
+		}
+		if employee.ContractType == "" {
+			employee.ContractType = models.ContractTypePermanent
+		}
+		if err := validateEmploymentTerms(employee); err != nil {
+			return err
+		}
+
+		if err := tx.Create(employee).Error; err != nil {
+			return err
 		}
 
-		return tx.Create(employee).Error
+		return tx.Create(&models.EmploymentStatusHistory{
+			EmployeeID:    employee.ID,
+			ToStatus:      employee.EmploymentStatus,
+			EffectiveDate: dateOnly(employee.HireDate),
+			Reason:        "Hired",
+		}).Error
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.employeeRepo.RefreshSearchText(employee.ID); err != nil {
+		return nil, err
+	}
+
+	// The employee exists at this point, so a missing template only gets logged;
+	// HR can start onboarding manually once a template is in place
+	if _, err := s.onboardingService.StartOnboarding(employee.ID); err != nil {
+		log.Printf("Onboarding not started for employee %d: %v", employee.ID, err)
+	}
+
 	return s.employeeRepo.FindByID(employee.ID)
 }
 
-func (s *EmployeeService) GetEmployees(page, limit int, departmentID *uint, status, search string) ([]models.Employee, int64, error) {
+func (s *EmployeeService) GetEmployees(page, limit int, departmentID *uint, status, search string, customFilters map[string]string, role string, viewerID uint) ([]models.Employee, int64, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -97,11 +161,99 @@ func (s *EmployeeService) GetEmployees(page, limit int, departmentID *uint, stat
 		limit = 10
 	}
 
-	return s.employeeRepo.FindAll(page, limit, departmentID, status, search)
+	if err := s.customFieldService.ValidateFilterKeys(models.CustomFieldEntityEmployee, role, customFilters); err != nil {
+		return nil, 0, err
+	}
+
+	search = strings.TrimSpace(search)
+	employees, total, err := s.employeeRepo.FindAll(page, limit, departmentID, status, search, customFilters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.applyCustomFieldVisibility(employees, role); err != nil {
+		return nil, 0, err
+	}
+
+	for i := range employees {
+		maskEmployeePII(&employees[i], role, viewerID)
+	}
+
+	return employees, total, nil
 }
 
-func (s *EmployeeService) GetEmployeeByID(id uint) (*models.Employee, error) {
-	return s.employeeRepo.FindByID(id)
+func (s *EmployeeService) GetEmployeeByID(id uint, role string, viewerID uint) (*models.Employee, error) {
+	employee, err := s.employeeRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	employee.CustomFields, err = s.customFieldService.FilterValues(models.CustomFieldEntityEmployee, role, employee.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+
+	// Outstanding company assets are listed on the employee detail
+	if err := s.db.Where("assigned_employee_id = ? AND status = ?", id, models.AssetAssigned).
+		Order("asset_tag ASC").Find(&employee.Assets).Error; err != nil {
+		return nil, err
+	}
+
+	maskEmployeePII(employee, role, viewerID)
+
+	return employee, nil
+}
+
+// SuggestEmployees powers autocomplete pickers with ranked, typo-tolerant matches
+func (s *EmployeeService) SuggestEmployees(search string, limit int) ([]models.EmployeeSuggestion, error) {
+	search = strings.TrimSpace(search)
+	if len([]rune(search)) < 2 {
+		return []models.EmployeeSuggestion{}, nil
+	}
+	if limit < 1 || limit > 20 {
+		limit = 10
+	}
+
+	return s.employeeRepo.Suggest(search, limit)
+}
+
+// ExportEmployees renders every employee matching the filters as CSV,
+// including the custom fields visible to the given role
+func (s *EmployeeService) ExportEmployees(departmentID *uint, status, search string, customFilters map[string]string, role string) ([]byte, error) {
+	if err := s.customFieldService.ValidateFilterKeys(models.CustomFieldEntityEmployee, role, customFilters); err != nil {
+		return nil, err
+	}
+
+	var employees []models.Employee
+	for page := 1; ; page++ {
+		batch, total, err := s.employeeRepo.FindAll(page, 500, departmentID, status, search, customFilters)
+		if err != nil {
+			return nil, err
+		}
+		employees = append(employees, batch...)
+		if len(batch) == 0 || int64(len(employees)) >= total {
+			break
+		}
+	}
+
+	definitions, err := s.customFieldService.GetDefinitions(models.CustomFieldEntityEmployee, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.GenerateEmployeesCSV(employees, definitions)
+}
+
+func (s *EmployeeService) applyCustomFieldVisibility(employees []models.Employee, role string) error {
+	definitions, err := s.customFieldService.GetDefinitions(models.CustomFieldEntityEmployee, role)
+	if err != nil {
+		return err
+	}
+
+	for i := range employees {
+		employees[i].CustomFields = filterCustomValues(definitions, role, employees[i].CustomFields)
+	}
+	return nil
 }
 
 func (s *EmployeeService) UpdateEmployee(id uint, req *models.UpdateEmployeeRequest) (*models.Employee, error) {
@@ -117,28 +269,56 @@ func (s *EmployeeService) UpdateEmployee(id uint, req *models.UpdateEmployeeRequ
 		employee.LastName = req.LastName
 	}
 	if req.DateOfBirth != nil {
-		employee.DateOfBirth = req.DateOfBirth
+		employee.DateOfBirth = models.NewEncryptedDate(req.DateOfBirth)
 	}
 	if req.Gender != "" {
 		employee.Gender = req.Gender
 	}
 	if req.Phone != "" {
-		employee.Phone = req.Phone
+		employee.Phone = models.EncryptedString(req.Phone)
 	}
 	if req.Address != "" {
-		employee.Address = req.Address
+		employee.Address = models.EncryptedString(req.Address)
+	}
+	if req.NationalID != "" && req.NationalID != string(employee.NationalID) {
+		if _, err := s.employeeRepo.FindByNationalID(req.NationalID); err == nil {
+			return nil, errors.New("national ID already registered")
+		}
+		employee.NationalID = models.EncryptedString(req.NationalID)
 	}
 	if req.DepartmentID != nil {
 		employee.DepartmentID = req.DepartmentID
 	}
+	if req.WorkLocationID != nil {
+		employee.WorkLocationID = req.WorkLocationID
+	}
+	if req.Timezone != nil {
+		if *req.Timezone != "" && !models.ValidTimezone(*req.Timezone) {
+			return nil, errors.New("invalid time zone")
+		}
+		employee.Timezone = *req.Timezone
+	}
 	if req.Position != "" {
 		employee.Position = req.Position
 	}
-	if req.EmploymentStatus != "" {
-		employee.EmploymentStatus = req.EmploymentStatus
-	}
 	if req.Salary > 0 {
-		employee.Salary = req.Salary
+		employee.Salary = models.EncryptedFloat(req.Salary)
+	}
+	if req.BankName != "" {
+		employee.BankName = req.BankName
+	}
+	if req.BankAccountNumber != "" {
+		employee.BankAccountNumber = models.EncryptedString(req.BankAccountNumber)
+	}
+	if req.BankAccountName != "" {
+		employee.BankAccountName = req.BankAccountName
+	}
+	if req.CustomFields != nil {
+		customFields, err := s.customFieldService.ValidateValues(models.CustomFieldEntityEmployee, employee.CustomFields, req.CustomFields)
+		if err != nil {
+			return nil, err
+		}
+		employee.CustomFields = customFields
 	}
 
 	err = s.employeeRepo.Update(employee)
@@ -146,15 +326,48 @@ func (s *EmployeeService) UpdateEmployee(id uint, req *models.UpdateEmployeeRequ
 		return nil, err
 	}
 
+	if req.Dependents != nil {
+		if err := s.employeeRepo.ReplaceDependents(id, buildDependents(id, req.Dependents)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.employeeRepo.RefreshSearchText(id); err != nil {
+		return nil, err
+	}
+
 	return s.employeeRepo.FindByID(id)
 }
 
+// DeleteEmployee is meant for records created by mistake. Employees with payroll
+// or attendance history must go through the termination flow so it is retained.
 func (s *EmployeeService) DeleteEmployee(id uint) error {
 	employee, err := s.employeeRepo.FindByID(id)
 	if err != nil {
 		return err
 	}
 
+	var historyCount int64
+	if err := s.db.Model(&models.Payroll{}).Where("employee_id = ?", id).Count(&historyCount).Error; err != nil {
+		return err
+	}
+	if historyCount == 0 {
+		if err := s.db.Model(&models.Attendance{}).Where("employee_id = ?", id).Count(&historyCount).Error; err != nil {
+			return err
+		}
+	}
+	if historyCount > 0 {
+		return errors.New("employee has payroll or attendance history; terminate the employee instead")
+	}
+
+	var assetCount int64
+	if err := s.db.Model(&models.Asset{}).Where("assigned_employee_id = ?", id).Count(&assetCount).Error; err != nil {
+		return err
+	}
+	if assetCount > 0 {
+		return errors.New("employee still holds company assets; record their return first")
+	}
+
 	return s.db.Transaction(func(tx *gorm.DB) error {
 		// Delete employee
 		if err := tx.Delete(employee).Error; err != nil {
@@ -173,35 +386,43 @@ func (s *EmployeeService) DeleteEmployee(id uint) error {
 }
 
 func (s *EmployeeService) GetDashboardStats() (map[string]interface{}, error) {
-	totalEmployees, err := s.employeeRepo.CountByStatus("active")
+	totalEmployees, err := s.employeeRepo.CountByStatuses(models.WorkingEmploymentStatuses)
 	if err != nil {
 		return nil, err
 	}
 
+	// "Today" is each employee's own date, which differs between WIB, WITA and WIT offices around midnight
+	defaultZone := s.timezoneService.Default().String()
+
 	// Get today's attendance count
-	var presentToday int64
-	if err := s.db.Model(&models.Attendance{}).
-		Where("date = CURRENT_DATE AND status = ?", "present").
-		Count(&presentToday).Error; err != nil {
+	presentToday, err := s.attendanceRepo.CountTodayPresent(defaultZone)
+	if err != nil {
 		return nil, err
 	}
 
 	// Get today's leave count
-	var onLeaveToday int64
-	if err := s.db.Model(&models.Leave{}).
-		Where("status = ? AND CURRENT_DATE BETWEEN start_date AND end_date", "approved").
-		Count(&onLeaveToday).Error; err != nil {
+	onLeaveToday, err := s.leaveRepo.CountTodayOnLeave(defaultZone)
+	if err != nil {
 		return nil, err
 	}
 
-	stats := map[string]interface{}{
+	return dashboardStats(totalEmployees, presentToday, onLeaveToday), nil
+}
+
+// dashboardStats counts everyone neither present nor on leave as absent. Someone
+// who clocks in during approved leave is counted twice, so absence never goes below zero.
+func dashboardStats(totalEmployees, presentToday, onLeaveToday int64) map[string]interface{} {
+	absentToday := totalEmployees - presentToday - onLeaveToday
+	if absentToday < 0 {
+		absentToday = 0
+	}
+
+	return map[string]interface{}{
 		"total_employees": totalEmployees,
 		"present_today":   presentToday,
 		"on_leave_today":  onLeaveToday,
-		"absent_today":    totalEmployees - presentToday - onLeaveToday,
+		"absent_today":    absentToday,
 	}
-
-	return stats, nil
 }
 
 func (s *EmployeeService) GenerateEmployeeCode() (string, error) {
@@ -209,3 +430,48 @@ func (s *EmployeeService) GenerateEmployeeCode() (string, error) {
 	s.db.Model(&models.Employee{}).Count(&count)
 	return fmt.Sprintf("EMP%04d", count+1), nil
 }
+
+func buildDependents(employeeID uint, requests []models.DependentRequest) []models.EmployeeDependent {
+	dependents := make([]models.EmployeeDependent, 0, len(requests))
+	for _, req := range requests {
+		dependent := models.EmployeeDependent{
+			EmployeeID:   employeeID,
+			Name:         req.Name,
+			Relationship: req.Relationship,
+		}
+		if req.DateOfBirth != nil {
+			dob := dateOnly(req.DateOfBirth.Time)
+			dependent.DateOfBirth = &dob
+		}
+		dependents = append(dependents, dependent)
+	}
+	return dependents
+}
+
+// piiRoles may see every employee's personal data; other users only see their own
+var piiRoles = map[string]bool{"admin": true, "hr_manager": true}
+
+// maskEmployeePII hides personal data the viewer has no need for and lists the hidden fields
+func maskEmployeePII(employee *models.Employee, role string, viewerID uint) {
+	if piiRoles[role] || (employee.UserID != nil && *employee.UserID == viewerID) {
+		return
+	}
+
+	employee.Phone = models.EncryptedString(maskTail(string(employee.Phone), 3))
+	employee.Address = ""
+	employee.NationalID = models.EncryptedString(maskTail(string(employee.NationalID), 4))
+	employee.BankAccountNumber = models.EncryptedString(maskTail(string(employee.BankAccountNumber), 4))
+	employee.DateOfBirth = nil
+	employee.Salary = 0
+	employee.Dependents = nil
+	employee.MaskedFields = []string{"phone", "address", "national_id", "bank_account_number", "date_of_birth", "salary", "dependents"}
+}
+
+// maskTail replaces all but the last n characters with asterisks
+func maskTail(value string, n int) string {
+	runes := []rune(value)
+	if len(runes) <= n {
+		return strings.Repeat("*", len(runes))
+	}
+	return strings.Repeat("*", len(runes)-n) + string(runes[len(runes)-n:])
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"hr-backend/internal/models"
+	"testing"
+)
+
+func TestDashboardStats(t *testing.T) {
+	tests := []struct {
+		name       string
+		total      int64
+		present    int64
+		onLeave    int64
+		wantAbsent int64
+	}{
+		{name: "no employees", wantAbsent: 0},
+		{name: "everyone present", total: 10, present: 10, wantAbsent: 0},
+		{name: "present, on leave and absent", total: 10, present: 6, onLeave: 1, wantAbsent: 3},
+		{name: "nobody came in", total: 10, wantAbsent: 10},
+		{name: "clocked in during leave", total: 10, present: 10, onLeave: 1, wantAbsent: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := dashboardStats(tt.total, tt.present, tt.onLeave)
+			if got := stats["absent_today"]; got != tt.wantAbsent {
+				t.Errorf("absent_today = %v, want %d", got, tt.wantAbsent)
+			}
+			if stats["total_employees"] != tt.total || stats["present_today"] != tt.present || stats["on_leave_today"] != tt.onLeave {
+				t.Errorf("dashboardStats() = %v, want the counts passed through", stats)
+			}
+		})
+	}
+}
+
+// The dashboard counts records with these statuses as present
+func TestAttendedStatuses(t *testing.T) {
+	attended := map[string]bool{}
+	for _, status := range models.AttendedStatuses {
+		attended[status] = true
+	}
+
+	tests := []struct {
+		status string
+		want   bool
+	}{
+		{status: models.AttendanceDayPresent, want: true},
+		{status: "late", want: true},
+		{status: models.AttendanceDayRemote, want: true},
+		{status: models.AttendanceDayAbsent, want: false},
+	}
+
+	for _, tt := range tests {
+		if attended[tt.status] != tt.want {
+			t.Errorf("status %q attended = %v, want %v", tt.status, attended[tt.status], tt.want)
+		}
+	}
+}
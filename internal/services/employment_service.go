@@ -0,0 +1,311 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hr-backend/internal/config"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// maxProbationMonths and maxContractYears are the statutory limits (PP 35/2021)
+	maxProbationMonths = 3
+	maxContractYears   = 5
+)
+
+type EmploymentService struct {
+	employmentRepo      *repositories.EmploymentRepository
+	employeeRepo        *repositories.EmployeeRepository
+	notificationService *NotificationService
+	cfg                 *config.Config
+	db                  *gorm.DB
+}
+
+func NewEmploymentService(employmentRepo *repositories.EmploymentRepository, employeeRepo *repositories.EmployeeRepository, notificationService *NotificationService, cfg *config.Config, db *gorm.DB) *EmploymentService {
+	return &EmploymentService{
+		employmentRepo:      employmentRepo,
+		employeeRepo:        employeeRepo,
+		notificationService: notificationService,
+		cfg:                 cfg,
+		db:                  db,
+	}
+}
+
+// ChangeStatus confirms, suspends or reinstates an employee. Resignations and
+// terminations go through the termination workflow so the settlement is calculated.
+func (s *EmploymentService) ChangeStatus(employeeID, changedBy uint, req *models.ChangeEmploymentStatusRequest) (*models.Employee, error) {
+	employee, err := s.employeeRepo.FindByID(employeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+
+	effectiveDate := dateOnly(time.Now())
+	if req.EffectiveDate != nil {
+		effectiveDate = dateOnly(req.EffectiveDate.Time)
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		return transitionEmploymentStatus(tx, employee, req.Status, effectiveDate, req.Reason, &changedBy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.employeeRepo.FindByID(employeeID)
+}
+
+func (s *EmploymentService) GetStatusHistory(employeeID uint) ([]models.EmploymentStatusHistory, error) {
+	if _, err := s.employeeRepo.FindByID(employeeID); err != nil {
+		return nil, errors.New("employee not found")
+	}
+	return s.employmentRepo.FindStatusHistory(employeeID)
+}
+
+func (s *EmploymentService) ExtendProbation(employeeID uint, req *models.ExtendProbationRequest) (*models.Employee, error) {
+	employee, err := s.employeeRepo.FindByID(employeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+
+	if employee.EmploymentStatus != models.EmploymentStatusProbation {
+		return nil, errors.New("employee is not on probation")
+	}
+
+	endDate := dateOnly(req.ProbationEndDate.Time)
+	if employee.ProbationEndDate != nil && !endDate.After(dateOnly(*employee.ProbationEndDate)) {
+		return nil, errors.New("new probation end date must be after the current one")
+	}
+
+	employee.ProbationEndDate = &endDate
+	if err := validateEmploymentTerms(employee); err != nil {
+		return nil, err
+	}
+
+	err = s.db.Model(&models.Employee{}).Where("id = ?", employeeID).
+		Updates(map[string]interface{}{"probation_end_date": endDate, "probation_reminded_at": nil}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return s.employeeRepo.FindByID(employeeID)
+}
+
+// RenewContract extends a fixed-term (PKWT) contract or converts it to a permanent one,
+// keeping the previous terms in the renewal history
+func (s *EmploymentService) RenewContract(employeeID, renewedBy uint, req *models.RenewContractRequest) (*models.Employee, error) {
+	employee, err := s.employeeRepo.FindByID(employeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+
+	if !isWorkingStatus(employee.EmploymentStatus) {
+		return nil, errors.New("contracts can only be renewed for current employees")
+	}
+
+	if employee.ContractType != models.ContractTypePKWT {
+		return nil, errors.New("only fixed-term contracts can be renewed")
+	}
+
+	renewal := &models.ContractRenewal{
+		EmployeeID:           employee.ID,
+		PreviousContractType: employee.ContractType,
+		PreviousEndDate:      employee.ContractEndDate,
+		ContractType:         req.ContractType,
+		Notes:                req.Notes,
+		RenewedBy:            renewedBy,
+	}
+
+	employee.ContractType = req.ContractType
+	employee.ContractEndDate = nil
+	if req.ContractEndDate != nil {
+		endDate := dateOnly(req.ContractEndDate.Time)
+		if renewal.PreviousEndDate != nil && !endDate.After(dateOnly(*renewal.PreviousEndDate)) {
+			return nil, errors.New("new contract end date must be after the current one")
+		}
+		employee.ContractEndDate = &endDate
+		renewal.NewEndDate = &endDate
+	}
+
+	if err := validateEmploymentTerms(employee); err != nil {
+		return nil, err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(renewal).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Employee{}).Where("id = ?", employee.ID).Updates(map[string]interface{}{
+			"contract_type":        employee.ContractType,
+			"contract_end_date":    employee.ContractEndDate,
+			"contract_reminded_at": nil,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.employeeRepo.FindByID(employeeID)
+}
+
+func (s *EmploymentService) GetContractRenewals(employeeID uint) ([]models.ContractRenewal, error) {
+	if _, err := s.employeeRepo.FindByID(employeeID); err != nil {
+		return nil, errors.New("employee not found")
+	}
+	return s.employmentRepo.FindContractRenewals(employeeID)
+}
+
+// GetExpiring lists probations and fixed-term contracts ending within the given number of days,
+// including ones already past their end date that nobody acted on
+func (s *EmploymentService) GetExpiring(days int) (*models.ExpiringEmployment, error) {
+	if days < 1 || days > 365 {
+		days = 30
+	}
+	before := dateOnly(time.Now()).AddDate(0, 0, days)
+
+	probation, err := s.employmentRepo.FindProbationEnding(before)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, err := s.employmentRepo.FindContractEnding(before)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ExpiringEmployment{ProbationEnding: probation, ContractEnding: contracts}, nil
+}
+
+// SendReminders notifies HR and the department manager once when a probation
+// or fixed-term contract is about to end
+func (s *EmploymentService) SendReminders() error {
+	today := dateOnly(time.Now())
+
+	probation, err := s.employmentRepo.FindProbationEnding(today.AddDate(0, 0, s.cfg.Employment.ProbationReminderDays))
+	if err != nil {
+		return err
+	}
+	for i := range probation {
+		employee := &probation[i]
+		if employee.ProbationRemindedAt != nil {
+			continue
+		}
+		s.notifyExpiry(employee, "Probation ending",
+			fmt.Sprintf("Probation of %s %s ends on %s", employee.FirstName, employee.LastName, employee.ProbationEndDate.Format("2006-01-02")))
+		if err := s.markReminded(employee.ID, "probation_reminded_at"); err != nil {
+			return err
+		}
+	}
+
+	contracts, err := s.employmentRepo.FindContractEnding(today.AddDate(0, 0, s.cfg.Employment.ContractReminderDays))
+	if err != nil {
+		return err
+	}
+	for i := range contracts {
+		employee := &contracts[i]
+		if employee.ContractRemindedAt != nil {
+			continue
+		}
+		s.notifyExpiry(employee, "Contract ending",
+			fmt.Sprintf("Fixed-term contract of %s %s ends on %s", employee.FirstName, employee.LastName, employee.ContractEndDate.Format("2006-01-02")))
+		if err := s.markReminded(employee.ID, "contract_reminded_at"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *EmploymentService) markReminded(employeeID uint, column string) error {
+	return s.db.Model(&models.Employee{}).Where("id = ?", employeeID).Update(column, time.Now()).Error
+}
+
+// notifyExpiry is best-effort: a failed notification must not stop the reminder run
+func (s *EmploymentService) notifyExpiry(employee *models.Employee, title, message string) {
+	link := fmt.Sprintf("/karyawan/%d", employee.ID)
+	s.notificationService.NotifyRoles([]string{"hr_manager"}, "employment", title, message, link)
+
+	if employee.Department != nil && employee.Department.ManagerID != nil {
+		manager, err := s.employeeRepo.FindByID(*employee.Department.ManagerID)
+		if err == nil && manager.UserID != nil {
+			s.notificationService.Notify(*manager.UserID, "employment", title, message, link)
+		}
+	}
+}
+
+// transitionEmploymentStatus moves the employee to a new status if the lifecycle
+// allows it and records the change in the status history
+func transitionEmploymentStatus(tx *gorm.DB, employee *models.Employee, status string, effectiveDate time.Time, reason string, changedBy *uint) error {
+	if !models.CanTransitionEmploymentStatus(employee.EmploymentStatus, status) {
+		return fmt.Errorf("cannot change employment status from %s to %s", employee.EmploymentStatus, status)
+	}
+
+	if err := tx.Model(&models.Employee{}).Where("id = ?", employee.ID).
+		Update("employment_status", status).Error; err != nil {
+		return err
+	}
+
+	history := &models.EmploymentStatusHistory{
+		EmployeeID:    employee.ID,
+		FromStatus:    employee.EmploymentStatus,
+		ToStatus:      status,
+		EffectiveDate: effectiveDate,
+		Reason:        reason,
+		ChangedBy:     changedBy,
+	}
+	if err := tx.Create(history).Error; err != nil {
+		return err
+	}
+
+	employee.EmploymentStatus = status
+	return nil
+}
+
+// validateEmploymentTerms checks probation and contract dates against the hire date
+// and the statutory limits
+func validateEmploymentTerms(employee *models.Employee) error {
+	hireDate := dateOnly(employee.HireDate)
+
+	if employee.ContractType == models.ContractTypePKWT {
+		if employee.ContractEndDate == nil {
+			return errors.New("contract end date is required for fixed-term contracts")
+		}
+		if !employee.ContractEndDate.After(hireDate) {
+			return errors.New("contract end date must be after hire date")
+		}
+		if employee.ContractEndDate.After(hireDate.AddDate(maxContractYears, 0, 0)) {
+			return fmt.Errorf("fixed-term contracts cannot exceed %d years in total", maxContractYears)
+		}
+		if employee.EmploymentStatus == models.EmploymentStatusProbation {
+			return errors.New("fixed-term contracts cannot have a probation period")
+		}
+	} else if employee.ContractEndDate != nil {
+		return errors.New("permanent contracts have no end date")
+	}
+
+	if employee.EmploymentStatus == models.EmploymentStatusProbation {
+		if employee.ProbationEndDate == nil {
+			return errors.New("probation end date is required for employees on probation")
+		}
+		if !employee.ProbationEndDate.After(hireDate) {
+			return errors.New("probation end date must be after hire date")
+		}
+		if employee.ProbationEndDate.After(hireDate.AddDate(0, maxProbationMonths, 0)) {
+			return fmt.Errorf("probation cannot exceed %d months", maxProbationMonths)
+		}
+	}
+
+	return nil
+}
+
+func isWorkingStatus(status string) bool {
+	for _, working := range models.WorkingEmploymentStatuses {
+		if working == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,411 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hr-backend/internal/config"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"hr-backend/internal/utils"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// maxHolidaySpanDays caps multi-day iCalendar events, which are otherwise
+// expanded into one holiday per day
+const maxHolidaySpanDays = 31
+
+type HolidayService struct {
+	holidayRepo  *repositories.HolidayRepository
+	locationRepo *repositories.WorkLocationRepository
+	employeeRepo *repositories.EmployeeRepository
+	cfg          *config.Config
+}
+
+func NewHolidayService(holidayRepo *repositories.HolidayRepository, locationRepo *repositories.WorkLocationRepository, employeeRepo *repositories.EmployeeRepository, cfg *config.Config) *HolidayService {
+	return &HolidayService{
+		holidayRepo:  holidayRepo,
+		locationRepo: locationRepo,
+		employeeRepo: employeeRepo,
+		cfg:          cfg,
+	}
+}
+
+func (s *HolidayService) GetCalendars() ([]models.HolidayCalendar, error) {
+	return s.holidayRepo.FindAllCalendars()
+}
+
+func (s *HolidayService) GetCalendarByID(id uint) (*models.HolidayCalendar, error) {
+	calendar, err := s.holidayRepo.FindCalendarByID(id)
+	if err != nil {
+		return nil, errors.New("holiday calendar not found")
+	}
+	return calendar, nil
+}
+
+func (s *HolidayService) CreateCalendar(req *models.HolidayCalendarRequest) (*models.HolidayCalendar, error) {
+	calendar := &models.HolidayCalendar{}
+	if err := s.applyCalendarRequest(calendar, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.holidayRepo.SaveCalendar(calendar); err != nil {
+		return nil, err
+	}
+	return calendar, nil
+}
+
+func (s *HolidayService) UpdateCalendar(id uint, req *models.HolidayCalendarRequest) (*models.HolidayCalendar, error) {
+	calendar, err := s.GetCalendarByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyCalendarRequest(calendar, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.holidayRepo.SaveCalendar(calendar); err != nil {
+		return nil, err
+	}
+	return calendar, nil
+}
+
+// DeleteCalendar removes a calendar and its holidays. Locations using it fall back to the default calendar.
+func (s *HolidayService) DeleteCalendar(id uint) error {
+	if _, err := s.GetCalendarByID(id); err != nil {
+		return err
+	}
+	return s.holidayRepo.DeleteCalendar(id)
+}
+
+func (s *HolidayService) GetHolidays(calendarID uint, year *int) ([]models.Holiday, error) {
+	if _, err := s.GetCalendarByID(calendarID); err != nil {
+		return nil, err
+	}
+	return s.holidayRepo.FindHolidays(calendarID, year)
+}
+
+func (s *HolidayService) CreateHoliday(calendarID uint, req *models.HolidayRequest) (*models.Holiday, error) {
+	if _, err := s.GetCalendarByID(calendarID); err != nil {
+		return nil, err
+	}
+
+	holiday := &models.Holiday{CalendarID: calendarID}
+	applyHolidayRequest(holiday, req)
+
+	if err := s.holidayRepo.SaveHoliday(holiday); err != nil {
+		return nil, err
+	}
+	return holiday, nil
+}
+
+func (s *HolidayService) UpdateHoliday(calendarID, id uint, req *models.HolidayRequest) (*models.Holiday, error) {
+	holiday, err := s.holidayRepo.FindHolidayByID(id)
+	if err != nil || holiday.CalendarID != calendarID {
+		return nil, errors.New("holiday not found")
+	}
+
+	applyHolidayRequest(holiday, req)
+
+	if err := s.holidayRepo.SaveHoliday(holiday); err != nil {
+		return nil, err
+	}
+	return holiday, nil
+}
+
+func (s *HolidayService) DeleteHoliday(calendarID, id uint) error {
+	holiday, err := s.holidayRepo.FindHolidayByID(id)
+	if err != nil || holiday.CalendarID != calendarID {
+		return errors.New("holiday not found")
+	}
+	return s.holidayRepo.DeleteHoliday(id)
+}
+
+// ImportICalendar adds the events of an iCalendar file to the calendar. Events are
+// matched on their UID, so importing an updated file again updates the holidays
+// instead of duplicating them. Summaries mentioning cuti bersama are imported as
+// collective leave; other events get holidayType.
+func (s *HolidayService) ImportICalendar(calendarID uint, header *multipart.FileHeader, holidayType string) (*models.HolidayImportResult, error) {
+	if _, err := s.GetCalendarByID(calendarID); err != nil {
+		return nil, err
+	}
+
+	maxSize := int64(s.cfg.Storage.MaxUploadSizeMB) << 20
+	if header.Size > maxSize {
+		return nil, fmt.Errorf("file is larger than %d MB", s.cfg.Storage.MaxUploadSizeMB)
+	}
+
+	src, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	events, err := utils.ParseICalendar(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid iCalendar file: %w", err)
+	}
+	if len(events) == 0 {
+		return nil, errors.New("the file contains no events")
+	}
+
+	if holidayType == "" {
+		holidayType = models.HolidayNational
+	}
+
+	result := &models.HolidayImportResult{Skipped: []string{}}
+	for _, event := range events {
+		label := fmt.Sprintf("%s (%s)", event.Summary, event.StartDate.Format("2006-01-02"))
+
+		switch {
+		case event.Cancelled:
+			result.Skipped = append(result.Skipped, label+": cancelled")
+			continue
+		case strings.TrimSpace(event.Summary) == "":
+			result.Skipped = append(result.Skipped, label+": no summary")
+			continue
+		case event.EndDate.Sub(event.StartDate).Hours()/24 >= maxHolidaySpanDays:
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s: longer than %d days", label, maxHolidaySpanDays))
+			continue
+		case event.Yearly && !event.EndDate.Equal(event.StartDate):
+			result.Skipped = append(result.Skipped, label+": recurring events must last one day")
+			continue
+		}
+
+		eventType := holidayType
+		if strings.Contains(strings.ToLower(event.Summary), "cuti bersama") {
+			eventType = models.HolidayCollectiveLeave
+		}
+
+		uid := event.UID
+		if uid == "" {
+			uid = event.StartDate.Format("20060102") + "-" + strings.ToLower(strings.TrimSpace(event.Summary))
+		}
+		multiDay := event.EndDate.After(event.StartDate)
+
+		for date := event.StartDate; !date.After(event.EndDate); date = date.AddDate(0, 0, 1) {
+			dayUID := uid
+			if multiDay {
+				dayUID = uid + "#" + date.Format("2006-01-02")
+			}
+
+			holiday, err := s.holidayRepo.FindHolidayByUID(calendarID, dayUID)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				holiday = &models.Holiday{CalendarID: calendarID, UID: dayUID}
+			} else if err != nil {
+				return nil, err
+			} else if holiday.Name == strings.TrimSpace(event.Summary) && holiday.Date.Equal(date) &&
+				holiday.Type == eventType && holiday.Recurring == event.Yearly {
+				continue
+			}
+
+			isNew := holiday.ID == 0
+			holiday.Date = date
+			holiday.Name = strings.TrimSpace(event.Summary)
+			holiday.Type = eventType
+			holiday.Recurring = event.Yearly
+
+			if err := s.holidayRepo.SaveHoliday(holiday); err != nil {
+				return nil, err
+			}
+			if isNew {
+				result.Created++
+			} else {
+				result.Updated++
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// GetHolidaysInRange lists the holidays observed between two dates, each dated on
+// its occurrence. The calendar is taken from the employee's work location when
+// employeeID is given, otherwise calendarID or the default calendar is used.
+func (s *HolidayService) GetHolidaysInRange(calendarID, employeeID *uint, startDate, endDate time.Time) ([]models.Holiday, error) {
+	startDate, endDate = dateOnly(startDate), dateOnly(endDate)
+	if err := validateDateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	if employeeID != nil {
+		employee, err := s.employeeRepo.FindByID(*employeeID)
+		if err != nil {
+			return nil, errors.New("employee not found")
+		}
+		if calendarID, err = s.calendarFor(employee.WorkLocationID, nil); err != nil {
+			return nil, err
+		}
+	} else if calendarID == nil {
+		var err error
+		if calendarID, err = s.defaultCalendarID(); err != nil {
+			return nil, err
+		}
+	}
+
+	holidays := []models.Holiday{}
+	if calendarID == nil {
+		return holidays, nil
+	}
+
+	byDate, err := s.holidaysByCalendar([]uint{*calendarID}, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		if holiday := byDate[*calendarID][date.Format("2006-01-02")]; holiday != nil {
+			occurrence := *holiday
+			occurrence.Date = date
+			holidays = append(holidays, occurrence)
+		}
+	}
+	return holidays, nil
+}
+
+// HolidaysFor returns the holidays an employee observes within the range, keyed by date
+func (s *HolidayService) HolidaysFor(employeeID uint, startDate, endDate time.Time) (map[string]*models.Holiday, error) {
+	employee, err := s.employeeRepo.FindByID(employeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+
+	holidays, err := s.HolidaysForEmployees([]models.Employee{*employee}, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return holidays[employeeID], nil
+}
+
+// HolidaysForEmployees returns the holidays each employee observes within the range,
+// keyed by employee ID and date
+func (s *HolidayService) HolidaysForEmployees(employees []models.Employee, startDate, endDate time.Time) (map[uint]map[string]*models.Holiday, error) {
+	startDate, endDate = dateOnly(startDate), dateOnly(endDate)
+
+	locationCalendars := map[uint]*uint{}
+	employeeCalendars := map[uint]*uint{}
+	var calendarIDs []uint
+	seen := map[uint]bool{}
+
+	for _, employee := range employees {
+		calendarID, err := s.calendarFor(employee.WorkLocationID, locationCalendars)
+		if err != nil {
+			return nil, err
+		}
+		employeeCalendars[employee.ID] = calendarID
+		if calendarID != nil && !seen[*calendarID] {
+			seen[*calendarID] = true
+			calendarIDs = append(calendarIDs, *calendarID)
+		}
+	}
+
+	byCalendar, err := s.holidaysByCalendar(calendarIDs, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	holidays := make(map[uint]map[string]*models.Holiday, len(employees))
+	for employeeID, calendarID := range employeeCalendars {
+		if calendarID != nil {
+			holidays[employeeID] = byCalendar[*calendarID]
+		}
+	}
+	return holidays, nil
+}
+
+// holidaysByCalendar expands recurring holidays into their occurrences within the range
+func (s *HolidayService) holidaysByCalendar(calendarIDs []uint, startDate, endDate time.Time) (map[uint]map[string]*models.Holiday, error) {
+	holidays, err := s.holidayRepo.FindHolidaysInRange(calendarIDs, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	byCalendar := map[uint]map[string]*models.Holiday{}
+	for i := range holidays {
+		holiday := &holidays[i]
+		if byCalendar[holiday.CalendarID] == nil {
+			byCalendar[holiday.CalendarID] = map[string]*models.Holiday{}
+		}
+		for year := startDate.Year(); year <= endDate.Year(); year++ {
+			date, ok := holiday.On(year)
+			if !ok || date.Before(startDate) || date.After(endDate) {
+				continue
+			}
+			key := date.Format("2006-01-02")
+			if byCalendar[holiday.CalendarID][key] == nil {
+				byCalendar[holiday.CalendarID][key] = holiday
+			}
+		}
+	}
+	return byCalendar, nil
+}
+
+// calendarFor resolves the calendar of a work location, falling back to the default
+// calendar. Results are remembered in cache when one is given.
+func (s *HolidayService) calendarFor(locationID *uint, cache map[uint]*uint) (*uint, error) {
+	var key uint // zero stands for employees without a location
+	if locationID != nil {
+		key = *locationID
+	}
+	if calendarID, ok := cache[key]; ok {
+		return calendarID, nil
+	}
+
+	calendarID, err := s.locationCalendar(locationID)
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		cache[key] = calendarID
+	}
+	return calendarID, nil
+}
+
+func (s *HolidayService) locationCalendar(locationID *uint) (*uint, error) {
+	if locationID != nil {
+		location, err := s.locationRepo.FindByID(*locationID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if err == nil && location.HolidayCalendarID != nil {
+			return location.HolidayCalendarID, nil
+		}
+	}
+	return s.defaultCalendarID()
+}
+
+func (s *HolidayService) defaultCalendarID() (*uint, error) {
+	calendar, err := s.holidayRepo.FindDefaultCalendar()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &calendar.ID, nil
+}
+
+func (s *HolidayService) applyCalendarRequest(calendar *models.HolidayCalendar, req *models.HolidayCalendarRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if existing, err := s.holidayRepo.FindCalendarByName(name); err == nil && existing.ID != calendar.ID {
+		return errors.New("a holiday calendar with this name already exists")
+	}
+
+	calendar.Name = name
+	calendar.Description = req.Description
+	calendar.IsDefault = req.IsDefault
+	return nil
+}
+
+func applyHolidayRequest(holiday *models.Holiday, req *models.HolidayRequest) {
+	holiday.Date = dateOnly(req.Date.Time)
+	holiday.Name = strings.TrimSpace(req.Name)
+	holiday.Type = req.Type
+	if holiday.Type == "" {
+		holiday.Type = models.HolidayNational
+	}
+	holiday.Recurring = req.Recurring
+}
@@ -0,0 +1,329 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hr-backend/internal/config"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"hr-backend/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultKioskQRInterval applies when the configured interval is not positive
+const defaultKioskQRInterval = 30 * time.Second
+
+// KioskService manages kiosks and records the punches made at them. A kiosk
+// displays a code made of its ID and the current time slot, signed with HMAC;
+// a code is accepted during its own slot and the one after, so a scan that
+// straddles a rotation still succeeds.
+type KioskService struct {
+	kioskRepo         *repositories.KioskRepository
+	employeeRepo      *repositories.EmployeeRepository
+	locationRepo      *repositories.WorkLocationRepository
+	attendanceService *AttendanceService
+	cfg               *config.Config
+}
+
+func NewKioskService(kioskRepo *repositories.KioskRepository, employeeRepo *repositories.EmployeeRepository, locationRepo *repositories.WorkLocationRepository, attendanceService *AttendanceService, cfg *config.Config) *KioskService {
+	return &KioskService{
+		kioskRepo:         kioskRepo,
+		employeeRepo:      employeeRepo,
+		locationRepo:      locationRepo,
+		attendanceService: attendanceService,
+		cfg:               cfg,
+	}
+}
+
+func (s *KioskService) GetKiosks() ([]models.Kiosk, error) {
+	return s.kioskRepo.FindAll()
+}
+
+func (s *KioskService) GetKioskByID(id uint) (*models.Kiosk, error) {
+	kiosk, err := s.kioskRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("kiosk not found")
+	}
+	return kiosk, nil
+}
+
+// CreateKiosk registers a kiosk and issues its credential
+func (s *KioskService) CreateKiosk(req *models.KioskRequest) (*models.KioskCredential, error) {
+	kiosk := &models.Kiosk{IsActive: true}
+	if err := s.applyKioskRequest(kiosk, req); err != nil {
+		return nil, err
+	}
+	return s.issueCredential(kiosk)
+}
+
+func (s *KioskService) UpdateKiosk(id uint, req *models.KioskRequest) (*models.Kiosk, error) {
+	kiosk, err := s.GetKioskByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyKioskRequest(kiosk, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.kioskRepo.Save(kiosk); err != nil {
+		return nil, err
+	}
+	return s.kioskRepo.FindByID(kiosk.ID)
+}
+
+func (s *KioskService) DeleteKiosk(id uint) error {
+	if _, err := s.GetKioskByID(id); err != nil {
+		return err
+	}
+	return s.kioskRepo.Delete(id)
+}
+
+// RotateCredential issues a new credential, signing the kiosk out with the old one
+func (s *KioskService) RotateCredential(id uint) (*models.KioskCredential, error) {
+	kiosk, err := s.GetKioskByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.issueCredential(kiosk)
+}
+
+// Authenticate returns the ID of the active kiosk the credential belongs to
+func (s *KioskService) Authenticate(token string) (uint, error) {
+	kiosk, err := s.kioskRepo.FindByTokenHash(hashKioskToken(token))
+	if err != nil || !kiosk.IsActive {
+		return 0, errors.New("invalid kiosk credential")
+	}
+
+	if err := s.kioskRepo.TouchLastSeen(kiosk.ID, time.Now()); err != nil {
+		return 0, err
+	}
+	return kiosk.ID, nil
+}
+
+// GetQRCode returns the code the kiosk displays in the current time slot
+func (s *KioskService) GetQRCode(kioskID uint) (*models.KioskQRCode, error) {
+	interval := s.qrInterval()
+	slot := time.Now().Unix() / int64(interval.Seconds())
+
+	return &models.KioskQRCode{
+		Code:      s.signQRCode(kioskID, slot),
+		ExpiresAt: time.Unix((slot+1)*int64(interval.Seconds()), 0),
+	}, nil
+}
+
+// Scan records a punch for the employee who scanned a kiosk's code with their phone
+func (s *KioskService) Scan(userID uint, req *models.KioskScanRequest) (*models.KioskPunchResult, error) {
+	employee, err := s.employeeRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("no employee profile is linked to this account")
+	}
+
+	kioskID, err := s.verifyQRCode(req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	kiosk, err := s.kioskRepo.FindByID(kioskID)
+	if err != nil || !kiosk.IsActive {
+		return nil, errors.New("kiosk is not active")
+	}
+
+	return s.punch(employee, kiosk, true)
+}
+
+// SetPin sets the PIN the employee enters at kiosks, lifting any lock
+func (s *KioskService) SetPin(userID uint, req *models.SetKioskPinRequest) error {
+	employee, err := s.employeeRepo.FindByUserID(userID)
+	if err != nil {
+		return errors.New("no employee profile is linked to this account")
+	}
+
+	hash, err := utils.HashPassword(req.Pin)
+	if err != nil {
+		return err
+	}
+
+	pin, err := s.kioskRepo.FindPin(employee.ID)
+	if err != nil {
+		pin = &models.KioskPin{EmployeeID: employee.ID}
+	}
+	pin.PinHash = hash
+	pin.FailedAttempts = 0
+	pin.LockedUntil = nil
+	return s.kioskRepo.SavePin(pin)
+}
+
+// PinPunch records a punch for the employee who entered their code and PIN at the
+// kiosk. Wrong PINs count towards a lock; the error does not tell whether the
+// employee code or the PIN was wrong.
+func (s *KioskService) PinPunch(kioskID uint, req *models.KioskPinPunchRequest) (*models.KioskPunchResult, error) {
+	kiosk, err := s.kioskRepo.FindByID(kioskID)
+	if err != nil || !kiosk.IsActive {
+		return nil, errors.New("kiosk is not active")
+	}
+
+	invalid := errors.New("invalid employee code or PIN")
+	employee, err := s.employeeRepo.FindByEmployeeCode(strings.TrimSpace(req.EmployeeCode))
+	if err != nil {
+		return nil, invalid
+	}
+
+	pin, err := s.kioskRepo.FindPin(employee.ID)
+	if err != nil {
+		return nil, invalid
+	}
+
+	now := time.Now()
+	if pin.LockedUntil != nil && now.Before(*pin.LockedUntil) {
+		return nil, errors.New("too many wrong PINs; try again later")
+	}
+
+	if !utils.CheckPasswordHash(req.Pin, pin.PinHash) {
+		pin.FailedAttempts++
+		if s.cfg.Kiosk.PinMaxAttempts > 0 && pin.FailedAttempts >= s.cfg.Kiosk.PinMaxAttempts {
+			lockedUntil := now.Add(s.cfg.Kiosk.PinLockout)
+			pin.LockedUntil = &lockedUntil
+			pin.FailedAttempts = 0
+		}
+		if err := s.kioskRepo.SavePin(pin); err != nil {
+			return nil, err
+		}
+		return nil, invalid
+	}
+
+	if pin.FailedAttempts > 0 || pin.LockedUntil != nil {
+		pin.FailedAttempts = 0
+		pin.LockedUntil = nil
+		if err := s.kioskRepo.SavePin(pin); err != nil {
+			return nil, err
+		}
+	}
+
+	// The kiosk screen is shared, so it is only told who punched and which way
+	return s.punch(employee, kiosk, false)
+}
+
+// punch records the punch of a current employee; departed staff may still have a PIN or account
+func (s *KioskService) punch(employee *models.Employee, kiosk *models.Kiosk, withAttendance bool) (*models.KioskPunchResult, error) {
+	if !isWorkingStatus(employee.EmploymentStatus) {
+		return nil, errors.New("employee is no longer employed")
+	}
+
+	attendance, punchType, err := s.attendanceService.KioskPunch(employee, kiosk)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.KioskPunchResult{
+		EmployeeID:   employee.ID,
+		EmployeeName: employee.FirstName + " " + employee.LastName,
+		Type:         punchType,
+		PunchedAt:    time.Now(),
+	}
+	if withAttendance {
+		result.Attendance = attendance
+	}
+	return result, nil
+}
+
+func (s *KioskService) applyKioskRequest(kiosk *models.Kiosk, req *models.KioskRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return errors.New("kiosk name is required")
+	}
+	if existing, err := s.kioskRepo.FindByName(name); err == nil && existing.ID != kiosk.ID {
+		return errors.New("a kiosk with this name already exists")
+	}
+
+	if req.WorkLocationID != nil {
+		if _, err := s.locationRepo.FindByID(*req.WorkLocationID); err != nil {
+			return errors.New("work location not found")
+		}
+	}
+
+	kiosk.Name = name
+	kiosk.WorkLocationID = req.WorkLocationID
+	kiosk.WorkLocation = nil
+	if req.IsActive != nil {
+		kiosk.IsActive = *req.IsActive
+	}
+	return nil
+}
+
+// issueCredential gives the kiosk a new random token; only its hash is stored
+func (s *KioskService) issueCredential(kiosk *models.Kiosk) (*models.KioskCredential, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(raw)
+
+	kiosk.TokenHash = hashKioskToken(token)
+	kiosk.TokenIssuedAt = time.Now()
+	if err := s.kioskRepo.Save(kiosk); err != nil {
+		return nil, err
+	}
+
+	kiosk, err := s.kioskRepo.FindByID(kiosk.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.KioskCredential{Kiosk: kiosk, Token: token}, nil
+}
+
+// verifyQRCode checks the signature and freshness of a scanned code and returns its kiosk
+func (s *KioskService) verifyQRCode(code string) (uint, error) {
+	invalid := errors.New("invalid kiosk code")
+
+	parts := strings.Split(strings.TrimSpace(code), ".")
+	if len(parts) != 3 {
+		return 0, invalid
+	}
+	kioskID, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, invalid
+	}
+	slot, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, invalid
+	}
+
+	if !hmac.Equal([]byte(s.signQRCode(uint(kioskID), slot)), []byte(strings.TrimSpace(code))) {
+		return 0, invalid
+	}
+
+	current := time.Now().Unix() / int64(s.qrInterval().Seconds())
+	if slot != current && slot != current-1 {
+		return 0, errors.New("kiosk code has expired; scan the code currently shown")
+	}
+	return uint(kioskID), nil
+}
+
+// signQRCode returns the code of the kiosk for the time slot: "<kiosk>.<slot>.<signature>"
+func (s *KioskService) signQRCode(kioskID uint, slot int64) string {
+	payload := fmt.Sprintf("%d.%d", kioskID, slot)
+	key := sha256.Sum256([]byte("kiosk-qr:" + s.cfg.Kiosk.QRSecret))
+	mac := hmac.New(sha256.New, key[:])
+	mac.Write([]byte(payload))
+	return payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *KioskService) qrInterval() time.Duration {
+	if s.cfg.Kiosk.QRInterval < time.Second {
+		return defaultKioskQRInterval
+	}
+	return s.cfg.Kiosk.QRInterval
+}
+
+func hashKioskToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
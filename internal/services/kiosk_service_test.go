@@ -0,0 +1,93 @@
+package services
+
+import (
+	"hr-backend/internal/config"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestKioskService(secret string) *KioskService {
+	// A long slot keeps the tests clear of slot boundaries
+	return &KioskService{cfg: &config.Config{Kiosk: config.KioskConfig{QRSecret: secret, QRInterval: time.Hour}}}
+}
+
+func TestKioskQRCode(t *testing.T) {
+	s := newTestKioskService("test-secret")
+	current := time.Now().Unix() / int64(time.Hour.Seconds())
+
+	valid := s.signQRCode(7, current)
+	parts := strings.Split(valid, ".")
+	forged := parts[0] + "." + parts[1] + "." + strings.Repeat("A", len(parts[2]))
+
+	tests := []struct {
+		name    string
+		code    string
+		want    uint
+		wantErr bool
+	}{
+		{name: "current slot", code: valid, want: 7},
+		{name: "previous slot", code: s.signQRCode(7, current-1), want: 7},
+		{name: "surrounding whitespace", code: " " + valid + "\n", want: 7},
+		{name: "expired slot", code: s.signQRCode(7, current-2), wantErr: true},
+		{name: "future slot", code: s.signQRCode(7, current+1), wantErr: true},
+		{name: "other kiosk in the payload", code: "8." + parts[1] + "." + parts[2], wantErr: true},
+		{name: "forged signature", code: forged, wantErr: true},
+		{name: "other secret", code: newTestKioskService("other-secret").signQRCode(7, current), wantErr: true},
+		{name: "missing signature", code: parts[0] + "." + parts[1], wantErr: true},
+		{name: "not numeric", code: "x." + parts[1] + "." + parts[2], wantErr: true},
+		{name: "empty", code: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.verifyQRCode(tt.code)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("verifyQRCode(%q) = %d, want an error", tt.code, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("verifyQRCode(%q) = %d, %v, want %d", tt.code, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestKioskQRCodeRotates(t *testing.T) {
+	s := newTestKioskService("test-secret")
+
+	code, err := s.GetQRCode(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, err := s.verifyQRCode(code.Code); err != nil || id != 3 {
+		t.Fatalf("verifyQRCode(GetQRCode()) = %d, %v, want 3", id, err)
+	}
+	if !code.ExpiresAt.After(time.Now()) || code.ExpiresAt.After(time.Now().Add(time.Hour)) {
+		t.Errorf("ExpiresAt = %v, want within the next hour", code.ExpiresAt)
+	}
+
+	if s.signQRCode(3, 100) == s.signQRCode(3, 101) {
+		t.Error("codes of consecutive slots are equal")
+	}
+}
+
+func TestKioskQRIntervalDefault(t *testing.T) {
+	tests := []struct {
+		configured time.Duration
+		want       time.Duration
+	}{
+		{configured: 0, want: defaultKioskQRInterval},
+		{configured: 500 * time.Millisecond, want: defaultKioskQRInterval},
+		{configured: 45 * time.Second, want: 45 * time.Second},
+	}
+
+	for _, tt := range tests {
+		s := &KioskService{cfg: &config.Config{Kiosk: config.KioskConfig{QRInterval: tt.configured}}}
+		if got := s.qrInterval(); got != tt.want {
+			t.Errorf("qrInterval() with %v configured = %v, want %v", tt.configured, got, tt.want)
+		}
+	}
+}
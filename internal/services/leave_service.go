@@ -12,12 +12,14 @@ import (
 type LeaveService struct {
 	leaveRepo    *repositories.LeaveRepository
 	employeeRepo *repositories.EmployeeRepository
+	shiftService *ShiftService
 }
 
-func NewLeaveService(leaveRepo *repositories.LeaveRepository, employeeRepo *repositories.EmployeeRepository) *LeaveService {
+func NewLeaveService(leaveRepo *repositories.LeaveRepository, employeeRepo *repositories.EmployeeRepository, shiftService *ShiftService) *LeaveService {
 	return &LeaveService{
 		leaveRepo:    leaveRepo,
 		employeeRepo: employeeRepo,
+		shiftService: shiftService,
 	}
 }
 
@@ -33,8 +35,17 @@ func (s *LeaveService) CreateLeave(req *models.CreateLeaveRequest) (*models.Leav
 		return nil, errors.New("end date must be after start date")
 	}
 
-	// Calculate total days
-	totalDays := int(req.EndDate.Time.Sub(req.StartDate.Time).Hours()/24) + 1
+	// Only scheduled working days count; weekends, rest days and holidays are skipped
+	if err := validateDateRange(dateOnly(req.StartDate.Time), dateOnly(req.EndDate.Time)); err != nil {
+		return nil, err
+	}
+	totalDays, err := s.shiftService.WorkingDays(req.EmployeeID, req.StartDate.Time, req.EndDate.Time)
+	if err != nil {
+		return nil, err
+	}
+	if totalDays == 0 {
+		return nil, errors.New("the leave period contains no working days")
+	}
 
 	// Check leave balance
 	year := req.StartDate.Time.Year()
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"errors"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+)
+
+type NotificationService struct {
+	notificationRepo *repositories.NotificationRepository
+	userRepo         *repositories.UserRepository
+}
+
+func NewNotificationService(notificationRepo *repositories.NotificationRepository, userRepo *repositories.UserRepository) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+	}
+}
+
+// Notify stores an in-app notification for a single user
+func (s *NotificationService) Notify(userID uint, notificationType, title, message, link string) error {
+	return s.notificationRepo.Create(&models.Notification{
+		UserID:  userID,
+		Type:    notificationType,
+		Title:   title,
+		Message: message,
+		Link:    link,
+	})
+}
+
+// NotifyRoles sends the same notification to every active user holding one of the roles
+func (s *NotificationService) NotifyRoles(roles []string, notificationType, title, message, link string) error {
+	users, err := s.userRepo.FindActiveByRoles(roles...)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		if err := s.Notify(user.ID, notificationType, title, message, link); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *NotificationService) GetNotifications(userID uint, unreadOnly bool, page, limit int) ([]models.Notification, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	return s.notificationRepo.FindByUser(userID, unreadOnly, page, limit)
+}
+
+func (s *NotificationService) MarkRead(id, userID uint) error {
+	notification, err := s.notificationRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	if notification.UserID != userID {
+		return errors.New("notification not found")
+	}
+
+	return s.notificationRepo.MarkRead(id)
+}
+
+func (s *NotificationService) MarkAllRead(userID uint) error {
+	return s.notificationRepo.MarkAllRead(userID)
+}
@@ -0,0 +1,411 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hr-backend/internal/config"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"sort"
+	"strings"
+	"time"
+)
+
+type OnboardingService struct {
+	onboardingRepo      *repositories.OnboardingRepository
+	employeeRepo        *repositories.EmployeeRepository
+	notificationService *NotificationService
+	cfg                 *config.Config
+}
+
+func NewOnboardingService(onboardingRepo *repositories.OnboardingRepository, employeeRepo *repositories.EmployeeRepository, notificationService *NotificationService, cfg *config.Config) *OnboardingService {
+	return &OnboardingService{
+		onboardingRepo:      onboardingRepo,
+		employeeRepo:        employeeRepo,
+		notificationService: notificationService,
+		cfg:                 cfg,
+	}
+}
+
+func (s *OnboardingService) CreateTemplate(req *models.CreateOnboardingTemplateRequest) (*models.OnboardingTemplate, error) {
+	template := &models.OnboardingTemplate{
+		Name:         req.Name,
+		Description:  req.Description,
+		DepartmentID: req.DepartmentID,
+		Position:     strings.TrimSpace(req.Position),
+		IsActive:     true,
+		Tasks:        buildTemplateTasks(req.Tasks),
+	}
+
+	if err := s.onboardingRepo.CreateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	return s.onboardingRepo.FindTemplateByID(template.ID)
+}
+
+func (s *OnboardingService) GetTemplates() ([]models.OnboardingTemplate, error) {
+	return s.onboardingRepo.FindAllTemplates()
+}
+
+func (s *OnboardingService) GetTemplateByID(id uint) (*models.OnboardingTemplate, error) {
+	return s.onboardingRepo.FindTemplateByID(id)
+}
+
+func (s *OnboardingService) UpdateTemplate(id uint, req *models.UpdateOnboardingTemplateRequest) (*models.OnboardingTemplate, error) {
+	template, err := s.onboardingRepo.FindTemplateByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		template.Name = req.Name
+	}
+	if req.Description != "" {
+		template.Description = req.Description
+	}
+	if req.DepartmentID != nil {
+		template.DepartmentID = req.DepartmentID
+	}
+	if req.Position != nil {
+		template.Position = strings.TrimSpace(*req.Position)
+	}
+	if req.IsActive != nil {
+		template.IsActive = *req.IsActive
+	}
+
+	if err := s.onboardingRepo.UpdateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	if req.Tasks != nil {
+		if err := s.onboardingRepo.ReplaceTemplateTasks(id, buildTemplateTasks(req.Tasks)); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.onboardingRepo.FindTemplateByID(id)
+}
+
+func (s *OnboardingService) DeleteTemplate(id uint) error {
+	if _, err := s.onboardingRepo.FindTemplateByID(id); err != nil {
+		return err
+	}
+	return s.onboardingRepo.DeleteTemplate(id)
+}
+
+// StartOnboarding spawns the task list of the most specific template matching
+// the employee's department and position. Due dates are relative to HireDate.
+func (s *OnboardingService) StartOnboarding(employeeID uint) ([]models.OnboardingTask, error) {
+	employee, err := s.employeeRepo.FindByID(employeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+
+	count, err := s.onboardingRepo.CountTasksByEmployee(employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, errors.New("onboarding already started for this employee")
+	}
+
+	templates, err := s.onboardingRepo.FindMatchingTemplates(employee.DepartmentID, employee.Position)
+	if err != nil {
+		return nil, err
+	}
+	if len(templates) == 0 {
+		return nil, errors.New("no onboarding template matches this employee")
+	}
+
+	template := mostSpecificTemplate(templates)
+
+	tasks := make([]models.OnboardingTask, 0, len(template.Tasks))
+	for _, templateTask := range template.Tasks {
+		templateTaskID := templateTask.ID
+		task := models.OnboardingTask{
+			EmployeeID:     employee.ID,
+			TemplateTaskID: &templateTaskID,
+			Title:          templateTask.Title,
+			Description:    templateTask.Description,
+			Category:       templateTask.Category,
+			AssigneeRole:   templateTask.AssigneeRole,
+			AssigneeUserID: templateTask.AssigneeUserID,
+			DueDate:        dateOnly(employee.HireDate).AddDate(0, 0, templateTask.DueOffsetDays),
+			Status:         models.OnboardingTaskPending,
+		}
+		if task.AssigneeUserID == nil {
+			task.AssigneeUserID = s.resolveAssignee(employee, templateTask.AssigneeRole)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := s.onboardingRepo.CreateTasks(tasks); err != nil {
+		return nil, err
+	}
+
+	for _, task := range tasks {
+		s.notifyAssignee(&task, "New onboarding task",
+			fmt.Sprintf("%s for %s %s is due on %s", task.Title, employee.FirstName, employee.LastName, task.DueDate.Format("2006-01-02")))
+	}
+
+	return s.onboardingRepo.FindTasksByEmployee(employeeID)
+}
+
+func (s *OnboardingService) AddTask(employeeID uint, req *models.CreateOnboardingTaskRequest) (*models.OnboardingTask, error) {
+	employee, err := s.employeeRepo.FindByID(employeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+
+	task := models.OnboardingTask{
+		EmployeeID:     employee.ID,
+		Title:          req.Title,
+		Description:    req.Description,
+		Category:       req.Category,
+		AssigneeRole:   req.AssigneeRole,
+		AssigneeUserID: req.AssigneeUserID,
+		DueDate:        dateOnly(req.DueDate.Time),
+		Status:         models.OnboardingTaskPending,
+	}
+	if task.AssigneeUserID == nil {
+		task.AssigneeUserID = s.resolveAssignee(employee, req.AssigneeRole)
+	}
+
+	tasks := []models.OnboardingTask{task}
+	if err := s.onboardingRepo.CreateTasks(tasks); err != nil {
+		return nil, err
+	}
+
+	return s.onboardingRepo.FindTaskByID(tasks[0].ID)
+}
+
+func (s *OnboardingService) GetEmployeeOnboarding(employeeID uint) (*models.OnboardingProgress, error) {
+	employee, err := s.employeeRepo.FindByID(employeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+
+	tasks, err := s.onboardingRepo.FindTasksByEmployee(employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := newOnboardingProgress(employee)
+	today := dateOnly(time.Now())
+	for _, task := range tasks {
+		countOnboardingTask(progress, task, today)
+	}
+	progress.Tasks = tasks
+
+	return progress, nil
+}
+
+func (s *OnboardingService) GetMyTasks(userID uint, role string) ([]models.OnboardingTask, error) {
+	return s.onboardingRepo.FindTasksForAssignee(userID, role)
+}
+
+// CompleteTask marks a task done. HR and admins may complete any task;
+// other users only the tasks assigned to them or to their role.
+func (s *OnboardingService) CompleteTask(id, userID uint, role string, req *models.CompleteOnboardingTaskRequest) (*models.OnboardingTask, error) {
+	task, err := s.onboardingRepo.FindTaskByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Status == models.OnboardingTaskCompleted {
+		return nil, errors.New("task is already completed")
+	}
+
+	isHR := role == "admin" || role == "hr_manager"
+	isAssignee := (task.AssigneeUserID != nil && *task.AssigneeUserID == userID) ||
+		(task.AssigneeUserID == nil && task.AssigneeRole == role)
+	if !isHR && !isAssignee {
+		return nil, errors.New("task is not assigned to you")
+	}
+
+	now := time.Now()
+	task.Status = models.OnboardingTaskCompleted
+	task.CompletedAt = &now
+	task.CompletedBy = &userID
+	task.Notes = req.Notes
+
+	if err := s.onboardingRepo.UpdateTask(task); err != nil {
+		return nil, err
+	}
+
+	return s.onboardingRepo.FindTaskByID(id)
+}
+
+func (s *OnboardingService) DeleteTask(id uint) error {
+	if _, err := s.onboardingRepo.FindTaskByID(id); err != nil {
+		return err
+	}
+	return s.onboardingRepo.DeleteTask(id)
+}
+
+// GetOverdueDashboard groups overdue onboarding tasks per employee
+func (s *OnboardingService) GetOverdueDashboard(departmentID *uint) (*models.OnboardingDashboard, error) {
+	today := dateOnly(time.Now())
+	tasks, err := s.onboardingRepo.FindOverdueTasks(today, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	byEmployee := map[uint]*models.OnboardingProgress{}
+	for _, task := range tasks {
+		progress, ok := byEmployee[task.EmployeeID]
+		if !ok {
+			progress = newOnboardingProgress(task.Employee)
+			byEmployee[task.EmployeeID] = progress
+		}
+		countOnboardingTask(progress, task, today)
+		task.Employee = nil
+		progress.Tasks = append(progress.Tasks, task)
+	}
+
+	dashboard := &models.OnboardingDashboard{
+		TotalOverdue: len(tasks),
+		Employees:    make([]models.OnboardingProgress, 0, len(byEmployee)),
+	}
+	for _, progress := range byEmployee {
+		dashboard.Employees = append(dashboard.Employees, *progress)
+	}
+	sort.Slice(dashboard.Employees, func(i, j int) bool {
+		return dashboard.Employees[i].OverdueTasks > dashboard.Employees[j].OverdueTasks
+	})
+
+	return dashboard, nil
+}
+
+// SendReminders notifies assignees of tasks coming due within the configured
+// window once, and of overdue tasks once per day
+func (s *OnboardingService) SendReminders() error {
+	now := time.Now()
+	today := dateOnly(now)
+	dueBy := today.AddDate(0, 0, s.cfg.Onboarding.ReminderDays)
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	tasks, err := s.onboardingRepo.FindTasksToRemind(dueBy, startOfDay)
+	if err != nil {
+		return err
+	}
+
+	for i := range tasks {
+		task := &tasks[i]
+		overdue := task.DueDate.Before(today)
+		if !overdue && task.LastRemindedAt != nil {
+			continue
+		}
+
+		title := "Onboarding task due soon"
+		if overdue {
+			title = "Onboarding task overdue"
+		}
+		message := fmt.Sprintf("%s is due on %s", task.Title, task.DueDate.Format("2006-01-02"))
+		if task.Employee != nil {
+			message = fmt.Sprintf("%s for %s %s is due on %s", task.Title, task.Employee.FirstName, task.Employee.LastName, task.DueDate.Format("2006-01-02"))
+		}
+
+		s.notifyAssignee(task, title, message)
+
+		task.LastRemindedAt = &now
+		if err := s.onboardingRepo.UpdateTask(task); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveAssignee maps a template role to a concrete user where one is implied:
+// the new hire themselves or their department manager. HR and admin tasks stay
+// assigned to the role so any member of it can pick them up.
+func (s *OnboardingService) resolveAssignee(employee *models.Employee, role string) *uint {
+	switch role {
+	case "employee":
+		return employee.UserID
+	case "department_manager":
+		if employee.Department != nil && employee.Department.ManagerID != nil {
+			manager, err := s.employeeRepo.FindByID(*employee.Department.ManagerID)
+			if err == nil {
+				return manager.UserID
+			}
+		}
+	}
+	return nil
+}
+
+// notifyAssignee is best-effort: a failed notification must not fail the task operation
+func (s *OnboardingService) notifyAssignee(task *models.OnboardingTask, title, message string) {
+	link := fmt.Sprintf("/karyawan/%d/orientasi", task.EmployeeID)
+	if task.AssigneeUserID != nil {
+		s.notificationService.Notify(*task.AssigneeUserID, "onboarding", title, message, link)
+		return
+	}
+
+	roles := []string{"hr_manager"}
+	if task.AssigneeRole != "" {
+		roles = []string{task.AssigneeRole}
+	}
+	s.notificationService.NotifyRoles(roles, "onboarding", title, message, link)
+}
+
+// mostSpecificTemplate prefers templates bound to both department and position,
+// then department only, then position only, then generic ones
+func mostSpecificTemplate(templates []models.OnboardingTemplate) *models.OnboardingTemplate {
+	best := &templates[0]
+	bestScore := -1
+	for i := range templates {
+		score := 0
+		if templates[i].DepartmentID != nil {
+			score += 2
+		}
+		if templates[i].Position != "" {
+			score++
+		}
+		if score > bestScore || (score == bestScore && templates[i].ID > best.ID) {
+			best = &templates[i]
+			bestScore = score
+		}
+	}
+	return best
+}
+
+func buildTemplateTasks(requests []models.OnboardingTemplateTaskRequest) []models.OnboardingTemplateTask {
+	tasks := make([]models.OnboardingTemplateTask, 0, len(requests))
+	for _, req := range requests {
+		tasks = append(tasks, models.OnboardingTemplateTask{
+			Title:          req.Title,
+			Description:    req.Description,
+			Category:       req.Category,
+			AssigneeRole:   req.AssigneeRole,
+			AssigneeUserID: req.AssigneeUserID,
+			DueOffsetDays:  req.DueOffsetDays,
+			SortOrder:      req.SortOrder,
+		})
+	}
+	return tasks
+}
+
+func newOnboardingProgress(employee *models.Employee) *models.OnboardingProgress {
+	progress := &models.OnboardingProgress{}
+	if employee != nil {
+		progress.EmployeeID = employee.ID
+		progress.EmployeeName = employee.FirstName + " " + employee.LastName
+		progress.HireDate = employee.HireDate
+		if employee.Department != nil {
+			progress.DepartmentName = employee.Department.Name
+		}
+	}
+	return progress
+}
+
+func countOnboardingTask(progress *models.OnboardingProgress, task models.OnboardingTask, today time.Time) {
+	progress.TotalTasks++
+	if task.Status == models.OnboardingTaskCompleted {
+		progress.CompletedTasks++
+	} else if task.DueDate.Before(today) {
+		progress.OverdueTasks++
+	}
+}
@@ -0,0 +1,335 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hr-backend/internal/config"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"math"
+	"strings"
+	"time"
+)
+
+// Overtime limits of PP 35/2021
+const (
+	maxDailyOvertimeHours  = 4
+	maxWeeklyOvertimeHours = 18
+)
+
+type OvertimeService struct {
+	overtimeRepo        *repositories.OvertimeRepository
+	attendanceRepo      *repositories.AttendanceRepository
+	employeeRepo        *repositories.EmployeeRepository
+	attendanceService   *AttendanceService
+	shiftService        *ShiftService
+	notificationService *NotificationService
+	timezoneService     *TimezoneService
+	cfg                 *config.Config
+}
+
+func NewOvertimeService(overtimeRepo *repositories.OvertimeRepository, attendanceRepo *repositories.AttendanceRepository, employeeRepo *repositories.EmployeeRepository, attendanceService *AttendanceService, shiftService *ShiftService, notificationService *NotificationService, timezoneService *TimezoneService, cfg *config.Config) *OvertimeService {
+	return &OvertimeService{
+		overtimeRepo:        overtimeRepo,
+		attendanceRepo:      attendanceRepo,
+		employeeRepo:        employeeRepo,
+		attendanceService:   attendanceService,
+		shiftService:        shiftService,
+		notificationService: notificationService,
+		timezoneService:     timezoneService,
+		cfg:                 cfg,
+	}
+}
+
+// SubmitRequest asks approval for overtime on today or a later date. Overtime on
+// a workday is limited to 4 hours, on a rest day or holiday to the length of the
+// multiplier tiers, and to 18 hours a week in total.
+func (s *OvertimeService) SubmitRequest(userID uint, req *models.SubmitOvertimeRequest) (*models.OvertimeRequest, error) {
+	employee, err := s.employeeRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("no employee profile is linked to this account")
+	}
+
+	date := dateOnly(req.Date.Time)
+	if date.Before(localDate(time.Now(), s.timezoneService.For(employee))) {
+		return nil, errors.New("overtime must be requested before it is worked")
+	}
+
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, errors.New("a reason is required")
+	}
+
+	if _, err := s.overtimeRepo.FindOpenOnDate(employee.ID, date); err == nil {
+		return nil, errors.New("an overtime request for this date already exists")
+	}
+
+	schedule, err := s.shiftService.ScheduleFor(employee.ID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	maxHours := float64(maxDailyOvertimeHours)
+	if schedule.DayType != models.DayTypeWorkday {
+		maxHours = s.restDayRegularHours() + 3
+	}
+	if req.Hours > maxHours {
+		return nil, fmt.Errorf("overtime on this day cannot exceed %g hours", maxHours)
+	}
+
+	weekStart := startOfWeek(date)
+	weekHours, err := s.overtimeRepo.SumOpenHours(employee.ID, weekStart, weekStart.AddDate(0, 0, 6))
+	if err != nil {
+		return nil, err
+	}
+	if weekHours+req.Hours > maxWeeklyOvertimeHours {
+		return nil, fmt.Errorf("overtime cannot exceed %d hours a week; %g hours are already requested", maxWeeklyOvertimeHours, weekHours)
+	}
+
+	request := &models.OvertimeRequest{
+		EmployeeID: employee.ID,
+		Date:       date,
+		DayType:    schedule.DayType,
+		Hours:      req.Hours,
+		Reason:     strings.TrimSpace(req.Reason),
+		Status:     models.OvertimePending,
+	}
+	if err := s.overtimeRepo.Create(request); err != nil {
+		return nil, err
+	}
+
+	s.notifyApprovers(employee, request)
+	return request, nil
+}
+
+func (s *OvertimeService) GetMyRequests(userID uint) ([]models.OvertimeRequest, error) {
+	employee, err := s.employeeRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("no employee profile is linked to this account")
+	}
+	return s.overtimeRepo.FindByEmployee(employee.ID)
+}
+
+func (s *OvertimeService) CancelRequest(id, userID uint) error {
+	request, err := s.overtimeRepo.FindByID(id)
+	if err != nil {
+		return errors.New("overtime request not found")
+	}
+
+	if request.Employee == nil || request.Employee.UserID == nil || *request.Employee.UserID != userID {
+		return errors.New("overtime request not found")
+	}
+
+	if request.Status != models.OvertimePending {
+		return errors.New("only pending requests can be cancelled")
+	}
+
+	request.Status = models.OvertimeCancelled
+	return s.overtimeRepo.Update(request)
+}
+
+func (s *OvertimeService) GetRequests(status string, employeeID, departmentID *uint, page, limit int) ([]models.OvertimeRequest, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	return s.overtimeRepo.FindAll(status, employeeID, departmentID, page, limit)
+}
+
+func (s *OvertimeService) GetRequestByID(id uint) (*models.OvertimeRequest, error) {
+	request, err := s.overtimeRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("overtime request not found")
+	}
+	return request, nil
+}
+
+// ApproveRequest approves the overtime; attendance already recorded for the date
+// is evaluated again so its approved overtime follows
+func (s *OvertimeService) ApproveRequest(id, reviewerID uint, req *models.ReviewOvertimeRequest) (*models.OvertimeRequest, error) {
+	request, err := s.reviewableRequest(id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err = s.completeReview(request, models.OvertimeApproved, reviewerID, req.Notes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attendanceService.refreshAttendance(request.EmployeeID, request.Date); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (s *OvertimeService) RejectRequest(id, reviewerID uint, req *models.ReviewOvertimeRequest) (*models.OvertimeRequest, error) {
+	if strings.TrimSpace(req.Notes) == "" {
+		return nil, errors.New("a reason is required when rejecting a request")
+	}
+
+	request, err := s.reviewableRequest(id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.completeReview(request, models.OvertimeRejected, reviewerID, req.Notes)
+}
+
+// GetOvertimeSummary compares the overtime worked in a month with the approvals and prices it
+func (s *OvertimeService) GetOvertimeSummary(employeeID uint, month, year int) (*models.OvertimeSummary, error) {
+	employee, err := s.employeeRepo.FindByID(employeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+	return s.OvertimeSummaryFor(employee, month, year)
+}
+
+// OvertimeSummaryFor prices the approved overtime of an employee's month. The hourly
+// wage is the monthly salary divided by the configured divisor. On workdays the first
+// hour pays 1.5 times and later hours twice the hourly wage; rest days and holidays
+// follow the tiers of overtimeMultipliers.
+func (s *OvertimeService) OvertimeSummaryFor(employee *models.Employee, month, year int) (*models.OvertimeSummary, error) {
+	if month < 1 || month > 12 {
+		return nil, errors.New("invalid month")
+	}
+
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 1, -1)
+
+	attendances, err := s.attendanceRepo.FindByEmployee(employee.ID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.OvertimeSummary{
+		EmployeeID: employee.ID,
+		Month:      month,
+		Year:       year,
+		Days:       []models.OvertimeDay{},
+	}
+	if s.cfg.Overtime.HourlyDivisor > 0 {
+		summary.HourlyRate = math.Round(float64(employee.Salary) / s.cfg.Overtime.HourlyDivisor)
+	}
+
+	for i := len(attendances) - 1; i >= 0; i-- {
+		attendance := attendances[i]
+		if attendance.OvertimeHours <= 0 {
+			continue
+		}
+
+		weighted := s.weightedHours(attendance.ApprovedOvertimeHours, attendance.DayType)
+		day := models.OvertimeDay{
+			AttendanceID:    attendance.ID,
+			Date:            attendance.Date,
+			DayType:         attendance.DayType,
+			WorkedHours:     attendance.OvertimeHours,
+			ApprovedHours:   attendance.ApprovedOvertimeHours,
+			UnapprovedHours: roundHours(attendance.OvertimeHours - attendance.ApprovedOvertimeHours),
+			WeightedHours:   roundHours(weighted),
+			Amount:          math.Round(summary.HourlyRate * weighted),
+		}
+
+		summary.ApprovedHours += day.ApprovedHours
+		summary.UnapprovedHours += day.UnapprovedHours
+		summary.Amount += day.Amount
+		summary.Days = append(summary.Days, day)
+	}
+
+	summary.ApprovedHours = roundHours(summary.ApprovedHours)
+	summary.UnapprovedHours = roundHours(summary.UnapprovedHours)
+	return summary, nil
+}
+
+// overtimeTier pays Hours of overtime at Multiplier times the hourly wage
+type overtimeTier struct {
+	Hours      float64
+	Multiplier float64
+}
+
+// overtimeMultipliers returns the tiers for the day type. On rest days and holidays
+// the regular hours (8 in a 5-day week, 7 in a 6-day week) pay double, the next hour
+// three times and the hours after that four times the hourly wage.
+func (s *OvertimeService) overtimeMultipliers(dayType string) []overtimeTier {
+	if dayType == "" || dayType == models.DayTypeWorkday {
+		return []overtimeTier{{1, 1.5}, {math.Inf(1), 2}}
+	}
+	return []overtimeTier{{s.restDayRegularHours(), 2}, {1, 3}, {math.Inf(1), 4}}
+}
+
+func (s *OvertimeService) restDayRegularHours() float64 {
+	if s.cfg.Overtime.WorkWeekDays == 6 {
+		return 7
+	}
+	return 8
+}
+
+// weightedHours converts overtime hours into paid hours by applying the multipliers
+func (s *OvertimeService) weightedHours(hours float64, dayType string) float64 {
+	weighted := 0.0
+	for _, tier := range s.overtimeMultipliers(dayType) {
+		if hours <= 0 {
+			break
+		}
+		inTier := math.Min(hours, tier.Hours)
+		weighted += inTier * tier.Multiplier
+		hours -= inTier
+	}
+	return weighted
+}
+
+func (s *OvertimeService) reviewableRequest(id, reviewerID uint) (*models.OvertimeRequest, error) {
+	request, err := s.overtimeRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("overtime request not found")
+	}
+
+	if request.Status != models.OvertimePending {
+		return nil, errors.New("overtime request has already been reviewed")
+	}
+
+	if request.Employee != nil && request.Employee.UserID != nil && *request.Employee.UserID == reviewerID {
+		return nil, errors.New("you cannot review your own overtime request")
+	}
+
+	return request, nil
+}
+
+func (s *OvertimeService) completeReview(request *models.OvertimeRequest, status string, reviewerID uint, notes string) (*models.OvertimeRequest, error) {
+	now := time.Now()
+	request.Status = status
+	request.ReviewedBy = &reviewerID
+	request.ReviewedAt = &now
+	request.ReviewNotes = notes
+
+	if err := s.overtimeRepo.Update(request); err != nil {
+		return nil, err
+	}
+
+	if request.Employee != nil && request.Employee.UserID != nil {
+		message := fmt.Sprintf("Your overtime request for %s was %s", request.Date.Format("2006-01-02"), status)
+		if notes != "" {
+			message += ": " + notes
+		}
+		s.notificationService.Notify(*request.Employee.UserID, "overtime", "Overtime request "+status, message, "/lembur/saya")
+	}
+
+	return request, nil
+}
+
+// notifyApprovers tells the department manager, or HR when there is none, about a new request
+func (s *OvertimeService) notifyApprovers(employee *models.Employee, request *models.OvertimeRequest) {
+	title := "Overtime requested"
+	message := fmt.Sprintf("%s %s requested %g hours of overtime on %s", employee.FirstName, employee.LastName, request.Hours, request.Date.Format("2006-01-02"))
+	link := fmt.Sprintf("/lembur/%d", request.ID)
+
+	if employee.Department != nil && employee.Department.ManagerID != nil && *employee.Department.ManagerID != employee.ID {
+		manager, err := s.employeeRepo.FindByID(*employee.Department.ManagerID)
+		if err == nil && manager.UserID != nil {
+			s.notificationService.Notify(*manager.UserID, "overtime", title, message, link)
+			return
+		}
+	}
+	s.notificationService.NotifyRoles([]string{"hr_manager"}, "overtime", title, message, link)
+}
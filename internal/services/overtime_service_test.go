@@ -0,0 +1,37 @@
+package services
+
+import (
+	"hr-backend/internal/config"
+	"hr-backend/internal/models"
+	"testing"
+)
+
+func TestOvertimeWeightedHours(t *testing.T) {
+	tests := []struct {
+		name         string
+		workWeekDays int
+		dayType      string
+		hours        float64
+		want         float64
+	}{
+		{name: "no overtime", workWeekDays: 5, dayType: models.DayTypeWorkday, hours: 0, want: 0},
+		{name: "workday first half hour", workWeekDays: 5, dayType: models.DayTypeWorkday, hours: 0.5, want: 0.75},
+		{name: "workday first hour", workWeekDays: 5, dayType: models.DayTypeWorkday, hours: 1, want: 1.5},
+		{name: "workday later hours pay double", workWeekDays: 5, dayType: models.DayTypeWorkday, hours: 3, want: 1.5 + 2*2},
+		{name: "day type unknown is a workday", workWeekDays: 5, dayType: "", hours: 2, want: 1.5 + 2},
+		{name: "rest day within regular hours", workWeekDays: 5, dayType: models.DayTypeRestDay, hours: 4, want: 8},
+		{name: "rest day ninth hour", workWeekDays: 5, dayType: models.DayTypeRestDay, hours: 9, want: 8*2 + 3},
+		{name: "rest day beyond the ninth hour", workWeekDays: 5, dayType: models.DayTypeRestDay, hours: 11, want: 8*2 + 3 + 2*4},
+		{name: "holiday in a six-day week", workWeekDays: 6, dayType: models.DayTypeHoliday, hours: 10, want: 7*2 + 3 + 2*4},
+		{name: "six-day week workday is unchanged", workWeekDays: 6, dayType: models.DayTypeWorkday, hours: 2, want: 1.5 + 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &OvertimeService{cfg: &config.Config{Overtime: config.OvertimeConfig{WorkWeekDays: tt.workWeekDays}}}
+			if got := s.weightedHours(tt.hours, tt.dayType); got != tt.want {
+				t.Errorf("weightedHours(%v, %q) = %v, want %v", tt.hours, tt.dayType, got, tt.want)
+			}
+		})
+	}
+}
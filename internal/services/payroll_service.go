@@ -11,48 +11,67 @@ import (
 )
 
 type PayrollService struct {
-	payrollRepo  *repositories.PayrollRepository
-	employeeRepo *repositories.EmployeeRepository
-	db           *gorm.DB
+	payrollRepo     *repositories.PayrollRepository
+	employeeRepo    *repositories.EmployeeRepository
+	overtimeService *OvertimeService
+	db              *gorm.DB
 }
 
-func NewPayrollService(payrollRepo *repositories.PayrollRepository, employeeRepo *repositories.EmployeeRepository, db *gorm.DB) *PayrollService {
+func NewPayrollService(payrollRepo *repositories.PayrollRepository, employeeRepo *repositories.EmployeeRepository, overtimeService *OvertimeService, db *gorm.DB) *PayrollService {
 	return &PayrollService{
-		payrollRepo:  payrollRepo,
-		employeeRepo: employeeRepo,
-		db:           db,
+		payrollRepo:     payrollRepo,
+		employeeRepo:    employeeRepo,
+		overtimeService: overtimeService,
+		db:              db,
 	}
 }
 
 func (s *PayrollService) GeneratePayroll(req *models.GeneratePayrollRequest) ([]models.Payroll, error) {
-	// Get all active employees
-	employees, _, err := s.employeeRepo.FindAll(1, 1000, nil, "active", "")
+	// Get everyone still on the payroll, including probation and suspension
+	employees, err := s.employeeRepo.FindByStatuses(models.WorkingEmploymentStatuses)
 	if err != nil {
 		return nil, err
 	}
 
 	var payrolls []models.Payroll
+	periodEnd := time.Date(req.Year, time.Month(req.Month)+1, 0, 0, 0, 0, 0, time.UTC)
 
 	for _, employee := range employees {
+		// Pay and overtime for the final month are part of the termination settlement
+		if employee.TerminationDate != nil && !employee.TerminationDate.After(periodEnd) {
+			continue
+		}
+
 		// Check if payroll already exists
 		existing, err := s.payrollRepo.FindByEmployeeAndPeriod(employee.ID, req.Month, req.Year)
 		if err == nil && existing.ID > 0 {
 			continue // Skip if already exists
 		}
 
+		salary := float64(employee.Salary)
+
+		// Only approved overtime is paid
+		overtime, err := s.overtimeService.OvertimeSummaryFor(&employee, req.Month, req.Year)
+		if err != nil {
+			return nil, err
+		}
+		gross := salary + overtime.Amount
+
 		// Calculate tax (simple flat 10% for example)
-		tax := employee.Salary * 0.10
+		tax := gross * 0.10
 
 		payroll := models.Payroll{
-			EmployeeID:  employee.ID,
-			Month:       req.Month,
-			Year:        req.Year,
-			BasicSalary: employee.Salary,
-			Allowances:  0,
-			Deductions:  0,
-			Tax:         tax,
-			NetSalary:   employee.Salary - tax,
-			Status:      "pending",
+			EmployeeID:    employee.ID,
+			Month:         req.Month,
+			Year:          req.Year,
+			BasicSalary:   salary,
+			Allowances:    0,
+			OvertimeHours: overtime.ApprovedHours,
+			OvertimePay:   overtime.Amount,
+			Deductions:    0,
+			Tax:           tax,
+			NetSalary:     gross - tax,
+			Status:        "pending",
 		}
 
 		if err := s.payrollRepo.Create(&payroll); err != nil {
@@ -90,7 +109,7 @@ func (s *PayrollService) UpdatePayroll(id uint, payroll *models.Payroll) (*model
 	existing.Allowances = payroll.Allowances
 	existing.Deductions = payroll.Deductions
 	existing.Tax = payroll.Tax
-	existing.NetSalary = payroll.BasicSalary + payroll.Allowances - payroll.Deductions - payroll.Tax
+	existing.NetSalary = payroll.BasicSalary + payroll.Allowances + existing.OvertimePay - payroll.Deductions - payroll.Tax
 
 	if err := s.payrollRepo.Update(existing); err != nil {
 		return nil, err
@@ -0,0 +1,356 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hr-backend/internal/config"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"hr-backend/internal/storage"
+	"hr-backend/internal/utils"
+	"path"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// anonymizedText replaces free text that may describe the person
+const anonymizedText = "[anonymized]"
+
+type PrivacyService struct {
+	employeeRepo *repositories.EmployeeRepository
+	storage      *storage.LocalStorage
+	cfg          *config.Config
+	db           *gorm.DB
+}
+
+func NewPrivacyService(employeeRepo *repositories.EmployeeRepository, storage *storage.LocalStorage, cfg *config.Config, db *gorm.DB) *PrivacyService {
+	return &PrivacyService{
+		employeeRepo: employeeRepo,
+		storage:      storage,
+		cfg:          cfg,
+		db:           db,
+	}
+}
+
+// ExportPersonalData bundles everything held about an employee into a ZIP of JSON
+// files plus the payslip PDFs, certificate scans, attendance photos and correction
+// evidence, for data subject access requests (UU PDP).
+// It returns the archive and a suggested file name.
+func (s *PrivacyService) ExportPersonalData(employeeID uint) ([]byte, string, error) {
+	employee, err := s.employeeRepo.FindByID(employeeID)
+	if err != nil {
+		return nil, "", errors.New("employee not found")
+	}
+
+	var (
+		statusHistory  []models.EmploymentStatusHistory
+		renewals       []models.ContractRenewal
+		attendances    []models.Attendance
+		punchEvents    []models.PunchEvent
+		corrections    []models.AttendanceCorrection
+		overtime       []models.OvertimeRequest
+		remoteWork     []models.RemoteWorkRequest
+		timesheets     []models.Timesheet
+		leaves         []models.Leave
+		leaveBalances  []models.LeaveBalance
+		payrolls       []models.Payroll
+		onboarding     []models.OnboardingTask
+		profileChanges []models.ProfileChangeRequest
+		terminations   []models.Termination
+		skills         []models.EmployeeSkill
+		assets         []models.AssetAssignment
+		certifications []models.Certification
+		notifications  []models.Notification
+	)
+
+	queries := []struct {
+		dest  interface{}
+		query *gorm.DB
+	}{
+		{&statusHistory, s.db.Order("effective_date ASC, id ASC")},
+		{&renewals, s.db.Order("created_at ASC")},
+		{&attendances, s.db.Order("date ASC")},
+		{&punchEvents, s.db.Order("punched_at ASC, id ASC")},
+		{&corrections, s.db.Order("created_at ASC")},
+		{&overtime, s.db.Order("date ASC")},
+		{&remoteWork, s.db.Order("start_date ASC")},
+		{&timesheets, s.db.Preload("Entries", func(db *gorm.DB) *gorm.DB { return db.Order("date ASC, id ASC") }).Order("week_start ASC")},
+		{&leaves, s.db.Order("start_date ASC")},
+		{&leaveBalances, s.db.Order("year ASC, leave_type ASC")},
+		{&payrolls, s.db.Order("year ASC, month ASC")},
+		{&onboarding, s.db.Order("due_date ASC")},
+		{&profileChanges, s.db.Order("created_at ASC")},
+		{&terminations, s.db.Preload("ChecklistItems").Order("created_at ASC")},
+		{&skills, s.db.Order("name ASC")},
+		{&assets, s.db.Preload("Asset").Order("assigned_at ASC")},
+		{&certifications, s.db.Preload("Files").Order("issue_date ASC")},
+	}
+	for _, q := range queries {
+		if err := q.query.Where("employee_id = ?", employee.ID).Find(q.dest).Error; err != nil {
+			return nil, "", err
+		}
+	}
+
+	if employee.UserID != nil {
+		if err := s.db.Where("user_id = ?", *employee.UserID).Order("created_at ASC").Find(&notifications).Error; err != nil {
+			return nil, "", err
+		}
+	}
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", employee},
+		{"employment_history.json", map[string]interface{}{"status_changes": statusHistory, "contract_renewals": renewals}},
+		{"attendance.json", attendances},
+		{"punch_events.json", punchEvents},
+		{"attendance_corrections.json", corrections},
+		{"overtime_requests.json", overtime},
+		{"remote_work_requests.json", remoteWork},
+		{"timesheets.json", timesheets},
+		{"leave.json", map[string]interface{}{"leaves": leaves, "balances": leaveBalances}},
+		{"payroll.json", payrolls},
+		{"onboarding.json", onboarding},
+		{"profile_changes.json", profileChanges},
+		{"terminations.json", terminations},
+		{"skills.json", skills},
+		{"certifications.json", certifications},
+		{"assets.json", assets},
+		{"notifications.json", notifications},
+	}
+
+	buf := new(bytes.Buffer)
+	archive := zip.NewWriter(buf)
+
+	fileNames := make([]string, 0, len(files)+len(payrolls))
+	for _, file := range files {
+		if err := writeZipJSON(archive, file.name, file.data); err != nil {
+			return nil, "", err
+		}
+		fileNames = append(fileNames, file.name)
+	}
+
+	for i := range payrolls {
+		payrolls[i].Employee = employee
+		pdf, err := utils.GeneratePayrollPDF(&payrolls[i])
+		if err != nil {
+			return nil, "", err
+		}
+		name := fmt.Sprintf("payslips/%04d-%02d.pdf", payrolls[i].Year, payrolls[i].Month)
+		if err := writeZipFile(archive, name, pdf); err != nil {
+			return nil, "", err
+		}
+		fileNames = append(fileNames, name)
+	}
+
+	for _, certification := range certifications {
+		for _, file := range certification.Files {
+			content, err := s.storage.ReadFile(file.StoragePath)
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to read %s: %w", file.FileName, err)
+			}
+			name := fmt.Sprintf("documents/certifications/%d-%d-%s", certification.ID, file.ID, file.FileName)
+			if err := writeZipFile(archive, name, content); err != nil {
+				return nil, "", err
+			}
+			fileNames = append(fileNames, name)
+		}
+	}
+
+	for _, event := range punchEvents {
+		if event.Photo == "" {
+			continue
+		}
+		content, err := s.storage.ReadFile(event.Photo)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read attendance photo: %w", err)
+		}
+		name := fmt.Sprintf("documents/attendance/%s-%s-%d%s", event.WorkDate.Format("2006-01-02"), event.Type, event.ID, path.Ext(event.Photo))
+		if err := writeZipFile(archive, name, content); err != nil {
+			return nil, "", err
+		}
+		fileNames = append(fileNames, name)
+	}
+
+	for _, correction := range corrections {
+		if correction.EvidencePath == "" {
+			continue
+		}
+		content, err := s.storage.ReadFile(correction.EvidencePath)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read correction evidence: %w", err)
+		}
+		name := fmt.Sprintf("documents/attendance_corrections/%d-%s", correction.ID, correction.EvidenceName)
+		if err := writeZipFile(archive, name, content); err != nil {
+			return nil, "", err
+		}
+		fileNames = append(fileNames, name)
+	}
+
+	manifest := map[string]interface{}{
+		"employee_id":   employee.ID,
+		"employee_code": employee.EmployeeCode,
+		"generated_at":  time.Now(),
+		"files":         fileNames,
+	}
+	if err := writeZipJSON(archive, "manifest.json", manifest); err != nil {
+		return nil, "", err
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), fmt.Sprintf("data_pribadi_%s.zip", employee.EmployeeCode), nil
+}
+
+// FindAnonymizable returns former employees whose retention period has ended
+// and who have not been anonymized yet
+func (s *PrivacyService) FindAnonymizable() ([]models.Employee, error) {
+	cutoff := dateOnly(time.Now()).AddDate(-s.cfg.Privacy.RetentionYears, 0, 0)
+
+	var employees []models.Employee
+	err := s.db.Unscoped().
+		Where("employment_status IN ? AND termination_date < ? AND anonymized_at IS NULL",
+			[]string{models.EmploymentStatusResigned, models.EmploymentStatusTerminated}, cutoff).
+		Order("id ASC").
+		Find(&employees).Error
+	return employees, err
+}
+
+// AnonymizeEmployee irreversibly scrubs the personal data of a former employee.
+// Payroll and attendance rows keep their figures so aggregate reports stay correct;
+// only identifying details and free text are removed.
+func (s *PrivacyService) AnonymizeEmployee(employeeID uint) error {
+	var employee models.Employee
+	if err := s.db.Unscoped().First(&employee, employeeID).Error; err != nil {
+		return errors.New("employee not found")
+	}
+
+	if employee.AnonymizedAt != nil {
+		return errors.New("employee is already anonymized")
+	}
+
+	if employee.EmploymentStatus != models.EmploymentStatusResigned && employee.EmploymentStatus != models.EmploymentStatusTerminated {
+		return errors.New("only former employees can be anonymized")
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		tx = tx.Unscoped().Session(&gorm.Session{})
+
+		if err := tx.Model(&models.Employee{}).Where("id = ?", employee.ID).Updates(map[string]interface{}{
+			"employee_code":       fmt.Sprintf("ANON-%06d", employee.ID),
+			"first_name":          "Anonymized",
+			"last_name":           "Employee",
+			"date_of_birth":       nil,
+			"gender":              "",
+			"phone":               "",
+			"address":             "",
+			"national_id":         "",
+			"salary":              models.EncryptedFloat(0),
+			"bank_name":           "",
+			"bank_account_number": "",
+			"bank_account_name":   "",
+			"profile_picture":     "",
+			"custom_fields":       models.JSONMap{},
+			"phone_index":         "",
+			"national_id_index":   "",
+			"anonymized_at":       time.Now(),
+		}).Error; err != nil {
+			return err
+		}
+
+		if employee.UserID != nil {
+			if err := tx.Model(&models.User{}).Where("id = ?", *employee.UserID).Updates(map[string]interface{}{
+				"email":         fmt.Sprintf("anonymized-%d@anonymized.invalid", *employee.UserID),
+				"password_hash": "!",
+				"is_active":     false,
+			}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("user_id = ?", *employee.UserID).Delete(&models.Notification{}).Error; err != nil {
+				return err
+			}
+		}
+
+		// Snapshots and dependents are personal data in their entirety
+		for _, model := range []interface{}{&models.EmployeeDependent{}, &models.ProfileChangeRequest{}} {
+			if err := tx.Where("employee_id = ?", employee.ID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		scrubs := []struct {
+			model  interface{}
+			values map[string]interface{}
+		}{
+			{&models.Attendance{}, map[string]interface{}{
+				"notes": "", "review_notes": "", "clock_in_photo": "", "clock_out_photo": "",
+				"clock_in_latitude": nil, "clock_in_longitude": nil, "clock_out_latitude": nil, "clock_out_longitude": nil,
+			}},
+			{&models.PunchEvent{}, map[string]interface{}{
+				"notes": "", "photo": "", "latitude": nil, "longitude": nil,
+			}},
+			{&models.AttendanceCorrection{}, map[string]interface{}{
+				"reason": "", "review_notes": "", "evidence_path": "", "evidence_name": "",
+			}},
+			{&models.OvertimeRequest{}, map[string]interface{}{"reason": "", "review_notes": ""}},
+			{&models.RemoteWorkRequest{}, map[string]interface{}{"reason": "", "review_notes": ""}},
+			{&models.Timesheet{}, map[string]interface{}{"review_notes": ""}},
+			{&models.TimesheetEntry{}, map[string]interface{}{"description": ""}},
+			{&models.Leave{}, map[string]interface{}{"reason": ""}},
+			{&models.Termination{}, map[string]interface{}{"reason": anonymizedText, "notes": ""}},
+			{&models.EmploymentStatusHistory{}, map[string]interface{}{"reason": ""}},
+			{&models.ContractRenewal{}, map[string]interface{}{"notes": ""}},
+			{&models.OnboardingTask{}, map[string]interface{}{"notes": ""}},
+			{&models.Certification{}, map[string]interface{}{"certificate_number": ""}},
+			{&models.AssetAssignment{}, map[string]interface{}{"notes": "", "return_notes": ""}},
+		}
+		for _, scrub := range scrubs {
+			if err := tx.Model(scrub.model).Where("employee_id = ?", employee.ID).Updates(scrub.values).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.ExitChecklistItem{}).
+			Where("termination_id IN (?)", tx.Model(&models.Termination{}).Select("id").Where("employee_id = ?", employee.ID)).
+			Update("notes", "").Error; err != nil {
+			return err
+		}
+
+		// Certificate scans show the person's name and often their photo
+		return tx.Where("certification_id IN (?)", tx.Model(&models.Certification{}).Select("id").Where("employee_id = ?", employee.ID)).
+			Delete(&models.CertificationFile{}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := deleteEmployeeFiles(s.storage, employee.ID); err != nil {
+		return err
+	}
+
+	return s.employeeRepo.RefreshSearchText(employee.ID)
+}
+
+func writeZipJSON(archive *zip.Writer, name string, data interface{}) error {
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeZipFile(archive, name, content)
+}
+
+func writeZipFile(archive *zip.Writer, name string, content []byte) error {
+	w, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(content)
+	return err
+}
@@ -0,0 +1,335 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// selfServiceFields are the profile fields employees may request changes to, in display order.
+// The keys match the JSON names in UpdateEmployeeRequest.
+var selfServiceFields = []string{"phone", "address", "bank_name", "bank_account_number", "bank_account_name", "dependents"}
+
+type ProfileChangeService struct {
+	profileChangeRepo   *repositories.ProfileChangeRepository
+	employeeRepo        *repositories.EmployeeRepository
+	employeeService     *EmployeeService
+	notificationService *NotificationService
+}
+
+func NewProfileChangeService(profileChangeRepo *repositories.ProfileChangeRepository, employeeRepo *repositories.EmployeeRepository, employeeService *EmployeeService, notificationService *NotificationService) *ProfileChangeService {
+	return &ProfileChangeService{
+		profileChangeRepo:   profileChangeRepo,
+		employeeRepo:        employeeRepo,
+		employeeService:     employeeService,
+		notificationService: notificationService,
+	}
+}
+
+// SubmitChange records the fields that differ from the employee's current profile
+// for HR review. Only one request can be pending at a time.
+func (s *ProfileChangeService) SubmitChange(userID uint, req *models.SubmitProfileChangeRequest) (*models.ProfileChangeRequest, error) {
+	employee, err := s.employeeRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("no employee profile is linked to this account")
+	}
+
+	if _, err := s.profileChangeRepo.FindPendingByEmployee(employee.ID); err == nil {
+		return nil, errors.New("a profile change request is already pending")
+	}
+
+	submitted := map[string]interface{}{}
+	if req.Phone != nil {
+		submitted["phone"] = strings.TrimSpace(*req.Phone)
+	}
+	if req.Address != nil {
+		submitted["address"] = strings.TrimSpace(*req.Address)
+	}
+	if req.BankName != nil {
+		submitted["bank_name"] = strings.TrimSpace(*req.BankName)
+	}
+	if req.BankAccountNumber != nil {
+		submitted["bank_account_number"] = strings.TrimSpace(*req.BankAccountNumber)
+	}
+	if req.BankAccountName != nil {
+		submitted["bank_account_name"] = strings.TrimSpace(*req.BankAccountName)
+	}
+	if req.Dependents != nil {
+		submitted["dependents"] = normalizeDependents(*req.Dependents)
+	}
+
+	current, err := profileSnapshot(employee)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := models.JSONMap{}
+	original := models.JSONMap{}
+	for field, value := range submitted {
+		normalized, err := normalizeJSON(value)
+		if err != nil {
+			return nil, err
+		}
+		if normalized == "" {
+			return nil, fmt.Errorf("%s cannot be empty", field)
+		}
+		if reflect.DeepEqual(normalized, current[field]) {
+			continue
+		}
+		changes[field] = normalized
+		original[field] = current[field]
+	}
+
+	if len(changes) == 0 {
+		return nil, errors.New("no changes submitted")
+	}
+
+	request := &models.ProfileChangeRequest{
+		EmployeeID: employee.ID,
+		Changes:    changes,
+		Original:   original,
+		Notes:      req.Notes,
+		Status:     models.ProfileChangePending,
+	}
+
+	if err := s.profileChangeRepo.Create(request); err != nil {
+		return nil, err
+	}
+
+	s.notificationService.NotifyRoles([]string{"hr_manager"}, "profile_change", "Profile change submitted",
+		fmt.Sprintf("%s %s requested changes to their profile", employee.FirstName, employee.LastName),
+		fmt.Sprintf("/perubahan-profil/%d", request.ID))
+
+	request.Diff = profileDiff(request)
+	return request, nil
+}
+
+func (s *ProfileChangeService) GetMyRequests(userID uint) ([]models.ProfileChangeRequest, error) {
+	employee, err := s.employeeRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("no employee profile is linked to this account")
+	}
+
+	requests, err := s.profileChangeRepo.FindByEmployee(employee.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range requests {
+		requests[i].Diff = profileDiff(&requests[i])
+	}
+	return requests, nil
+}
+
+func (s *ProfileChangeService) CancelRequest(id, userID uint) error {
+	request, err := s.profileChangeRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	if request.Employee == nil || request.Employee.UserID == nil || *request.Employee.UserID != userID {
+		return errors.New("profile change request not found")
+	}
+
+	if request.Status != models.ProfileChangePending {
+		return errors.New("only pending requests can be cancelled")
+	}
+
+	request.Status = models.ProfileChangeCancelled
+	return s.profileChangeRepo.Update(request)
+}
+
+func (s *ProfileChangeService) GetRequests(status string, page, limit int) ([]models.ProfileChangeRequest, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	requests, total, err := s.profileChangeRepo.FindAll(status, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range requests {
+		requests[i].Diff = profileDiff(&requests[i])
+	}
+	return requests, total, nil
+}
+
+func (s *ProfileChangeService) GetRequestByID(id uint) (*models.ProfileChangeRequest, error) {
+	request, err := s.profileChangeRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Diff = profileDiff(request)
+	return request, nil
+}
+
+// ApproveRequest applies the requested changes through EmployeeService.UpdateEmployee.
+// It refuses when the affected fields were edited since submission, so HR never
+// approves a diff that no longer matches the profile.
+func (s *ProfileChangeService) ApproveRequest(id, reviewerID uint, req *models.ReviewProfileChangeRequest) (*models.ProfileChangeRequest, error) {
+	request, err := s.reviewableRequest(id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	employee, err := s.employeeRepo.FindByID(request.EmployeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+
+	current, err := profileSnapshot(employee)
+	if err != nil {
+		return nil, err
+	}
+	for field := range request.Changes {
+		if !reflect.DeepEqual(current[field], request.Original[field]) {
+			return nil, fmt.Errorf("%s was changed after the request was submitted; ask the employee to resubmit", field)
+		}
+	}
+
+	// Changes are keyed by the UpdateEmployeeRequest JSON names
+	payload, err := json.Marshal(request.Changes)
+	if err != nil {
+		return nil, err
+	}
+	var update models.UpdateEmployeeRequest
+	if err := json.Unmarshal(payload, &update); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.employeeService.UpdateEmployee(request.EmployeeID, &update); err != nil {
+		return nil, err
+	}
+
+	return s.completeReview(request, models.ProfileChangeApproved, reviewerID, req.Notes)
+}
+
+func (s *ProfileChangeService) RejectRequest(id, reviewerID uint, req *models.ReviewProfileChangeRequest) (*models.ProfileChangeRequest, error) {
+	if strings.TrimSpace(req.Notes) == "" {
+		return nil, errors.New("a reason is required when rejecting a request")
+	}
+
+	request, err := s.reviewableRequest(id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.completeReview(request, models.ProfileChangeRejected, reviewerID, req.Notes)
+}
+
+func (s *ProfileChangeService) reviewableRequest(id, reviewerID uint) (*models.ProfileChangeRequest, error) {
+	request, err := s.profileChangeRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Status != models.ProfileChangePending {
+		return nil, errors.New("profile change request has already been reviewed")
+	}
+
+	if request.Employee != nil && request.Employee.UserID != nil && *request.Employee.UserID == reviewerID {
+		return nil, errors.New("you cannot review your own profile change")
+	}
+
+	return request, nil
+}
+
+func (s *ProfileChangeService) completeReview(request *models.ProfileChangeRequest, status string, reviewerID uint, notes string) (*models.ProfileChangeRequest, error) {
+	now := time.Now()
+	request.Status = status
+	request.ReviewedBy = &reviewerID
+	request.ReviewedAt = &now
+	request.ReviewNotes = notes
+
+	if err := s.profileChangeRepo.Update(request); err != nil {
+		return nil, err
+	}
+
+	if request.Employee != nil && request.Employee.UserID != nil {
+		message := "Your profile change request was " + status
+		if notes != "" {
+			message += ": " + notes
+		}
+		s.notificationService.Notify(*request.Employee.UserID, "profile_change", "Profile change "+status, message, "/profil-saya/perubahan")
+	}
+
+	request.Diff = profileDiff(request)
+	return request, nil
+}
+
+// profileSnapshot returns the self-service fields of the employee in their JSON form
+func profileSnapshot(employee *models.Employee) (map[string]interface{}, error) {
+	dependents := make([]models.DependentRequest, 0, len(employee.Dependents))
+	for _, dependent := range employee.Dependents {
+		item := models.DependentRequest{Name: dependent.Name, Relationship: dependent.Relationship}
+		if dependent.DateOfBirth != nil {
+			item.DateOfBirth = &models.FlexibleDate{Time: dateOnly(*dependent.DateOfBirth)}
+		}
+		dependents = append(dependents, item)
+	}
+
+	snapshot := map[string]interface{}{
+		"phone":               employee.Phone,
+		"address":             employee.Address,
+		"bank_name":           employee.BankName,
+		"bank_account_number": employee.BankAccountNumber,
+		"bank_account_name":   employee.BankAccountName,
+		"dependents":          dependents,
+	}
+
+	for field, value := range snapshot {
+		normalized, err := normalizeJSON(value)
+		if err != nil {
+			return nil, err
+		}
+		snapshot[field] = normalized
+	}
+	return snapshot, nil
+}
+
+func normalizeDependents(dependents []models.DependentRequest) []models.DependentRequest {
+	normalized := make([]models.DependentRequest, 0, len(dependents))
+	for _, dependent := range dependents {
+		dependent.Name = strings.TrimSpace(dependent.Name)
+		if dependent.DateOfBirth != nil {
+			dependent.DateOfBirth = &models.FlexibleDate{Time: dateOnly(dependent.DateOfBirth.Time)}
+		}
+		normalized = append(normalized, dependent)
+	}
+	return normalized
+}
+
+// normalizeJSON round-trips a value through JSON so it compares equal to values read back from JSONB
+func normalizeJSON(value interface{}) (interface{}, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized interface{}
+	err = json.Unmarshal(b, &normalized)
+	return normalized, err
+}
+
+func profileDiff(request *models.ProfileChangeRequest) []models.ProfileFieldChange {
+	diff := []models.ProfileFieldChange{}
+	for _, field := range selfServiceFields {
+		if value, ok := request.Changes[field]; ok {
+			diff = append(diff, models.ProfileFieldChange{
+				Field:    field,
+				OldValue: request.Original[field],
+				NewValue: value,
+			})
+		}
+	}
+	return diff
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"hr-backend/internal/models"
+	"sort"
+	"time"
+)
+
+// Where an employee stands after the punches of a day
+const (
+	punchStateOff     = "off"
+	punchStateWorking = "working"
+	punchStateOnBreak = "on_break"
+)
+
+// punchSummary is the outcome of replaying the punch events of a work date
+type punchSummary struct {
+	clockIn  *models.PunchEvent
+	clockOut *models.PunchEvent
+	breaks   time.Duration
+	state    string
+	manual   bool
+	last     time.Time
+}
+
+// summarizePunches replays the events of a work date. Voided events are ignored,
+// and once HR has keyed in the day only its manual and corrected events count.
+// The first clock-in and the last clock-out bound the day; breaks and the gaps between
+// sessions are collected so they can be excluded from working hours. Auto events
+// take the direction that fits: a clock-in when off, a break while working unless
+// it is the last punch, which is the clock-out. Events out of sequence, such as a
+// second clock-in while working, are ignored.
+func summarizePunches(events []models.PunchEvent) punchSummary {
+	voided := map[uint]bool{}
+	for _, event := range events {
+		if event.Type == models.PunchTypeVoid && event.VoidsID != nil {
+			voided[*event.VoidsID] = true
+		}
+	}
+
+	var effective []models.PunchEvent
+	summary := punchSummary{state: punchStateOff}
+	for _, event := range events {
+		if event.Type == models.PunchTypeVoid || voided[event.ID] {
+			continue
+		}
+		if event.Source == models.AttendanceSourceManual {
+			summary.manual = true
+		}
+		effective = append(effective, event)
+	}
+
+	if summary.manual {
+		manual := effective[:0:0]
+		for _, event := range effective {
+			if event.Source == models.AttendanceSourceManual || event.Source == models.AttendanceSourceCorrection {
+				manual = append(manual, event)
+			}
+		}
+		effective = manual
+	}
+
+	sort.SliceStable(effective, func(i, j int) bool { return effective[i].PunchedAt.Before(effective[j].PunchedAt) })
+
+	// Repeated device scans, also across overlapping logs, count once
+	deduped := effective[:0:0]
+	for _, event := range effective {
+		if event.Type == models.PunchTypeAuto && len(deduped) > 0 &&
+			event.PunchedAt.Sub(deduped[len(deduped)-1].PunchedAt) <= duplicatePunchWindow {
+			continue
+		}
+		deduped = append(deduped, event)
+	}
+	effective = deduped
+
+	var breakStart time.Time
+	for i := range effective {
+		event := &effective[i]
+
+		punchType := event.Type
+		if punchType == models.PunchTypeAuto {
+			switch {
+			case summary.state == punchStateOff:
+				punchType = models.PunchTypeIn
+			case summary.state == punchStateOnBreak:
+				punchType = models.PunchTypeBreakEnd
+			case i == len(effective)-1:
+				punchType = models.PunchTypeOut
+			default:
+				punchType = models.PunchTypeBreakStart
+			}
+		}
+
+		switch punchType {
+		case models.PunchTypeIn:
+			switch summary.state {
+			case punchStateOff:
+				if summary.clockIn == nil {
+					summary.clockIn = event
+				} else if summary.clockOut != nil {
+					summary.breaks += event.PunchedAt.Sub(summary.clockOut.PunchedAt)
+				}
+			case punchStateOnBreak:
+				summary.breaks += event.PunchedAt.Sub(breakStart)
+			default:
+				continue
+			}
+			summary.state = punchStateWorking
+
+		case models.PunchTypeBreakStart:
+			if summary.state != punchStateWorking {
+				continue
+			}
+			breakStart = event.PunchedAt
+			summary.state = punchStateOnBreak
+
+		case models.PunchTypeBreakEnd:
+			if summary.state != punchStateOnBreak {
+				continue
+			}
+			summary.breaks += event.PunchedAt.Sub(breakStart)
+			summary.state = punchStateWorking
+
+		case models.PunchTypeOut:
+			switch summary.state {
+			case punchStateOnBreak:
+				summary.breaks += event.PunchedAt.Sub(breakStart)
+			case punchStateOff:
+				continue
+			}
+			summary.clockOut = event
+			summary.state = punchStateOff
+
+		default:
+			continue
+		}
+
+		summary.last = event.PunchedAt
+	}
+
+	return summary
+}
+
+// applyPunchSummary copies the derived clock times, positions and photos onto the record
+func applyPunchSummary(attendance *models.Attendance, summary punchSummary) {
+	attendance.ClockIn = nil
+	attendance.ClockOut = nil
+	attendance.BreakMinutes = 0
+	attendance.ClockInLatitude, attendance.ClockInLongitude, attendance.ClockInAccuracy = nil, nil, nil
+	attendance.ClockInGeofence, attendance.ClockInPhoto = "", ""
+	attendance.ClockOutLatitude, attendance.ClockOutLongitude, attendance.ClockOutAccuracy = nil, nil, nil
+	attendance.ClockOutGeofence, attendance.ClockOutPhoto = "", ""
+	attendance.AutoClosed = false
+
+	if in := summary.clockIn; in != nil {
+		clockIn := in.PunchedAt
+		attendance.ClockIn = &clockIn
+		attendance.ClockInLatitude, attendance.ClockInLongitude, attendance.ClockInAccuracy = in.Latitude, in.Longitude, in.Accuracy
+		attendance.ClockInGeofence, attendance.ClockInPhoto = in.Geofence, in.Photo
+		attendance.Source = in.Source
+	}
+
+	// A day with an open session has no clock-out yet
+	if out := summary.clockOut; out != nil && summary.state == punchStateOff {
+		clockOut := out.PunchedAt
+		attendance.ClockOut = &clockOut
+		attendance.ClockOutLatitude, attendance.ClockOutLongitude, attendance.ClockOutAccuracy = out.Latitude, out.Longitude, out.Accuracy
+		attendance.ClockOutGeofence, attendance.ClockOutPhoto = out.Geofence, out.Photo
+		attendance.BreakMinutes = int(summary.breaks.Minutes())
+		attendance.AutoClosed = out.Source == models.AttendanceSourceSystem
+	}
+
+	if summary.manual {
+		attendance.Source = models.AttendanceSourceManual
+	}
+	attendance.HasClockInPhoto = attendance.ClockInPhoto != ""
+	attendance.HasClockOutPhoto = attendance.ClockOutPhoto != ""
+}
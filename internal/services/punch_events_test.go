@@ -0,0 +1,165 @@
+package services
+
+import (
+	"hr-backend/internal/models"
+	"testing"
+	"time"
+)
+
+// punchAt returns a punch on 2026-03-02 at hh:mm, with the ID as its order of recording
+func punchAt(id uint, punchType, source, clock string) models.PunchEvent {
+	at, err := time.Parse("2006-01-02 15:04", "2026-03-02 "+clock)
+	if err != nil {
+		panic(err)
+	}
+	return models.PunchEvent{ID: id, Type: punchType, Source: source, PunchedAt: at}
+}
+
+func voidOf(id, voids uint, clock string) models.PunchEvent {
+	event := punchAt(id, models.PunchTypeVoid, models.AttendanceSourceCorrection, clock)
+	event.VoidsID = &voids
+	return event
+}
+
+func TestSummarizePunches(t *testing.T) {
+	app, device, manual, correction := models.AttendanceSourceApp, models.AttendanceSourceDevice,
+		models.AttendanceSourceManual, models.AttendanceSourceCorrection
+
+	tests := []struct {
+		name     string
+		events   []models.PunchEvent
+		clockIn  string
+		clockOut string
+		breaks   time.Duration
+		state    string
+		manual   bool
+	}{
+		{
+			name:  "no punches",
+			state: punchStateOff,
+		},
+		{
+			name:    "clocked in only",
+			events:  []models.PunchEvent{punchAt(1, models.PunchTypeIn, app, "08:00")},
+			clockIn: "08:00",
+			state:   punchStateWorking,
+		},
+		{
+			name: "day with a break",
+			events: []models.PunchEvent{
+				punchAt(1, models.PunchTypeIn, app, "08:00"),
+				punchAt(2, models.PunchTypeBreakStart, app, "12:00"),
+				punchAt(3, models.PunchTypeBreakEnd, app, "12:45"),
+				punchAt(4, models.PunchTypeOut, app, "17:00"),
+			},
+			clockIn: "08:00", clockOut: "17:00", breaks: 45 * time.Minute, state: punchStateOff,
+		},
+		{
+			name: "clock-out during a break ends it",
+			events: []models.PunchEvent{
+				punchAt(1, models.PunchTypeIn, app, "08:00"),
+				punchAt(2, models.PunchTypeBreakStart, app, "16:30"),
+				punchAt(3, models.PunchTypeOut, app, "17:00"),
+			},
+			clockIn: "08:00", clockOut: "17:00", breaks: 30 * time.Minute, state: punchStateOff,
+		},
+		{
+			name: "gap between sessions counts as a break",
+			events: []models.PunchEvent{
+				punchAt(1, models.PunchTypeIn, app, "08:00"),
+				punchAt(2, models.PunchTypeOut, app, "11:00"),
+				punchAt(3, models.PunchTypeIn, app, "13:00"),
+				punchAt(4, models.PunchTypeOut, app, "18:00"),
+			},
+			clockIn: "08:00", clockOut: "18:00", breaks: 2 * time.Hour, state: punchStateOff,
+		},
+		{
+			name: "out of sequence punches are ignored",
+			events: []models.PunchEvent{
+				punchAt(1, models.PunchTypeOut, app, "07:00"),
+				punchAt(2, models.PunchTypeIn, app, "08:00"),
+				punchAt(3, models.PunchTypeIn, app, "08:05"),
+				punchAt(4, models.PunchTypeBreakEnd, app, "12:00"),
+				punchAt(5, models.PunchTypeOut, app, "17:00"),
+			},
+			clockIn: "08:00", clockOut: "17:00", state: punchStateOff,
+		},
+		{
+			name: "events are replayed in time order",
+			events: []models.PunchEvent{
+				punchAt(1, models.PunchTypeOut, app, "17:00"),
+				punchAt(2, models.PunchTypeIn, app, "08:00"),
+			},
+			clockIn: "08:00", clockOut: "17:00", state: punchStateOff,
+		},
+		{
+			name: "auto punches alternate and the last one clocks out",
+			events: []models.PunchEvent{
+				punchAt(1, models.PunchTypeAuto, device, "08:00"),
+				punchAt(2, models.PunchTypeAuto, device, "12:00"),
+				punchAt(3, models.PunchTypeAuto, device, "13:00"),
+				punchAt(4, models.PunchTypeAuto, device, "17:00"),
+			},
+			clockIn: "08:00", clockOut: "17:00", breaks: time.Hour, state: punchStateOff,
+		},
+		{
+			name: "repeated device scans count once",
+			events: []models.PunchEvent{
+				punchAt(1, models.PunchTypeAuto, device, "08:00"),
+				punchAt(2, models.PunchTypeAuto, device, "08:01"),
+				punchAt(3, models.PunchTypeAuto, device, "08:02"),
+				punchAt(4, models.PunchTypeAuto, device, "17:00"),
+			},
+			clockIn: "08:00", clockOut: "17:00", state: punchStateOff,
+		},
+		{
+			name: "voided punches are ignored",
+			events: []models.PunchEvent{
+				punchAt(1, models.PunchTypeIn, app, "08:00"),
+				punchAt(2, models.PunchTypeOut, app, "09:00"),
+				voidOf(3, 2, "10:00"),
+				punchAt(4, models.PunchTypeOut, correction, "17:00"),
+			},
+			clockIn: "08:00", clockOut: "17:00", state: punchStateOff,
+		},
+		{
+			name: "manual entries replace recorded punches",
+			events: []models.PunchEvent{
+				punchAt(1, models.PunchTypeIn, app, "07:30"),
+				punchAt(2, models.PunchTypeOut, app, "19:00"),
+				punchAt(3, models.PunchTypeIn, manual, "08:00"),
+				punchAt(4, models.PunchTypeOut, manual, "17:00"),
+			},
+			clockIn: "08:00", clockOut: "17:00", state: punchStateOff, manual: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := summarizePunches(tt.events)
+
+			if got := punchClock(summary.clockIn); got != tt.clockIn {
+				t.Errorf("clock-in = %q, want %q", got, tt.clockIn)
+			}
+			if got := punchClock(summary.clockOut); got != tt.clockOut {
+				t.Errorf("clock-out = %q, want %q", got, tt.clockOut)
+			}
+			if summary.breaks != tt.breaks {
+				t.Errorf("breaks = %v, want %v", summary.breaks, tt.breaks)
+			}
+			if summary.state != tt.state {
+				t.Errorf("state = %q, want %q", summary.state, tt.state)
+			}
+			if summary.manual != tt.manual {
+				t.Errorf("manual = %v, want %v", summary.manual, tt.manual)
+			}
+		})
+	}
+}
+
+func punchClock(event *models.PunchEvent) string {
+	if event == nil {
+		return ""
+	}
+	return event.PunchedAt.Format("15:04")
+}
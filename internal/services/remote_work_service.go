@@ -0,0 +1,389 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"strings"
+	"time"
+)
+
+type RemoteWorkService struct {
+	remoteWorkRepo      *repositories.RemoteWorkRepository
+	employeeRepo        *repositories.EmployeeRepository
+	attendanceService   *AttendanceService
+	shiftService        *ShiftService
+	notificationService *NotificationService
+	timezoneService     *TimezoneService
+}
+
+func NewRemoteWorkService(remoteWorkRepo *repositories.RemoteWorkRepository, employeeRepo *repositories.EmployeeRepository, attendanceService *AttendanceService, shiftService *ShiftService, notificationService *NotificationService, timezoneService *TimezoneService) *RemoteWorkService {
+	return &RemoteWorkService{
+		remoteWorkRepo:      remoteWorkRepo,
+		employeeRepo:        employeeRepo,
+		attendanceService:   attendanceService,
+		shiftService:        shiftService,
+		notificationService: notificationService,
+		timezoneService:     timezoneService,
+	}
+}
+
+func (s *RemoteWorkService) GetPolicies() ([]models.RemoteWorkPolicy, error) {
+	return s.remoteWorkRepo.FindPolicies()
+}
+
+func (s *RemoteWorkService) CreatePolicy(req *models.RemoteWorkPolicyRequest) (*models.RemoteWorkPolicy, error) {
+	policy := &models.RemoteWorkPolicy{}
+	if err := s.applyPolicyRequest(policy, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.remoteWorkRepo.SavePolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (s *RemoteWorkService) UpdatePolicy(id uint, req *models.RemoteWorkPolicyRequest) (*models.RemoteWorkPolicy, error) {
+	policy, err := s.remoteWorkRepo.FindPolicyByID(id)
+	if err != nil {
+		return nil, errors.New("remote work policy not found")
+	}
+
+	if err := s.applyPolicyRequest(policy, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.remoteWorkRepo.SavePolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (s *RemoteWorkService) DeletePolicy(id uint) error {
+	if _, err := s.remoteWorkRepo.FindPolicyByID(id); err != nil {
+		return errors.New("remote work policy not found")
+	}
+
+	count, err := s.remoteWorkRepo.CountPolicyDepartments(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("cannot delete a remote work policy that is assigned to departments")
+	}
+
+	return s.remoteWorkRepo.DeletePolicy(id)
+}
+
+// SubmitRequest asks approval to work remotely from today or a later date. The
+// working days requested in each week, together with those of open requests,
+// must stay within the weekly quota of the employee's policy.
+func (s *RemoteWorkService) SubmitRequest(userID uint, req *models.SubmitRemoteWorkRequest) (*models.RemoteWorkRequest, error) {
+	employee, err := s.employeeRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("no employee profile is linked to this account")
+	}
+
+	startDate, endDate := dateOnly(req.StartDate.Time), dateOnly(req.EndDate.Time)
+	if err := validateDateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
+	if startDate.Before(localDate(time.Now(), s.timezoneService.For(employee))) {
+		return nil, errors.New("remote work must be requested before it starts")
+	}
+
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, errors.New("a reason is required")
+	}
+
+	policy, err := s.policyFor(employee)
+	if err != nil {
+		return nil, err
+	}
+
+	// Quota is counted per week, Monday to Sunday
+	weekStart := startOfWeek(startDate)
+	weekEnd := startOfWeek(endDate).AddDate(0, 0, 6)
+
+	open, err := s.remoteWorkRepo.FindOpenInRange(employee.ID, weekStart, weekEnd)
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range open {
+		if !request.StartDate.After(endDate) && !request.EndDate.Before(startDate) {
+			return nil, errors.New("a remote work request for these dates already exists")
+		}
+	}
+
+	schedules, err := s.shiftService.Schedules([]models.Employee{*employee}, weekStart, weekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	used := map[string]int{}
+	requested := map[string]bool{}
+	days := 0
+	for date := weekStart; !date.After(weekEnd); date = date.AddDate(0, 0, 1) {
+		if schedules[employee.ID][date.Format("2006-01-02")].DayOff {
+			continue
+		}
+		week := startOfWeek(date).Format("2006-01-02")
+		if !date.Before(startDate) && !date.After(endDate) {
+			used[week]++
+			requested[week] = true
+			days++
+			continue
+		}
+		for _, request := range open {
+			if !date.Before(dateOnly(request.StartDate)) && !date.After(dateOnly(request.EndDate)) {
+				used[week]++
+				break
+			}
+		}
+	}
+
+	if days == 0 {
+		return nil, errors.New("the requested dates contain no working days")
+	}
+	for week := range requested {
+		if count := used[week]; count > policy.WeeklyQuota {
+			return nil, fmt.Errorf("remote work is limited to %d days a week; the week of %s would have %d", policy.WeeklyQuota, week, count)
+		}
+	}
+
+	request := &models.RemoteWorkRequest{
+		EmployeeID: employee.ID,
+		Type:       req.Type,
+		StartDate:  startDate,
+		EndDate:    endDate,
+		Days:       days,
+		Reason:     strings.TrimSpace(req.Reason),
+		Status:     models.RemoteWorkPending,
+	}
+	if err := s.remoteWorkRepo.Create(request); err != nil {
+		return nil, err
+	}
+
+	s.notifyApprovers(employee, request)
+	return request, nil
+}
+
+func (s *RemoteWorkService) GetMyRequests(userID uint) ([]models.RemoteWorkRequest, error) {
+	employee, err := s.employeeRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("no employee profile is linked to this account")
+	}
+	return s.remoteWorkRepo.FindByEmployee(employee.ID)
+}
+
+func (s *RemoteWorkService) CancelRequest(id, userID uint) error {
+	request, err := s.remoteWorkRepo.FindByID(id)
+	if err != nil {
+		return errors.New("remote work request not found")
+	}
+
+	if request.Employee == nil || request.Employee.UserID == nil || *request.Employee.UserID != userID {
+		return errors.New("remote work request not found")
+	}
+
+	if request.Status != models.RemoteWorkPending {
+		return errors.New("only pending requests can be cancelled")
+	}
+
+	request.Status = models.RemoteWorkCancelled
+	return s.remoteWorkRepo.Update(request)
+}
+
+func (s *RemoteWorkService) GetRequests(status string, employeeID, departmentID *uint, page, limit int) ([]models.RemoteWorkRequest, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	return s.remoteWorkRepo.FindAll(status, employeeID, departmentID, page, limit)
+}
+
+func (s *RemoteWorkService) GetRequestByID(id uint) (*models.RemoteWorkRequest, error) {
+	request, err := s.remoteWorkRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("remote work request not found")
+	}
+	return request, nil
+}
+
+// ApproveRequest approves the remote work; attendance already recorded within the
+// range is derived again so it is tagged remote
+func (s *RemoteWorkService) ApproveRequest(id, reviewerID uint, req *models.ReviewRemoteWorkRequest) (*models.RemoteWorkRequest, error) {
+	request, err := s.reviewableRequest(id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err = s.completeReview(request, models.RemoteWorkApproved, reviewerID, req.Notes)
+	if err != nil {
+		return nil, err
+	}
+
+	for date := dateOnly(request.StartDate); !date.After(dateOnly(request.EndDate)); date = date.AddDate(0, 0, 1) {
+		if err := s.attendanceService.refreshAttendance(request.EmployeeID, date); err != nil {
+			return nil, err
+		}
+	}
+	return request, nil
+}
+
+func (s *RemoteWorkService) RejectRequest(id, reviewerID uint, req *models.ReviewRemoteWorkRequest) (*models.RemoteWorkRequest, error) {
+	if strings.TrimSpace(req.Notes) == "" {
+		return nil, errors.New("a reason is required when rejecting a request")
+	}
+
+	request, err := s.reviewableRequest(id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.completeReview(request, models.RemoteWorkRejected, reviewerID, req.Notes)
+}
+
+// GetCalendar lists who works remotely on each working day of the range, including
+// requests still awaiting approval
+func (s *RemoteWorkService) GetCalendar(startDate, endDate time.Time, departmentID *uint) ([]models.RemoteWorkCalendarEntry, error) {
+	startDate, endDate = dateOnly(startDate), dateOnly(endDate)
+	if err := validateDateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	requests, err := s.remoteWorkRepo.FindCalendar(startDate, endDate, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	employees := []models.Employee{}
+	seen := map[uint]bool{}
+	for _, request := range requests {
+		if request.Employee != nil && !seen[request.EmployeeID] {
+			seen[request.EmployeeID] = true
+			employees = append(employees, *request.Employee)
+		}
+	}
+
+	schedules, err := s.shiftService.Schedules(employees, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []models.RemoteWorkCalendarEntry{}
+	for _, request := range requests {
+		if request.Employee == nil {
+			continue
+		}
+		from, to := dateOnly(request.StartDate), dateOnly(request.EndDate)
+		if from.Before(startDate) {
+			from = startDate
+		}
+		if to.After(endDate) {
+			to = endDate
+		}
+		for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+			if schedules[request.EmployeeID][date.Format("2006-01-02")].DayOff {
+				continue
+			}
+			entries = append(entries, models.RemoteWorkCalendarEntry{
+				Date:         date,
+				RequestID:    request.ID,
+				EmployeeID:   request.EmployeeID,
+				EmployeeName: request.Employee.FirstName + " " + request.Employee.LastName,
+				DepartmentID: request.Employee.DepartmentID,
+				Type:         request.Type,
+				Status:       request.Status,
+			})
+		}
+	}
+	return entries, nil
+}
+
+// policyFor returns the policy of the employee's department, or the default policy
+func (s *RemoteWorkService) policyFor(employee *models.Employee) (*models.RemoteWorkPolicy, error) {
+	if employee.Department != nil && employee.Department.RemoteWorkPolicyID != nil {
+		if policy, err := s.remoteWorkRepo.FindPolicyByID(*employee.Department.RemoteWorkPolicyID); err == nil {
+			return policy, nil
+		}
+	}
+	policy, err := s.remoteWorkRepo.FindDefaultPolicy()
+	if err != nil {
+		return nil, errors.New("remote work is not available to you")
+	}
+	return policy, nil
+}
+
+func (s *RemoteWorkService) applyPolicyRequest(policy *models.RemoteWorkPolicy, req *models.RemoteWorkPolicyRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if existing, err := s.remoteWorkRepo.FindPolicyByName(name); err == nil && existing.ID != policy.ID {
+		return errors.New("a remote work policy with this name already exists")
+	}
+
+	policy.Name = name
+	policy.Description = req.Description
+	policy.WeeklyQuota = req.WeeklyQuota
+	policy.IsDefault = req.IsDefault
+	return nil
+}
+
+func (s *RemoteWorkService) reviewableRequest(id, reviewerID uint) (*models.RemoteWorkRequest, error) {
+	request, err := s.remoteWorkRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("remote work request not found")
+	}
+
+	if request.Status != models.RemoteWorkPending {
+		return nil, errors.New("remote work request has already been reviewed")
+	}
+
+	if request.Employee != nil && request.Employee.UserID != nil && *request.Employee.UserID == reviewerID {
+		return nil, errors.New("you cannot review your own remote work request")
+	}
+
+	return request, nil
+}
+
+func (s *RemoteWorkService) completeReview(request *models.RemoteWorkRequest, status string, reviewerID uint, notes string) (*models.RemoteWorkRequest, error) {
+	now := time.Now()
+	request.Status = status
+	request.ReviewedBy = &reviewerID
+	request.ReviewedAt = &now
+	request.ReviewNotes = notes
+
+	if err := s.remoteWorkRepo.Update(request); err != nil {
+		return nil, err
+	}
+
+	if request.Employee != nil && request.Employee.UserID != nil {
+		message := fmt.Sprintf("Your remote work request for %s to %s was %s", request.StartDate.Format("2006-01-02"), request.EndDate.Format("2006-01-02"), status)
+		if notes != "" {
+			message += ": " + notes
+		}
+		s.notificationService.Notify(*request.Employee.UserID, "remote_work", "Remote work request "+status, message, "/kerja-jarak-jauh/saya")
+	}
+
+	return request, nil
+}
+
+// notifyApprovers tells the department manager, or HR when there is none, about a new request
+func (s *RemoteWorkService) notifyApprovers(employee *models.Employee, request *models.RemoteWorkRequest) {
+	title := "Remote work requested"
+	message := fmt.Sprintf("%s %s requested to work remotely from %s to %s (%d days)", employee.FirstName, employee.LastName,
+		request.StartDate.Format("2006-01-02"), request.EndDate.Format("2006-01-02"), request.Days)
+	link := fmt.Sprintf("/kerja-jarak-jauh/%d", request.ID)
+
+	if employee.Department != nil && employee.Department.ManagerID != nil && *employee.Department.ManagerID != employee.ID {
+		manager, err := s.employeeRepo.FindByID(*employee.Department.ManagerID)
+		if err == nil && manager.UserID != nil {
+			s.notificationService.Notify(*manager.UserID, "remote_work", title, message, link)
+			return
+		}
+	}
+	s.notificationService.NotifyRoles([]string{"hr_manager"}, "remote_work", title, message, link)
+}
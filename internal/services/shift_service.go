@@ -0,0 +1,449 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ShiftService struct {
+	shiftRepo      *repositories.ShiftRepository
+	employeeRepo   *repositories.EmployeeRepository
+	holidayService *HolidayService
+}
+
+func NewShiftService(shiftRepo *repositories.ShiftRepository, employeeRepo *repositories.EmployeeRepository, holidayService *HolidayService) *ShiftService {
+	return &ShiftService{
+		shiftRepo:      shiftRepo,
+		employeeRepo:   employeeRepo,
+		holidayService: holidayService,
+	}
+}
+
+func (s *ShiftService) GetShifts() ([]models.Shift, error) {
+	return s.shiftRepo.FindAll()
+}
+
+func (s *ShiftService) GetShiftByID(id uint) (*models.Shift, error) {
+	shift, err := s.shiftRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("shift not found")
+	}
+	return shift, nil
+}
+
+func (s *ShiftService) CreateShift(req *models.ShiftRequest) (*models.Shift, error) {
+	shift := &models.Shift{IsActive: true}
+	if err := s.applyShiftRequest(shift, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.shiftRepo.Save(shift); err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+func (s *ShiftService) UpdateShift(id uint, req *models.ShiftRequest) (*models.Shift, error) {
+	shift, err := s.shiftRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("shift not found")
+	}
+
+	if err := s.applyShiftRequest(shift, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.shiftRepo.Save(shift); err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+// DeleteShift removes a shift that is no longer rostered from today onwards or used in a pattern
+func (s *ShiftService) DeleteShift(id uint) error {
+	if _, err := s.shiftRepo.FindByID(id); err != nil {
+		return errors.New("shift not found")
+	}
+
+	inUse, err := s.shiftRepo.CountUpcomingUse(id, dateOnly(time.Now()))
+	if err != nil {
+		return err
+	}
+	if inUse > 0 {
+		return errors.New("shift is still used in rosters or patterns; deactivate it instead")
+	}
+
+	return s.shiftRepo.Delete(id)
+}
+
+func (s *ShiftService) GetPatterns() ([]models.ShiftPattern, error) {
+	return s.shiftRepo.FindAllPatterns()
+}
+
+func (s *ShiftService) GetPatternByID(id uint) (*models.ShiftPattern, error) {
+	pattern, err := s.shiftRepo.FindPatternByID(id)
+	if err != nil {
+		return nil, errors.New("shift pattern not found")
+	}
+	return pattern, nil
+}
+
+func (s *ShiftService) CreatePattern(req *models.ShiftPatternRequest) (*models.ShiftPattern, error) {
+	slots, err := s.patternSlots(req.ShiftIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern := &models.ShiftPattern{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Slots:       slots,
+	}
+	if err := s.shiftRepo.CreatePattern(pattern); err != nil {
+		return nil, err
+	}
+
+	return s.shiftRepo.FindPatternByID(pattern.ID)
+}
+
+func (s *ShiftService) UpdatePattern(id uint, req *models.ShiftPatternRequest) (*models.ShiftPattern, error) {
+	pattern, err := s.shiftRepo.FindPatternByID(id)
+	if err != nil {
+		return nil, errors.New("shift pattern not found")
+	}
+
+	slots, err := s.patternSlots(req.ShiftIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range slots {
+		slots[i].PatternID = pattern.ID
+	}
+
+	pattern.Name = strings.TrimSpace(req.Name)
+	pattern.Description = req.Description
+	pattern.Slots = nil
+
+	if err := s.shiftRepo.UpdatePattern(pattern, slots); err != nil {
+		return nil, err
+	}
+
+	return s.shiftRepo.FindPatternByID(id)
+}
+
+func (s *ShiftService) DeletePattern(id uint) error {
+	if _, err := s.shiftRepo.FindPatternByID(id); err != nil {
+		return errors.New("shift pattern not found")
+	}
+	return s.shiftRepo.DeletePattern(id)
+}
+
+func (s *ShiftService) GetRoster(startDate, endDate time.Time, employeeID, departmentID *uint) ([]models.RosterEntry, error) {
+	startDate, endDate = dateOnly(startDate), dateOnly(endDate)
+	if err := validateDateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
+	return s.shiftRepo.FindRoster(startDate, endDate, employeeID, departmentID)
+}
+
+// GetMySchedule returns the roster of the employee linked to the user
+func (s *ShiftService) GetMySchedule(userID uint, startDate, endDate time.Time) ([]models.RosterEntry, error) {
+	employee, err := s.employeeRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("no employee profile is linked to this account")
+	}
+	return s.GetRoster(startDate, endDate, &employee.ID, nil)
+}
+
+// SetRoster assigns shifts (or days off) to employees on specific dates,
+// replacing whatever was rostered before
+func (s *ShiftService) SetRoster(req *models.SetRosterRequest) ([]models.RosterEntry, error) {
+	employees := map[uint]bool{}
+	shifts := map[uint]bool{}
+	seen := map[string]bool{}
+
+	entries := make([]models.RosterEntry, 0, len(req.Entries))
+	for _, item := range req.Entries {
+		if !employees[item.EmployeeID] {
+			if _, err := s.employeeRepo.FindByID(item.EmployeeID); err != nil {
+				return nil, fmt.Errorf("employee %d not found", item.EmployeeID)
+			}
+			employees[item.EmployeeID] = true
+		}
+
+		if item.ShiftID != nil && !shifts[*item.ShiftID] {
+			if err := s.ensureActiveShift(*item.ShiftID); err != nil {
+				return nil, err
+			}
+			shifts[*item.ShiftID] = true
+		}
+
+		date := dateOnly(item.Date.Time)
+		key := fmt.Sprintf("%d/%s", item.EmployeeID, date.Format("2006-01-02"))
+		if seen[key] {
+			return nil, fmt.Errorf("employee %d is rostered twice on %s", item.EmployeeID, date.Format("2006-01-02"))
+		}
+		seen[key] = true
+
+		entries = append(entries, models.RosterEntry{
+			EmployeeID: item.EmployeeID,
+			Date:       date,
+			ShiftID:    item.ShiftID,
+		})
+	}
+
+	if err := s.shiftRepo.UpsertRoster(entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ApplyPattern rosters employees following a rotating pattern over a date range
+func (s *ShiftService) ApplyPattern(req *models.ApplyShiftPatternRequest) (int, error) {
+	pattern, err := s.shiftRepo.FindPatternByID(req.PatternID)
+	if err != nil {
+		return 0, errors.New("shift pattern not found")
+	}
+	if len(pattern.Slots) == 0 {
+		return 0, errors.New("shift pattern has no days")
+	}
+
+	for _, slot := range pattern.Slots {
+		if slot.ShiftID != nil {
+			if err := s.ensureActiveShift(*slot.ShiftID); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	startDate, endDate := dateOnly(req.StartDate.Time), dateOnly(req.EndDate.Time)
+	if err := validateDateRange(startDate, endDate); err != nil {
+		return 0, err
+	}
+
+	var entries []models.RosterEntry
+	for _, employeeID := range req.EmployeeIDs {
+		if _, err := s.employeeRepo.FindByID(employeeID); err != nil {
+			return 0, fmt.Errorf("employee %d not found", employeeID)
+		}
+
+		day := 0
+		for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+			slot := pattern.Slots[(req.StartDay+day)%len(pattern.Slots)]
+			entries = append(entries, models.RosterEntry{
+				EmployeeID: employeeID,
+				Date:       date,
+				ShiftID:    slot.ShiftID,
+				PatternID:  &pattern.ID,
+			})
+			day++
+		}
+	}
+
+	if err := s.shiftRepo.UpsertRoster(entries); err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+func (s *ShiftService) DeleteRosterEntry(id uint) error {
+	if _, err := s.shiftRepo.FindRosterEntryByID(id); err != nil {
+		return errors.New("roster entry not found")
+	}
+	return s.shiftRepo.DeleteRosterEntry(id)
+}
+
+// ScheduleFor returns what an employee is expected to work on a date
+func (s *ShiftService) ScheduleFor(employeeID uint, date time.Time) (*models.DaySchedule, error) {
+	date = dateOnly(date)
+
+	entry, err := s.shiftRepo.FindRosterEntry(employeeID, date)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		entry = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	defaultShift, err := s.defaultShift()
+	if err != nil {
+		return nil, err
+	}
+
+	holidays, err := s.holidayService.HolidaysFor(employeeID, date, date)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := resolveSchedule(entry, defaultShift, holidays[date.Format("2006-01-02")], date)
+	return &schedule, nil
+}
+
+// Schedules returns the schedule of every employee for each day of the range,
+// keyed by employee ID and date
+func (s *ShiftService) Schedules(employees []models.Employee, startDate, endDate time.Time) (map[uint]map[string]models.DaySchedule, error) {
+	startDate, endDate = dateOnly(startDate), dateOnly(endDate)
+
+	employeeIDs := make([]uint, 0, len(employees))
+	for _, employee := range employees {
+		employeeIDs = append(employeeIDs, employee.ID)
+	}
+
+	entries, err := s.shiftRepo.FindRosterForEmployees(employeeIDs, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	roster := map[uint]map[string]*models.RosterEntry{}
+	for i := range entries {
+		entry := &entries[i]
+		if roster[entry.EmployeeID] == nil {
+			roster[entry.EmployeeID] = map[string]*models.RosterEntry{}
+		}
+		roster[entry.EmployeeID][entry.Date.Format("2006-01-02")] = entry
+	}
+
+	defaultShift, err := s.defaultShift()
+	if err != nil {
+		return nil, err
+	}
+
+	holidays, err := s.holidayService.HolidaysForEmployees(employees, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make(map[uint]map[string]models.DaySchedule, len(employees))
+	for _, employee := range employees {
+		days := map[string]models.DaySchedule{}
+		for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+			key := date.Format("2006-01-02")
+			days[key] = resolveSchedule(roster[employee.ID][key], defaultShift, holidays[employee.ID][key], date)
+		}
+		schedules[employee.ID] = days
+	}
+	return schedules, nil
+}
+
+// WorkingDays counts the days within the range the employee is scheduled to work
+func (s *ShiftService) WorkingDays(employeeID uint, startDate, endDate time.Time) (int, error) {
+	employee, err := s.employeeRepo.FindByID(employeeID)
+	if err != nil {
+		return 0, errors.New("employee not found")
+	}
+
+	schedules, err := s.Schedules([]models.Employee{*employee}, startDate, endDate)
+	if err != nil {
+		return 0, err
+	}
+
+	days := 0
+	for _, schedule := range schedules[employeeID] {
+		if !schedule.DayOff {
+			days++
+		}
+	}
+	return days, nil
+}
+
+// defaultShift returns the shift used on days without a roster entry, or nil when none is set
+func (s *ShiftService) defaultShift() (*models.Shift, error) {
+	shift, err := s.shiftRepo.FindDefault()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return shift, err
+}
+
+// resolveSchedule applies the scheduling rules to a day: a roster entry wins, so
+// employees can be rostered on holidays; otherwise holidays and weekends are days
+// off and weekdays follow the default shift. Weekdays without a default shift are
+// working days with no shift.
+func resolveSchedule(entry *models.RosterEntry, defaultShift *models.Shift, holiday *models.Holiday, date time.Time) models.DaySchedule {
+	schedule := models.DaySchedule{Date: date, DayType: models.DayTypeWorkday, Holiday: holiday}
+
+	switch {
+	case entry != nil:
+		schedule.Shift = entry.Shift
+		schedule.DayOff = entry.ShiftID == nil
+		if schedule.DayOff {
+			schedule.DayType = models.DayTypeRestDay
+		}
+	case holiday != nil:
+		schedule.DayOff = true
+	case isWeekend(date):
+		schedule.DayOff = true
+		schedule.DayType = models.DayTypeRestDay
+	default:
+		schedule.Shift = defaultShift
+	}
+
+	if holiday != nil {
+		schedule.DayType = models.DayTypeHoliday
+	}
+	return schedule
+}
+
+func isWeekend(date time.Time) bool {
+	return date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+}
+
+func (s *ShiftService) applyShiftRequest(shift *models.Shift, req *models.ShiftRequest) error {
+	if !models.ValidShiftClock(req.StartTime) || !models.ValidShiftClock(req.EndTime) {
+		return errors.New("start and end times must use the HH:MM format")
+	}
+	if req.StartTime == req.EndTime {
+		return errors.New("start and end times cannot be equal")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if existing, err := s.shiftRepo.FindByName(name); err == nil && existing.ID != shift.ID {
+		return errors.New("a shift with this name already exists")
+	}
+
+	shift.Name = name
+	shift.StartTime = req.StartTime
+	shift.EndTime = req.EndTime
+	shift.BreakMinutes = req.BreakMinutes
+	shift.GraceMinutes = req.GraceMinutes
+	shift.IsDefault = req.IsDefault
+	if req.IsActive != nil {
+		shift.IsActive = *req.IsActive
+	}
+
+	if shift.ScheduledHours() <= 0 {
+		return errors.New("break cannot be longer than the shift")
+	}
+	if shift.IsDefault && !shift.IsActive {
+		return errors.New("the default shift must be active")
+	}
+	return nil
+}
+
+func (s *ShiftService) patternSlots(shiftIDs []*uint) ([]models.ShiftPatternSlot, error) {
+	slots := make([]models.ShiftPatternSlot, 0, len(shiftIDs))
+	for i, shiftID := range shiftIDs {
+		if shiftID != nil {
+			if err := s.ensureActiveShift(*shiftID); err != nil {
+				return nil, err
+			}
+		}
+		slots = append(slots, models.ShiftPatternSlot{DayIndex: i, ShiftID: shiftID})
+	}
+	return slots, nil
+}
+
+func (s *ShiftService) ensureActiveShift(id uint) error {
+	shift, err := s.shiftRepo.FindByID(id)
+	if err != nil {
+		return fmt.Errorf("shift %d not found", id)
+	}
+	if !shift.IsActive {
+		return fmt.Errorf("shift %s is inactive", shift.Name)
+	}
+	return nil
+}
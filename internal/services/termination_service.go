@@ -0,0 +1,474 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// workingDaysPerMonth converts a monthly wage into a daily wage for leave encashment (5-day week)
+const workingDaysPerMonth = 21
+
+// defaultSeveranceRules follow PP 35/2021; HR can adjust them per termination type
+var defaultSeveranceRules = []models.SeveranceRule{
+	{TerminationType: "resignation", SeveranceMultiplier: 0, ServiceAwardMultiplier: 0, LeaveEncashment: true, Description: "Voluntary resignation"},
+	{TerminationType: "layoff", SeveranceMultiplier: 1, ServiceAwardMultiplier: 1, LeaveEncashment: true, Description: "Efficiency or restructuring"},
+	{TerminationType: "misconduct", SeveranceMultiplier: 0, ServiceAwardMultiplier: 0, LeaveEncashment: true, Description: "Urgent violation of company regulations"},
+	{TerminationType: "disciplinary", SeveranceMultiplier: 0.5, ServiceAwardMultiplier: 1, LeaveEncashment: true, Description: "Violation after written warnings"},
+	{TerminationType: "contract_end", SeveranceMultiplier: 0, ServiceAwardMultiplier: 0, LeaveEncashment: true, Description: "Fixed-term contract expired"},
+	{TerminationType: "retirement", SeveranceMultiplier: 1.75, ServiceAwardMultiplier: 1, LeaveEncashment: true, Description: "Retirement age reached"},
+	{TerminationType: "death", SeveranceMultiplier: 2, ServiceAwardMultiplier: 1, LeaveEncashment: true, Description: "Employee passed away"},
+}
+
+var defaultExitChecklist = []models.ExitChecklistItem{
+	{Title: "Hand over ongoing work and documents", Category: "handover"},
+	{Title: "Return company assets", Category: "assets"},
+	{Title: "Revoke system and building access", Category: "access"},
+	{Title: "Conduct exit interview", Category: "interview"},
+	{Title: "Pay final settlement", Category: "settlement"},
+	{Title: "Issue employment certificate", Category: "documents"},
+}
+
+type TerminationService struct {
+	terminationRepo     *repositories.TerminationRepository
+	employeeRepo        *repositories.EmployeeRepository
+	assetRepo           *repositories.AssetRepository
+	payrollRepo         *repositories.PayrollRepository
+	leaveService        *LeaveService
+	overtimeService     *OvertimeService
+	notificationService *NotificationService
+	db                  *gorm.DB
+}
+
+func NewTerminationService(terminationRepo *repositories.TerminationRepository, employeeRepo *repositories.EmployeeRepository, assetRepo *repositories.AssetRepository, payrollRepo *repositories.PayrollRepository, leaveService *LeaveService, overtimeService *OvertimeService, notificationService *NotificationService, db *gorm.DB) *TerminationService {
+	return &TerminationService{
+		terminationRepo:     terminationRepo,
+		employeeRepo:        employeeRepo,
+		assetRepo:           assetRepo,
+		payrollRepo:         payrollRepo,
+		leaveService:        leaveService,
+		overtimeService:     overtimeService,
+		notificationService: notificationService,
+		db:                  db,
+	}
+}
+
+// TerminateEmployee schedules the termination, calculates the final settlement and
+// creates the exit checklist. Past termination dates take effect immediately.
+func (s *TerminationService) TerminateEmployee(employeeID, initiatedBy uint, req *models.CreateTerminationRequest) (*models.Termination, error) {
+	employee, err := s.employeeRepo.FindByID(employeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+
+	if !models.CanTransitionEmploymentStatus(employee.EmploymentStatus, separationStatus(req.TerminationType)) {
+		return nil, fmt.Errorf("employee with status %s cannot be terminated", employee.EmploymentStatus)
+	}
+
+	if _, err := s.terminationRepo.FindOpenByEmployee(employeeID); err == nil {
+		return nil, errors.New("employee already has a termination in progress")
+	}
+
+	terminationDate := dateOnly(req.TerminationDate.Time)
+	if terminationDate.Before(dateOnly(employee.HireDate)) {
+		return nil, errors.New("termination date must be after hire date")
+	}
+
+	noticeDate := dateOnly(time.Now())
+	if req.NoticeDate != nil {
+		noticeDate = dateOnly(req.NoticeDate.Time)
+	}
+
+	termination := &models.Termination{
+		EmployeeID:      employee.ID,
+		TerminationType: req.TerminationType,
+		NoticeDate:      noticeDate,
+		TerminationDate: terminationDate,
+		Reason:          req.Reason,
+		Notes:           req.Notes,
+		Status:          models.TerminationScheduled,
+		InitiatedBy:     initiatedBy,
+	}
+
+	if err := s.calculateSettlement(termination, employee); err != nil {
+		return nil, err
+	}
+
+	assets, err := s.assetRepo.FindByEmployee(employee.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range defaultExitChecklist {
+		// Held assets get one item each, ticked off when the asset is returned
+		if item.Category == "assets" && len(assets) > 0 {
+			for i := range assets {
+				termination.ChecklistItems = append(termination.ChecklistItems, models.ExitChecklistItem{
+					Title:    assetReturnTitle(&assets[i]),
+					Category: item.Category,
+					AssetID:  &assets[i].ID,
+				})
+			}
+			continue
+		}
+		termination.ChecklistItems = append(termination.ChecklistItems, models.ExitChecklistItem{
+			Title:    item.Title,
+			Category: item.Category,
+		})
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(termination).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Employee{}).Where("id = ?", employee.ID).
+			Update("termination_date", terminationDate).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if terminationDate.Before(dateOnly(time.Now())) {
+		if err := s.completeTermination(termination); err != nil {
+			return nil, err
+		}
+	}
+
+	s.notificationService.NotifyRoles([]string{"hr_manager"}, "termination", "Employee termination scheduled",
+		fmt.Sprintf("%s %s leaves on %s (%s)", employee.FirstName, employee.LastName, terminationDate.Format("2006-01-02"), req.TerminationType),
+		fmt.Sprintf("/pemberhentian/%d", termination.ID))
+
+	return s.terminationRepo.FindByID(termination.ID)
+}
+
+func (s *TerminationService) GetTerminations(status string, page, limit int) ([]models.Termination, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	return s.terminationRepo.FindAll(status, page, limit)
+}
+
+func (s *TerminationService) GetTerminationByID(id uint) (*models.Termination, error) {
+	return s.terminationRepo.FindByID(id)
+}
+
+// RecalculateSettlement refreshes the settlement, e.g. after a salary or leave balance
+// correction or when overtime of the final month is approved
+func (s *TerminationService) RecalculateSettlement(id uint) (*models.Termination, error) {
+	termination, err := s.terminationRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if termination.Status == models.TerminationCancelled {
+		return nil, errors.New("termination is cancelled")
+	}
+
+	employee, err := s.employeeRepo.FindByID(termination.EmployeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+
+	if err := s.calculateSettlement(termination, employee); err != nil {
+		return nil, err
+	}
+
+	if err := s.terminationRepo.Update(termination); err != nil {
+		return nil, err
+	}
+
+	return s.terminationRepo.FindByID(id)
+}
+
+func (s *TerminationService) CancelTermination(id uint) (*models.Termination, error) {
+	termination, err := s.terminationRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if termination.Status != models.TerminationScheduled {
+		return nil, errors.New("only scheduled terminations can be cancelled")
+	}
+
+	termination.Status = models.TerminationCancelled
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Termination{}).Where("id = ?", id).
+			Update("status", models.TerminationCancelled).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Employee{}).Where("id = ?", termination.EmployeeID).
+			Update("termination_date", nil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.terminationRepo.FindByID(id)
+}
+
+func (s *TerminationService) CompleteChecklistItem(terminationID, itemID, userID uint, req *models.CompleteChecklistItemRequest) (*models.ExitChecklistItem, error) {
+	item, err := s.terminationRepo.FindChecklistItem(terminationID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Completed {
+		return nil, errors.New("checklist item is already completed")
+	}
+
+	if item.AssetID != nil {
+		return nil, errors.New("this item is completed by recording the asset return")
+	}
+
+	now := time.Now()
+	item.Completed = true
+	item.CompletedAt = &now
+	item.CompletedBy = &userID
+	item.Notes = req.Notes
+
+	if err := s.terminationRepo.UpdateChecklistItem(item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// ProcessDueTerminations deactivates employees whose last working day has passed
+func (s *TerminationService) ProcessDueTerminations() error {
+	terminations, err := s.terminationRepo.FindDue(dateOnly(time.Now()))
+	if err != nil {
+		return err
+	}
+
+	for i := range terminations {
+		if err := s.completeTermination(&terminations[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *TerminationService) GetSeveranceRules() ([]models.SeveranceRule, error) {
+	for _, rule := range defaultSeveranceRules {
+		if _, err := s.terminationRepo.FindRuleByType(rule.TerminationType); errors.Is(err, gorm.ErrRecordNotFound) {
+			rule := rule
+			if err := s.terminationRepo.SaveRule(&rule); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return s.terminationRepo.FindAllRules()
+}
+
+func (s *TerminationService) UpdateSeveranceRule(terminationType string, req *models.UpdateSeveranceRuleRequest) (*models.SeveranceRule, error) {
+	rule, err := s.severanceRule(terminationType)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.SeveranceMultiplier != nil {
+		rule.SeveranceMultiplier = *req.SeveranceMultiplier
+	}
+	if req.ServiceAwardMultiplier != nil {
+		rule.ServiceAwardMultiplier = *req.ServiceAwardMultiplier
+	}
+	if req.LeaveEncashment != nil {
+		rule.LeaveEncashment = *req.LeaveEncashment
+	}
+	if req.Description != "" {
+		rule.Description = req.Description
+	}
+
+	if err := s.terminationRepo.SaveRule(rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *TerminationService) completeTermination(termination *models.Termination) error {
+	employee, err := s.employeeRepo.FindByID(termination.EmployeeID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := transitionEmploymentStatus(tx, employee, separationStatus(termination.TerminationType),
+			termination.TerminationDate, termination.Reason, &termination.InitiatedBy); err != nil {
+			return err
+		}
+
+		if employee.UserID != nil {
+			if err := tx.Model(&models.User{}).Where("id = ?", *employee.UserID).
+				Update("is_active", false).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.Termination{}).Where("id = ?", termination.ID).
+			Updates(map[string]interface{}{"status": models.TerminationCompleted, "completed_at": now}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	termination.Status = models.TerminationCompleted
+	termination.CompletedAt = &now
+	return nil
+}
+
+// calculateSettlement fills in the final settlement: salary and approved overtime for
+// the days worked in the final month, encashment of unused annual leave, and
+// severance plus service award scaled by the rule for the termination type. Payroll
+// skips the final month once the termination is scheduled; anything it paid for that
+// month before then is deducted.
+func (s *TerminationService) calculateSettlement(termination *models.Termination, employee *models.Employee) error {
+	rule, err := s.severanceRule(termination.TerminationType)
+	if err != nil {
+		return err
+	}
+
+	wage := float64(employee.Salary)
+	lastDay := termination.TerminationDate
+	hireDate := dateOnly(employee.HireDate)
+
+	termination.MonthlyWage = wage
+	termination.YearsOfService = math.Round(lastDay.Sub(hireDate).Hours()/24/365.25*100) / 100
+
+	// Pro-rated salary for the final month
+	monthStart := time.Date(lastDay.Year(), lastDay.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if hireDate.After(monthStart) {
+		monthStart = hireDate
+	}
+	daysInMonth := time.Date(lastDay.Year(), lastDay.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	daysWorked := int(lastDay.Sub(monthStart).Hours()/24) + 1
+	termination.ProratedSalary = roundCurrency(wage * float64(daysWorked) / float64(daysInMonth))
+
+	// Approved overtime of the final month, up to the last working day
+	overtime, err := s.overtimeService.OvertimeSummaryFor(employee, int(lastDay.Month()), lastDay.Year())
+	if err != nil {
+		return err
+	}
+	termination.OvertimeHours = 0
+	termination.OvertimePay = 0
+	for _, day := range overtime.Days {
+		if day.Date.After(lastDay) {
+			continue
+		}
+		termination.OvertimeHours += day.ApprovedHours
+		termination.OvertimePay += day.Amount
+	}
+	termination.OvertimeHours = roundHours(termination.OvertimeHours)
+
+	// Payroll generated before the termination was scheduled already paid the final
+	// month; only the difference is settled
+	termination.PayrollPaid = 0
+	payroll, err := s.payrollRepo.FindByEmployeeAndPeriod(employee.ID, int(lastDay.Month()), lastDay.Year())
+	if err == nil {
+		termination.PayrollPaid = payroll.BasicSalary + payroll.OvertimePay
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	// Unused annual leave
+	termination.UnusedLeaveDays = 0
+	termination.LeaveEncashment = 0
+	if rule.LeaveEncashment {
+		balances, err := s.leaveService.GetLeaveBalance(employee.ID)
+		if err != nil {
+			return err
+		}
+		for _, balance := range balances {
+			if balance.LeaveType == "annual" && balance.RemainingDays > 0 {
+				termination.UnusedLeaveDays = balance.RemainingDays
+			}
+		}
+		termination.LeaveEncashment = roundCurrency(float64(termination.UnusedLeaveDays) * wage / workingDaysPerMonth)
+	}
+
+	termination.SeverancePay = roundCurrency(severanceMonths(termination.YearsOfService) * rule.SeveranceMultiplier * wage)
+	termination.ServiceAwardPay = roundCurrency(serviceAwardMonths(termination.YearsOfService) * rule.ServiceAwardMultiplier * wage)
+	termination.TotalSettlement = settlementTotal(termination)
+
+	return nil
+}
+
+// settlementTotal adds up the settlement; salary and overtime already paid by
+// payroll are deducted, so an overpaid final month reduces the other components
+func settlementTotal(termination *models.Termination) float64 {
+	return roundCurrency(termination.ProratedSalary + termination.OvertimePay - termination.PayrollPaid +
+		termination.LeaveEncashment + termination.SeverancePay + termination.ServiceAwardPay)
+}
+
+func (s *TerminationService) severanceRule(terminationType string) (*models.SeveranceRule, error) {
+	rule, err := s.terminationRepo.FindRuleByType(terminationType)
+	if err == nil {
+		return rule, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	for _, defaultRule := range defaultSeveranceRules {
+		if defaultRule.TerminationType == terminationType {
+			rule := defaultRule
+			return &rule, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no severance rule for termination type %s", terminationType)
+}
+
+// separationStatus is the employment status an employee ends up in after leaving
+func separationStatus(terminationType string) string {
+	if terminationType == "resignation" {
+		return models.EmploymentStatusResigned
+	}
+	return models.EmploymentStatusTerminated
+}
+
+// severanceMonths is the statutory severance (uang pesangon) in months of wage
+func severanceMonths(years float64) float64 {
+	months := math.Floor(years) + 1
+	return math.Min(months, 9)
+}
+
+// serviceAwardMonths is the statutory service award (uang penghargaan masa kerja) in months of wage
+func serviceAwardMonths(years float64) float64 {
+	switch {
+	case years >= 24:
+		return 10
+	case years >= 21:
+		return 8
+	case years >= 18:
+		return 7
+	case years >= 15:
+		return 6
+	case years >= 12:
+		return 5
+	case years >= 9:
+		return 4
+	case years >= 6:
+		return 3
+	case years >= 3:
+		return 2
+	}
+	return 0
+}
+
+func roundCurrency(amount float64) float64 {
+	return math.Round(amount)
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"hr-backend/internal/models"
+	"testing"
+)
+
+func TestSettlementTotal(t *testing.T) {
+	tests := []struct {
+		name        string
+		termination models.Termination
+		want        float64
+	}{
+		{
+			name:        "no payroll for the final month",
+			termination: models.Termination{ProratedSalary: 5000000, OvertimePay: 250000, LeaveEncashment: 700000, SeverancePay: 20000000},
+			want:        25950000,
+		},
+		{
+			name:        "payroll already paid the final month in full",
+			termination: models.Termination{ProratedSalary: 10000000, OvertimePay: 250000, LeaveEncashment: 700000, PayrollPaid: 10250000},
+			want:        700000,
+		},
+		{
+			name:        "payroll overpaid a partial final month",
+			termination: models.Termination{ProratedSalary: 5000000, LeaveEncashment: 700000, SeverancePay: 20000000, PayrollPaid: 10000000},
+			want:        15700000,
+		},
+		{
+			name:        "service award is settled alongside payroll",
+			termination: models.Termination{ProratedSalary: 5000000, ServiceAwardPay: 20000000, PayrollPaid: 5000000},
+			want:        20000000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := settlementTotal(&tt.termination); got != tt.want {
+				t.Errorf("settlementTotal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,687 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hr-backend/internal/config"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"math"
+	"strings"
+	"time"
+)
+
+type TimesheetService struct {
+	timesheetRepo       *repositories.TimesheetRepository
+	attendanceRepo      *repositories.AttendanceRepository
+	employeeRepo        *repositories.EmployeeRepository
+	notificationService *NotificationService
+	timezoneService     *TimezoneService
+	cfg                 *config.Config
+}
+
+func NewTimesheetService(timesheetRepo *repositories.TimesheetRepository, attendanceRepo *repositories.AttendanceRepository, employeeRepo *repositories.EmployeeRepository, notificationService *NotificationService, timezoneService *TimezoneService, cfg *config.Config) *TimesheetService {
+	return &TimesheetService{
+		timesheetRepo:       timesheetRepo,
+		attendanceRepo:      attendanceRepo,
+		employeeRepo:        employeeRepo,
+		notificationService: notificationService,
+		timezoneService:     timezoneService,
+		cfg:                 cfg,
+	}
+}
+
+func (s *TimesheetService) GetProjects(status, search string) ([]models.Project, error) {
+	return s.timesheetRepo.FindProjects(status, strings.TrimSpace(search))
+}
+
+func (s *TimesheetService) GetProjectByID(id uint) (*models.Project, error) {
+	project, err := s.timesheetRepo.FindProjectByID(id)
+	if err != nil {
+		return nil, errors.New("project not found")
+	}
+	return project, nil
+}
+
+func (s *TimesheetService) CreateProject(req *models.ProjectRequest) (*models.Project, error) {
+	project := &models.Project{}
+	if err := s.applyProjectRequest(project, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.timesheetRepo.SaveProject(project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func (s *TimesheetService) UpdateProject(id uint, req *models.ProjectRequest) (*models.Project, error) {
+	project, err := s.timesheetRepo.FindProjectByID(id)
+	if err != nil {
+		return nil, errors.New("project not found")
+	}
+
+	if err := s.applyProjectRequest(project, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.timesheetRepo.SaveProject(project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// DeleteProject removes a project nobody logged hours on; others can only be closed
+func (s *TimesheetService) DeleteProject(id uint) error {
+	if _, err := s.timesheetRepo.FindProjectByID(id); err != nil {
+		return errors.New("project not found")
+	}
+
+	count, err := s.timesheetRepo.CountProjectEntries(id, nil)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("cannot delete a project with logged hours; close it instead")
+	}
+
+	return s.timesheetRepo.DeleteProject(id)
+}
+
+func (s *TimesheetService) AddTask(projectID uint, req *models.ProjectTaskRequest) (*models.ProjectTask, error) {
+	if _, err := s.timesheetRepo.FindProjectByID(projectID); err != nil {
+		return nil, errors.New("project not found")
+	}
+
+	task := &models.ProjectTask{
+		ProjectID:  projectID,
+		Name:       strings.TrimSpace(req.Name),
+		IsBillable: req.IsBillable,
+		IsActive:   req.IsActive == nil || *req.IsActive,
+	}
+	if err := s.timesheetRepo.SaveTask(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *TimesheetService) UpdateTask(projectID, taskID uint, req *models.ProjectTaskRequest) (*models.ProjectTask, error) {
+	task, err := s.timesheetRepo.FindTask(projectID, taskID)
+	if err != nil {
+		return nil, errors.New("task not found")
+	}
+
+	task.Name = strings.TrimSpace(req.Name)
+	task.IsBillable = req.IsBillable
+	if req.IsActive != nil {
+		task.IsActive = *req.IsActive
+	}
+
+	if err := s.timesheetRepo.SaveTask(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// DeleteTask removes a task nobody logged hours on; others can only be deactivated
+func (s *TimesheetService) DeleteTask(projectID, taskID uint) error {
+	if _, err := s.timesheetRepo.FindTask(projectID, taskID); err != nil {
+		return errors.New("task not found")
+	}
+
+	count, err := s.timesheetRepo.CountProjectEntries(projectID, &taskID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("cannot delete a task with logged hours; deactivate it instead")
+	}
+
+	return s.timesheetRepo.DeleteTask(taskID)
+}
+
+// AddEntry logs hours on a project for today or an earlier date, adding them to
+// the timesheet of that week
+func (s *TimesheetService) AddEntry(userID uint, req *models.TimesheetEntryRequest) (*models.TimesheetEntry, error) {
+	employee, err := s.employeeRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("no employee profile is linked to this account")
+	}
+
+	entry := &models.TimesheetEntry{EmployeeID: employee.ID}
+	if err := s.applyEntryRequest(employee, entry, req); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *TimesheetService) UpdateEntry(userID, entryID uint, req *models.TimesheetEntryRequest) (*models.TimesheetEntry, error) {
+	employee, entry, err := s.ownEntry(userID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := entry.TimesheetID
+	if _, err := s.editableTimesheet(previous); err != nil {
+		return nil, err
+	}
+
+	if err := s.applyEntryRequest(employee, entry, req); err != nil {
+		return nil, err
+	}
+
+	// The entry moved to another week
+	if entry.TimesheetID != previous {
+		if err := s.refreshTotals(previous); err != nil {
+			return nil, err
+		}
+	}
+	return entry, nil
+}
+
+func (s *TimesheetService) DeleteEntry(userID, entryID uint) error {
+	_, entry, err := s.ownEntry(userID, entryID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.editableTimesheet(entry.TimesheetID); err != nil {
+		return err
+	}
+
+	if err := s.timesheetRepo.DeleteEntry(entry.ID); err != nil {
+		return err
+	}
+	return s.refreshTotals(entry.TimesheetID)
+}
+
+func (s *TimesheetService) GetMyTimesheets(userID uint) ([]models.Timesheet, error) {
+	employee, err := s.employeeRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("no employee profile is linked to this account")
+	}
+	return s.timesheetRepo.FindByEmployee(employee.ID)
+}
+
+func (s *TimesheetService) GetMyTimesheet(userID, id uint) (*models.TimesheetDetail, error) {
+	timesheet, err := s.ownTimesheet(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.reconcile(timesheet)
+}
+
+// SubmitTimesheet sends a draft or rejected timesheet to the manager, recording
+// the attendance working hours of the week alongside it
+func (s *TimesheetService) SubmitTimesheet(userID, id uint) (*models.TimesheetDetail, error) {
+	timesheet, err := s.ownTimesheet(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if timesheet.Status != models.TimesheetDraft && timesheet.Status != models.TimesheetRejected {
+		return nil, errors.New("timesheet has already been submitted")
+	}
+
+	if len(timesheet.Entries) == 0 {
+		return nil, errors.New("cannot submit an empty timesheet")
+	}
+
+	detail, err := s.reconcile(timesheet)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	timesheet.Status = models.TimesheetSubmitted
+	timesheet.WorkedHours = detail.WorkedHours
+	timesheet.SubmittedAt = &now
+	timesheet.ReviewedBy = nil
+	timesheet.ReviewedAt = nil
+	timesheet.ReviewNotes = ""
+
+	if err := s.timesheetRepo.Update(timesheet); err != nil {
+		return nil, err
+	}
+
+	s.notifyApprovers(timesheet.Employee, detail)
+	return detail, nil
+}
+
+func (s *TimesheetService) GetTimesheets(status string, employeeID, departmentID *uint, page, limit int) ([]models.Timesheet, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	return s.timesheetRepo.FindAll(status, employeeID, departmentID, page, limit)
+}
+
+func (s *TimesheetService) GetTimesheetByID(id uint) (*models.TimesheetDetail, error) {
+	timesheet, err := s.timesheetRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("timesheet not found")
+	}
+	return s.reconcile(timesheet)
+}
+
+func (s *TimesheetService) ApproveTimesheet(id, reviewerID uint, req *models.ReviewTimesheetRequest) (*models.Timesheet, error) {
+	timesheet, err := s.reviewableTimesheet(id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.completeReview(timesheet, models.TimesheetApproved, reviewerID, req.Notes)
+}
+
+// RejectTimesheet returns the timesheet to the employee, who can correct and submit it again
+func (s *TimesheetService) RejectTimesheet(id, reviewerID uint, req *models.ReviewTimesheetRequest) (*models.Timesheet, error) {
+	if strings.TrimSpace(req.Notes) == "" {
+		return nil, errors.New("a reason is required when rejecting a timesheet")
+	}
+
+	timesheet, err := s.reviewableTimesheet(id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.completeReview(timesheet, models.TimesheetRejected, reviewerID, req.Notes)
+}
+
+// GetUtilizationReport compares the approved hours of every employee who logged
+// any within the range with their attendance working hours. Utilization is the
+// logged share of the working hours in percent, billable utilization the billable share.
+func (s *TimesheetService) GetUtilizationReport(filter models.TimesheetReportFilter) ([]models.EmployeeUtilization, error) {
+	filter.StartDate, filter.EndDate = dateOnly(filter.StartDate), dateOnly(filter.EndDate)
+	if err := validateDateRange(filter.StartDate, filter.EndDate); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.timesheetRepo.FindApprovedEntries(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	byEmployee := map[uint]*models.EmployeeUtilization{}
+	employeeIDs := []uint{}
+	for _, entry := range entries {
+		row, ok := byEmployee[entry.EmployeeID]
+		if !ok {
+			row = &models.EmployeeUtilization{EmployeeID: entry.EmployeeID}
+			byEmployee[entry.EmployeeID] = row
+			employeeIDs = append(employeeIDs, entry.EmployeeID)
+		}
+		row.LoggedHours += entry.Hours
+		if entry.Billable {
+			row.BillableHours += entry.Hours
+			if entry.Project != nil {
+				row.BillableAmount += entry.Hours * entry.Project.HourlyRate
+			}
+		}
+	}
+
+	employees, err := s.employeeRepo.FindByIDs(employeeIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, employee := range employees {
+		byEmployee[employee.ID].EmployeeName = employee.FirstName + " " + employee.LastName
+		byEmployee[employee.ID].DepartmentID = employee.DepartmentID
+	}
+
+	attendances, err := s.attendanceRepo.FindByEmployeesAndDateRange(employeeIDs, filter.StartDate, filter.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, attendance := range attendances {
+		byEmployee[attendance.EmployeeID].WorkedHours += attendance.WorkingHours
+	}
+
+	report := make([]models.EmployeeUtilization, 0, len(employeeIDs))
+	for _, id := range employeeIDs {
+		row := byEmployee[id]
+		row.WorkedHours = roundHours(row.WorkedHours)
+		row.LoggedHours = roundHours(row.LoggedHours)
+		row.BillableHours = roundHours(row.BillableHours)
+		row.BillableAmount = math.Round(row.BillableAmount)
+		if row.WorkedHours > 0 {
+			row.Utilization = percentOf(row.LoggedHours, row.WorkedHours)
+			row.BillableUtilization = percentOf(row.BillableHours, row.WorkedHours)
+		}
+		report = append(report, *row)
+	}
+	return report, nil
+}
+
+// GetProjectReport totals the approved hours logged on each project within the range
+func (s *TimesheetService) GetProjectReport(filter models.TimesheetReportFilter) ([]models.ProjectHoursReport, error) {
+	filter.StartDate, filter.EndDate = dateOnly(filter.StartDate), dateOnly(filter.EndDate)
+	if err := validateDateRange(filter.StartDate, filter.EndDate); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.timesheetRepo.FindApprovedEntries(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	byProject := map[uint]*models.ProjectHoursReport{}
+	employees := map[uint]map[uint]bool{}
+	projectIDs := []uint{}
+	for _, entry := range entries {
+		row, ok := byProject[entry.ProjectID]
+		if !ok {
+			row = &models.ProjectHoursReport{ProjectID: entry.ProjectID}
+			if entry.Project != nil {
+				row.Code = entry.Project.Code
+				row.Name = entry.Project.Name
+				row.ClientName = entry.Project.ClientName
+			}
+			byProject[entry.ProjectID] = row
+			employees[entry.ProjectID] = map[uint]bool{}
+			projectIDs = append(projectIDs, entry.ProjectID)
+		}
+		row.TotalHours += entry.Hours
+		if entry.Billable {
+			row.BillableHours += entry.Hours
+			if entry.Project != nil {
+				row.BillableAmount += entry.Hours * entry.Project.HourlyRate
+			}
+		}
+		employees[entry.ProjectID][entry.EmployeeID] = true
+	}
+
+	report := make([]models.ProjectHoursReport, 0, len(projectIDs))
+	for _, id := range projectIDs {
+		row := byProject[id]
+		row.TotalHours = roundHours(row.TotalHours)
+		row.BillableHours = roundHours(row.BillableHours)
+		row.BillableAmount = math.Round(row.BillableAmount)
+		row.Employees = len(employees[id])
+		report = append(report, *row)
+	}
+	return report, nil
+}
+
+func (s *TimesheetService) applyProjectRequest(project *models.Project, req *models.ProjectRequest) error {
+	code := strings.TrimSpace(req.Code)
+	if existing, err := s.timesheetRepo.FindProjectByCode(code); err == nil && existing.ID != project.ID {
+		return errors.New("project code already exists")
+	}
+
+	project.Code = code
+	project.Name = strings.TrimSpace(req.Name)
+	project.ClientName = strings.TrimSpace(req.ClientName)
+	project.Description = req.Description
+	project.IsBillable = req.IsBillable
+	project.HourlyRate = req.HourlyRate
+	project.Status = req.Status
+	if project.Status == "" {
+		project.Status = models.ProjectActive
+	}
+	return nil
+}
+
+// applyEntryRequest checks the project, task and date of an entry, files it under
+// the timesheet of its week and keeps the totals of that timesheet up to date
+func (s *TimesheetService) applyEntryRequest(employee *models.Employee, entry *models.TimesheetEntry, req *models.TimesheetEntryRequest) error {
+	date := dateOnly(req.Date.Time)
+	if date.After(localDate(time.Now(), s.timezoneService.For(employee))) {
+		return errors.New("hours cannot be logged for future dates")
+	}
+
+	project, err := s.timesheetRepo.FindProjectByID(req.ProjectID)
+	if err != nil {
+		return errors.New("project not found")
+	}
+	if project.Status != models.ProjectActive {
+		return errors.New("project is closed")
+	}
+
+	billable := project.IsBillable
+	if req.TaskID != nil {
+		task, err := s.timesheetRepo.FindTask(project.ID, *req.TaskID)
+		if err != nil {
+			return errors.New("task not found in this project")
+		}
+		if !task.IsActive {
+			return errors.New("task is no longer active")
+		}
+		billable = billable && task.IsBillable
+	}
+
+	timesheet, err := s.weekTimesheet(employee.ID, startOfWeek(date))
+	if err != nil {
+		return err
+	}
+
+	entries, err := s.timesheetRepo.FindEntries(timesheet.ID)
+	if err != nil {
+		return err
+	}
+	dayHours := req.Hours
+	for _, other := range entries {
+		if other.ID != entry.ID && other.Date.Equal(date) {
+			dayHours += other.Hours
+		}
+	}
+	if dayHours > 24 {
+		return fmt.Errorf("hours logged on %s cannot exceed 24", date.Format("2006-01-02"))
+	}
+
+	entry.TimesheetID = timesheet.ID
+	entry.ProjectID = project.ID
+	entry.TaskID = req.TaskID
+	entry.Date = date
+	entry.Hours = req.Hours
+	entry.Billable = billable
+	entry.Description = strings.TrimSpace(req.Description)
+
+	if err := s.timesheetRepo.SaveEntry(entry); err != nil {
+		return err
+	}
+	return s.refreshTotals(timesheet.ID)
+}
+
+// weekTimesheet returns the employee's timesheet for the week, starting a draft
+// when there is none, as long as it can still be changed
+func (s *TimesheetService) weekTimesheet(employeeID uint, weekStart time.Time) (*models.Timesheet, error) {
+	timesheet, err := s.timesheetRepo.FindByEmployeeAndWeek(employeeID, weekStart)
+	if err != nil {
+		timesheet = &models.Timesheet{
+			EmployeeID: employeeID,
+			WeekStart:  weekStart,
+			Status:     models.TimesheetDraft,
+		}
+		if err := s.timesheetRepo.Create(timesheet); err != nil {
+			return nil, err
+		}
+		return timesheet, nil
+	}
+
+	if timesheet.Status != models.TimesheetDraft && timesheet.Status != models.TimesheetRejected {
+		return nil, fmt.Errorf("the timesheet for the week of %s has already been submitted", weekStart.Format("2006-01-02"))
+	}
+	return timesheet, nil
+}
+
+func (s *TimesheetService) editableTimesheet(id uint) (*models.Timesheet, error) {
+	timesheet, err := s.timesheetRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("timesheet not found")
+	}
+
+	if timesheet.Status != models.TimesheetDraft && timesheet.Status != models.TimesheetRejected {
+		return nil, errors.New("entries of a submitted timesheet cannot be changed")
+	}
+	return timesheet, nil
+}
+
+func (s *TimesheetService) refreshTotals(timesheetID uint) error {
+	timesheet, err := s.timesheetRepo.FindByID(timesheetID)
+	if err != nil {
+		return err
+	}
+
+	timesheet.TotalHours, timesheet.BillableHours = 0, 0
+	for _, entry := range timesheet.Entries {
+		timesheet.TotalHours += entry.Hours
+		if entry.Billable {
+			timesheet.BillableHours += entry.Hours
+		}
+	}
+	timesheet.TotalHours = roundHours(timesheet.TotalHours)
+	timesheet.BillableHours = roundHours(timesheet.BillableHours)
+	return s.timesheetRepo.Update(timesheet)
+}
+
+// reconcile compares the hours logged on each day of the week with the attendance
+// working hours. Days differing by more than the configured tolerance are over or
+// under and count as discrepancies.
+func (s *TimesheetService) reconcile(timesheet *models.Timesheet) (*models.TimesheetDetail, error) {
+	weekEnd := dateOnly(timesheet.WeekStart).AddDate(0, 0, 6)
+	attendances, err := s.attendanceRepo.FindByEmployee(timesheet.EmployeeID, dateOnly(timesheet.WeekStart), weekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	logged := map[string]float64{}
+	for _, entry := range timesheet.Entries {
+		logged[entry.Date.Format("2006-01-02")] += entry.Hours
+	}
+	worked := map[string]float64{}
+	for _, attendance := range attendances {
+		worked[attendance.Date.Format("2006-01-02")] += attendance.WorkingHours
+	}
+
+	tolerance := float64(s.cfg.Timesheet.ToleranceMinutes) / 60
+	detail := &models.TimesheetDetail{
+		Timesheet:      timesheet,
+		Reconciliation: []models.TimesheetDay{},
+	}
+	for date := dateOnly(timesheet.WeekStart); !date.After(weekEnd); date = date.AddDate(0, 0, 1) {
+		key := date.Format("2006-01-02")
+		if logged[key] == 0 && worked[key] == 0 {
+			continue
+		}
+
+		day := models.TimesheetDay{
+			Date:        date,
+			LoggedHours: roundHours(logged[key]),
+			WorkedHours: roundHours(worked[key]),
+			Difference:  roundHours(logged[key] - worked[key]),
+			Status:      models.TimesheetDayMatch,
+		}
+		switch {
+		case day.Difference > tolerance:
+			day.Status = models.TimesheetDayOver
+		case day.Difference < -tolerance:
+			day.Status = models.TimesheetDayUnder
+		}
+		if day.Status != models.TimesheetDayMatch {
+			detail.Discrepancies++
+		}
+
+		detail.WorkedHours += worked[key]
+		detail.Reconciliation = append(detail.Reconciliation, day)
+	}
+
+	detail.WorkedHours = roundHours(detail.WorkedHours)
+	detail.Difference = roundHours(timesheet.TotalHours - detail.WorkedHours)
+	return detail, nil
+}
+
+func (s *TimesheetService) ownEntry(userID, entryID uint) (*models.Employee, *models.TimesheetEntry, error) {
+	employee, err := s.employeeRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, nil, errors.New("no employee profile is linked to this account")
+	}
+
+	entry, err := s.timesheetRepo.FindEntryByID(entryID)
+	if err != nil || entry.EmployeeID != employee.ID {
+		return nil, nil, errors.New("timesheet entry not found")
+	}
+	return employee, entry, nil
+}
+
+func (s *TimesheetService) ownTimesheet(userID, id uint) (*models.Timesheet, error) {
+	timesheet, err := s.timesheetRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("timesheet not found")
+	}
+
+	if timesheet.Employee == nil || timesheet.Employee.UserID == nil || *timesheet.Employee.UserID != userID {
+		return nil, errors.New("timesheet not found")
+	}
+	return timesheet, nil
+}
+
+func (s *TimesheetService) reviewableTimesheet(id, reviewerID uint) (*models.Timesheet, error) {
+	timesheet, err := s.timesheetRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("timesheet not found")
+	}
+
+	if timesheet.Status != models.TimesheetSubmitted {
+		return nil, errors.New("only submitted timesheets can be reviewed")
+	}
+
+	if timesheet.Employee != nil && timesheet.Employee.UserID != nil && *timesheet.Employee.UserID == reviewerID {
+		return nil, errors.New("you cannot review your own timesheet")
+	}
+
+	return timesheet, nil
+}
+
+func (s *TimesheetService) completeReview(timesheet *models.Timesheet, status string, reviewerID uint, notes string) (*models.Timesheet, error) {
+	now := time.Now()
+	timesheet.Status = status
+	timesheet.ReviewedBy = &reviewerID
+	timesheet.ReviewedAt = &now
+	timesheet.ReviewNotes = notes
+
+	if err := s.timesheetRepo.Update(timesheet); err != nil {
+		return nil, err
+	}
+
+	if timesheet.Employee != nil && timesheet.Employee.UserID != nil {
+		message := fmt.Sprintf("Your timesheet for the week of %s was %s", timesheet.WeekStart.Format("2006-01-02"), status)
+		if notes != "" {
+			message += ": " + notes
+		}
+		s.notificationService.Notify(*timesheet.Employee.UserID, "timesheet", "Timesheet "+status, message, fmt.Sprintf("/timesheet/saya/%d", timesheet.ID))
+	}
+
+	return timesheet, nil
+}
+
+// notifyApprovers tells the department manager, or HR when there is none, about a
+// submitted timesheet and how many of its days disagree with attendance
+func (s *TimesheetService) notifyApprovers(employee *models.Employee, detail *models.TimesheetDetail) {
+	if employee == nil {
+		return
+	}
+
+	timesheet := detail.Timesheet
+	title := "Timesheet submitted"
+	message := fmt.Sprintf("%s %s submitted %g hours for the week of %s", employee.FirstName, employee.LastName, timesheet.TotalHours, timesheet.WeekStart.Format("2006-01-02"))
+	if detail.Discrepancies > 0 {
+		message += fmt.Sprintf("; %d days differ from attendance", detail.Discrepancies)
+	}
+	link := fmt.Sprintf("/timesheet/%d", timesheet.ID)
+
+	if employee.Department != nil && employee.Department.ManagerID != nil && *employee.Department.ManagerID != employee.ID {
+		manager, err := s.employeeRepo.FindByID(*employee.Department.ManagerID)
+		if err == nil && manager.UserID != nil {
+			s.notificationService.Notify(*manager.UserID, "timesheet", title, message, link)
+			return
+		}
+	}
+	s.notificationService.NotifyRoles([]string{"hr_manager"}, "timesheet", title, message, link)
+}
+
+// percentOf returns part as a percentage of whole, rounded to one decimal
+func percentOf(part, whole float64) float64 {
+	return math.Round(part/whole*1000) / 10
+}
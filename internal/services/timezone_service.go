@@ -0,0 +1,112 @@
+package services
+
+import (
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"sync"
+	"time"
+)
+
+// TimezoneService resolves the time zone an employee works in: their own, else
+// that of their work location, else the company default. Calendar dates such as
+// the work date of a punch and "today" are taken in that zone.
+type TimezoneService struct {
+	locationRepo *repositories.WorkLocationRepository
+	employeeRepo *repositories.EmployeeRepository
+	defaultZone  *time.Location
+
+	mu    sync.Mutex
+	zones map[string]*time.Location
+}
+
+func NewTimezoneService(locationRepo *repositories.WorkLocationRepository, employeeRepo *repositories.EmployeeRepository, defaultZone *time.Location) *TimezoneService {
+	return &TimezoneService{
+		locationRepo: locationRepo,
+		employeeRepo: employeeRepo,
+		defaultZone:  defaultZone,
+		zones:        map[string]*time.Location{},
+	}
+}
+
+// Default returns the company time zone
+func (s *TimezoneService) Default() *time.Location {
+	return s.defaultZone
+}
+
+// Today returns the current date in the company time zone
+func (s *TimezoneService) Today() time.Time {
+	return localDate(time.Now(), s.defaultZone)
+}
+
+// For returns the time zone the employee works in
+func (s *TimezoneService) For(employee *models.Employee) *time.Location {
+	if employee.Timezone != "" {
+		return s.load(employee.Timezone)
+	}
+	if employee.WorkLocation != nil {
+		return s.load(employee.WorkLocation.Timezone)
+	}
+	return s.ForLocation(employee.WorkLocationID)
+}
+
+func (s *TimezoneService) ForEmployeeID(employeeID uint) *time.Location {
+	employee, err := s.employeeRepo.FindByID(employeeID)
+	if err != nil {
+		return s.defaultZone
+	}
+	return s.For(employee)
+}
+
+// ForLocation returns the time zone of a work location, or the company default
+func (s *TimezoneService) ForLocation(locationID *uint) *time.Location {
+	if locationID == nil {
+		return s.defaultZone
+	}
+	location, err := s.locationRepo.FindByID(*locationID)
+	if err != nil {
+		return s.defaultZone
+	}
+	return s.load(location.Timezone)
+}
+
+// ForEmployees returns the time zone of each employee, keyed by employee ID
+func (s *TimezoneService) ForEmployees(employees []models.Employee) (map[uint]*time.Location, error) {
+	locations, err := s.locationRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	byLocation := make(map[uint]string, len(locations))
+	for _, location := range locations {
+		byLocation[location.ID] = location.Timezone
+	}
+
+	zones := make(map[uint]*time.Location, len(employees))
+	for _, employee := range employees {
+		name := employee.Timezone
+		if name == "" && employee.WorkLocationID != nil {
+			name = byLocation[*employee.WorkLocationID]
+		}
+		zones[employee.ID] = s.load(name)
+	}
+	return zones, nil
+}
+
+// load returns the named time zone, falling back to the company default for
+// empty or unknown names. Names are validated when saved.
+func (s *TimezoneService) load(name string) *time.Location {
+	if name == "" {
+		return s.defaultZone
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if zone, ok := s.zones[name]; ok {
+		return zone
+	}
+	zone, err := time.LoadLocation(name)
+	if err != nil {
+		zone = s.defaultZone
+	}
+	s.zones[name] = zone
+	return zone
+}
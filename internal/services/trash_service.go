@@ -0,0 +1,195 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hr-backend/internal/config"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"hr-backend/internal/storage"
+	"time"
+)
+
+type TrashService struct {
+	trashRepo    *repositories.TrashRepository
+	employeeRepo *repositories.EmployeeRepository
+	deptRepo     *repositories.DepartmentRepository
+	storage      *storage.LocalStorage
+	cfg          *config.Config
+}
+
+func NewTrashService(trashRepo *repositories.TrashRepository, employeeRepo *repositories.EmployeeRepository, deptRepo *repositories.DepartmentRepository, storage *storage.LocalStorage, cfg *config.Config) *TrashService {
+	return &TrashService{
+		trashRepo:    trashRepo,
+		employeeRepo: employeeRepo,
+		deptRepo:     deptRepo,
+		storage:      storage,
+		cfg:          cfg,
+	}
+}
+
+// GetTrash lists the soft-deleted records of an entity, most recently deleted first
+func (s *TrashService) GetTrash(entity string, page, limit int) ([]models.TrashedRecord, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	var records []models.TrashedRecord
+	var total int64
+
+	switch entity {
+	case models.TrashEntityEmployee:
+		employees, count, err := s.trashRepo.FindEmployees(page, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i := range employees {
+			employee := &employees[i]
+			label := fmt.Sprintf("%s %s (%s)", employee.FirstName, employee.LastName, employee.EmployeeCode)
+			records = append(records, s.trashedRecord(employee.ID, label, employee.DeletedAt.Time, employee))
+		}
+		total = count
+	case models.TrashEntityDepartment:
+		departments, count, err := s.trashRepo.FindDepartments(page, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i := range departments {
+			department := &departments[i]
+			records = append(records, s.trashedRecord(department.ID, department.Name, department.DeletedAt.Time, department))
+		}
+		total = count
+	case models.TrashEntityLeave:
+		leaves, count, err := s.trashRepo.FindLeaves(page, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i := range leaves {
+			leave := &leaves[i]
+			label := fmt.Sprintf("%s leave %s - %s", leave.LeaveType, leave.StartDate.Format("2006-01-02"), leave.EndDate.Format("2006-01-02"))
+			if leave.Employee != nil {
+				label = fmt.Sprintf("%s %s: %s", leave.Employee.FirstName, leave.Employee.LastName, label)
+			}
+			records = append(records, s.trashedRecord(leave.ID, label, leave.DeletedAt.Time, leave))
+		}
+		total = count
+	default:
+		return nil, 0, errors.New("unknown trash entity")
+	}
+
+	if records == nil {
+		records = []models.TrashedRecord{}
+	}
+	return records, total, nil
+}
+
+// Restore undeletes a record. Employees come back with their user account, but
+// only once the department they belong to has been restored.
+func (s *TrashService) Restore(entity string, id uint) error {
+	switch entity {
+	case models.TrashEntityEmployee:
+		employee, err := s.trashRepo.FindTrashedEmployee(id)
+		if err != nil {
+			return errors.New("deleted employee not found")
+		}
+		if employee.DepartmentID != nil {
+			if _, err := s.deptRepo.FindByID(*employee.DepartmentID); err != nil {
+				return errors.New("the employee's department is deleted; restore the department first")
+			}
+		}
+		if err := s.trashRepo.RestoreEmployee(employee); err != nil {
+			return err
+		}
+		return s.employeeRepo.RefreshSearchText(employee.ID)
+	case models.TrashEntityDepartment:
+		if _, err := s.trashRepo.FindTrashedDepartment(id); err != nil {
+			return errors.New("deleted department not found")
+		}
+		if err := s.trashRepo.RestoreDepartment(id); err != nil {
+			return err
+		}
+		return s.employeeRepo.RefreshSearchTextByDepartment(id)
+	case models.TrashEntityLeave:
+		leave, err := s.trashRepo.FindTrashedLeave(id)
+		if err != nil {
+			return errors.New("deleted leave not found")
+		}
+		if _, err := s.employeeRepo.FindByID(leave.EmployeeID); err != nil {
+			return errors.New("the leave's employee is deleted; restore the employee first")
+		}
+		return s.trashRepo.RestoreLeave(id)
+	default:
+		return errors.New("unknown trash entity")
+	}
+}
+
+// Purge permanently removes a record that is in the trash
+func (s *TrashService) Purge(entity string, id uint) error {
+	switch entity {
+	case models.TrashEntityEmployee:
+		employee, err := s.trashRepo.FindTrashedEmployee(id)
+		if err != nil {
+			return errors.New("deleted employee not found")
+		}
+		if err := s.trashRepo.PurgeEmployee(employee); err != nil {
+			return err
+		}
+		return deleteEmployeeFiles(s.storage, employee.ID)
+	case models.TrashEntityDepartment:
+		if _, err := s.trashRepo.FindTrashedDepartment(id); err != nil {
+			return errors.New("deleted department not found")
+		}
+		return s.trashRepo.PurgeDepartment(id)
+	case models.TrashEntityLeave:
+		if _, err := s.trashRepo.FindTrashedLeave(id); err != nil {
+			return errors.New("deleted leave not found")
+		}
+		return s.trashRepo.PurgeLeave(id)
+	default:
+		return errors.New("unknown trash entity")
+	}
+}
+
+// PurgeExpired permanently removes records that have been in the trash longer than
+// the retention period. A record that cannot be purged does not block the others.
+func (s *TrashService) PurgeExpired() error {
+	cutoff := time.Now().AddDate(0, 0, -s.cfg.Trash.RetentionDays)
+
+	entities := []struct {
+		name  string
+		model interface{}
+	}{
+		// Leaves first, since purging an employee removes their leaves as well
+		{models.TrashEntityLeave, &models.Leave{}},
+		{models.TrashEntityEmployee, &models.Employee{}},
+		{models.TrashEntityDepartment, &models.Department{}},
+	}
+
+	var errs []error
+	for _, entity := range entities {
+		ids, err := s.trashRepo.FindExpiredIDs(entity.model, cutoff)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := s.Purge(entity.name, id); err != nil {
+				errs = append(errs, fmt.Errorf("purge %s %d: %w", entity.name, id, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *TrashService) trashedRecord(id uint, label string, deletedAt time.Time, record interface{}) models.TrashedRecord {
+	return models.TrashedRecord{
+		ID:        id,
+		Label:     label,
+		DeletedAt: deletedAt,
+		PurgeAt:   deletedAt.AddDate(0, 0, s.cfg.Trash.RetentionDays),
+		Record:    record,
+	}
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"errors"
+	"hr-backend/internal/models"
+	"hr-backend/internal/repositories"
+	"strings"
+)
+
+type WorkLocationService struct {
+	locationRepo *repositories.WorkLocationRepository
+	holidayRepo  *repositories.HolidayRepository
+}
+
+func NewWorkLocationService(locationRepo *repositories.WorkLocationRepository, holidayRepo *repositories.HolidayRepository) *WorkLocationService {
+	return &WorkLocationService{
+		locationRepo: locationRepo,
+		holidayRepo:  holidayRepo,
+	}
+}
+
+func (s *WorkLocationService) GetLocations() ([]models.WorkLocation, error) {
+	return s.locationRepo.FindAll()
+}
+
+func (s *WorkLocationService) GetLocationByID(id uint) (*models.WorkLocation, error) {
+	location, err := s.locationRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("work location not found")
+	}
+	return location, nil
+}
+
+func (s *WorkLocationService) CreateLocation(req *models.WorkLocationRequest) (*models.WorkLocation, error) {
+	location := &models.WorkLocation{}
+	if err := s.applyLocationRequest(location, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.locationRepo.Save(location); err != nil {
+		return nil, err
+	}
+	return s.locationRepo.FindByID(location.ID)
+}
+
+func (s *WorkLocationService) UpdateLocation(id uint, req *models.WorkLocationRequest) (*models.WorkLocation, error) {
+	location, err := s.GetLocationByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyLocationRequest(location, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.locationRepo.Save(location); err != nil {
+		return nil, err
+	}
+	return s.locationRepo.FindByID(location.ID)
+}
+
+func (s *WorkLocationService) DeleteLocation(id uint) error {
+	if _, err := s.GetLocationByID(id); err != nil {
+		return err
+	}
+
+	count, err := s.locationRepo.CountEmployees(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("cannot delete a work location that still has employees")
+	}
+
+	return s.locationRepo.Delete(id)
+}
+
+func (s *WorkLocationService) GetGeofences(locationID uint) ([]models.Geofence, error) {
+	if _, err := s.GetLocationByID(locationID); err != nil {
+		return nil, err
+	}
+	return s.locationRepo.FindGeofences(locationID)
+}
+
+func (s *WorkLocationService) CreateGeofence(locationID uint, req *models.GeofenceRequest) (*models.Geofence, error) {
+	if _, err := s.GetLocationByID(locationID); err != nil {
+		return nil, err
+	}
+
+	geofence := &models.Geofence{WorkLocationID: locationID, IsActive: true}
+	if err := applyGeofenceRequest(geofence, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.locationRepo.SaveGeofence(geofence); err != nil {
+		return nil, err
+	}
+	return geofence, nil
+}
+
+func (s *WorkLocationService) UpdateGeofence(locationID, id uint, req *models.GeofenceRequest) (*models.Geofence, error) {
+	geofence, err := s.locationRepo.FindGeofenceByID(id)
+	if err != nil || geofence.WorkLocationID != locationID {
+		return nil, errors.New("geofence not found")
+	}
+
+	if err := applyGeofenceRequest(geofence, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.locationRepo.SaveGeofence(geofence); err != nil {
+		return nil, err
+	}
+	return geofence, nil
+}
+
+func (s *WorkLocationService) DeleteGeofence(locationID, id uint) error {
+	geofence, err := s.locationRepo.FindGeofenceByID(id)
+	if err != nil || geofence.WorkLocationID != locationID {
+		return errors.New("geofence not found")
+	}
+	return s.locationRepo.DeleteGeofence(id)
+}
+
+func (s *WorkLocationService) applyLocationRequest(location *models.WorkLocation, req *models.WorkLocationRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if existing, err := s.locationRepo.FindByName(name); err == nil && existing.ID != location.ID {
+		return errors.New("a work location with this name already exists")
+	}
+
+	if req.Timezone != "" && !models.ValidTimezone(req.Timezone) {
+		return errors.New("invalid time zone")
+	}
+
+	if req.HolidayCalendarID != nil {
+		if _, err := s.holidayRepo.FindCalendarByID(*req.HolidayCalendarID); err != nil {
+			return errors.New("holiday calendar not found")
+		}
+	}
+
+	location.Name = name
+	location.Address = req.Address
+	location.Timezone = req.Timezone
+	location.HolidayCalendarID = req.HolidayCalendarID
+	location.HolidayCalendar = nil
+	return nil
+}
+
+func applyGeofenceRequest(geofence *models.Geofence, req *models.GeofenceRequest) error {
+	switch req.Type {
+	case models.GeofenceCircle:
+		if req.RadiusMeters <= 0 {
+			return errors.New("a circular geofence needs a radius")
+		}
+		geofence.Latitude = req.Latitude
+		geofence.Longitude = req.Longitude
+		geofence.RadiusMeters = req.RadiusMeters
+		geofence.Polygon = nil
+	case models.GeofencePolygon:
+		if len(req.Polygon) < 3 {
+			return errors.New("a polygon geofence needs at least three points")
+		}
+		geofence.Latitude = 0
+		geofence.Longitude = 0
+		geofence.RadiusMeters = 0
+		geofence.Polygon = req.Polygon
+	}
+
+	geofence.Name = strings.TrimSpace(req.Name)
+	geofence.Type = req.Type
+	if req.IsActive != nil {
+		geofence.IsActive = *req.IsActive
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Package storage keeps uploaded files on the local filesystem under UPLOAD_DIR.
+// Paths handed out are relative to the upload directory and stored in the database.
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type LocalStorage struct {
+	root string
+}
+
+func NewLocalStorage(root string) *LocalStorage {
+	return &LocalStorage{root: root}
+}
+
+// Save writes the content to a new file with a random name inside dir and
+// returns its relative path
+func (s *LocalStorage) Save(dir, ext string, content io.Reader) (string, error) {
+	name := make([]byte, 16)
+	if _, err := rand.Read(name); err != nil {
+		return "", err
+	}
+	rel := filepath.ToSlash(filepath.Join(dir, hex.EncodeToString(name)+ext))
+
+	full, err := s.resolve(rel)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(full), 0o750); err != nil {
+		return "", err
+	}
+
+	f, err := os.OpenFile(full, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(f, content); err != nil {
+		f.Close()
+		os.Remove(full)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(full)
+		return "", err
+	}
+
+	return rel, nil
+}
+
+func (s *LocalStorage) Open(rel string) (*os.File, error) {
+	full, err := s.resolve(rel)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(full)
+}
+
+// ReadFile returns the whole content of a stored file
+func (s *LocalStorage) ReadFile(rel string) ([]byte, error) {
+	full, err := s.resolve(rel)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(full)
+}
+
+// Delete removes a stored file; a file that is already gone is not an error
+func (s *LocalStorage) Delete(rel string) error {
+	full, err := s.resolve(rel)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(full); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// DeleteDir removes a directory and everything stored below it
+func (s *LocalStorage) DeleteDir(rel string) error {
+	full, err := s.resolve(rel)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(full)
+}
+
+// resolve maps a relative path into the upload directory, refusing paths that escape it
+func (s *LocalStorage) resolve(rel string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(rel))
+	if clean == "." || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", errors.New("invalid storage path")
+	}
+	return filepath.Join(s.root, clean), nil
+}
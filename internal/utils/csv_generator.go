@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"hr-backend/internal/models"
+	"strconv"
+	"strings"
+)
+
+// GenerateEmployeesCSV exports employees as CSV with one extra column per custom field
+func GenerateEmployeesCSV(employees []models.Employee, customFields []models.CustomFieldDefinition) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	header := []string{
+		"Kode Karyawan", "Nama Depan", "Nama Belakang", "Email", "Departemen",
+		"Posisi", "Telepon", "Tanggal Masuk", "Status",
+	}
+	for _, field := range customFields {
+		header = append(header, field.Label)
+	}
+
+	if err := writer.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, employee := range employees {
+		email := ""
+		if employee.User != nil {
+			email = employee.User.Email
+		}
+		department := ""
+		if employee.Department != nil {
+			department = employee.Department.Name
+		}
+
+		row := []string{
+			employee.EmployeeCode,
+			employee.FirstName,
+			employee.LastName,
+			email,
+			department,
+			employee.Position,
+			string(employee.Phone),
+			employee.HireDate.Format("2006-01-02"),
+			employee.EmploymentStatus,
+		}
+		for _, field := range customFields {
+			row = append(row, formatCustomValue(employee.CustomFields[field.Key]))
+		}
+
+		if err := writer.Write(row); err != nil {
+			return nil, fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to generate CSV: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// formatCustomValue renders a custom field value as a single CSV cell
+func formatCustomValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case bool:
+		if v {
+			return "Ya"
+		}
+		return "Tidak"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, fmt.Sprint(item))
+		}
+		return strings.Join(parts, "; ")
+	}
+	return fmt.Sprint(value)
+}
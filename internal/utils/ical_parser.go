@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ICalEvent is the part of an iCalendar VEVENT needed to import holidays.
+// EndDate is inclusive, unlike DTEND.
+type ICalEvent struct {
+	UID       string
+	Summary   string
+	StartDate time.Time
+	EndDate   time.Time
+	Yearly    bool
+	Cancelled bool
+}
+
+// ParseICalendar reads the events of an iCalendar (RFC 5545) file. Only dates
+// are kept, so timed events are treated as covering the day they start on.
+func ParseICalendar(r io.Reader) ([]ICalEvent, error) {
+	lines, err := unfoldICalLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []ICalEvent
+	var event *ICalEvent
+	var dtend string
+	var allDayEnd bool
+
+	for i, line := range lines {
+		name, params, value, ok := splitICalLine(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			event = &ICalEvent{}
+			dtend, allDayEnd = "", false
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if event == nil {
+				continue
+			}
+			if event.StartDate.IsZero() {
+				return nil, fmt.Errorf("line %d: event %q has no DTSTART", i+1, event.Summary)
+			}
+			event.EndDate = event.StartDate
+			if dtend != "" {
+				end, err := parseICalDate(dtend)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", i+1, err)
+				}
+				// DTEND of an all-day event is the day after it ends
+				if allDayEnd {
+					end = end.AddDate(0, 0, -1)
+				}
+				if end.After(event.StartDate) {
+					event.EndDate = end
+				}
+			}
+			events = append(events, *event)
+			event = nil
+		case event == nil:
+			continue
+		case name == "UID":
+			event.UID = value
+		case name == "SUMMARY":
+			event.Summary = unescapeICalText(value)
+		case name == "DTSTART":
+			start, err := parseICalDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			event.StartDate = start
+		case name == "DTEND":
+			dtend = value
+			allDayEnd = strings.Contains(strings.ToUpper(params), "VALUE=DATE") && !strings.Contains(value, "T")
+		case name == "RRULE":
+			event.Yearly = strings.Contains(strings.ToUpper(value), "FREQ=YEARLY")
+		case name == "STATUS":
+			event.Cancelled = strings.EqualFold(value, "CANCELLED")
+		}
+	}
+
+	return events, nil
+}
+
+// unfoldICalLines joins continuation lines, which start with a space or tab
+func unfoldICalLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// splitICalLine splits "NAME;PARAM=x:value" into its upper-cased name, parameters and value
+func splitICalLine(line string) (name, params, value string, ok bool) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return "", "", "", false
+	}
+	head := line[:colon]
+	value = strings.TrimSpace(line[colon+1:])
+	if semi := strings.Index(head, ";"); semi >= 0 {
+		params = head[semi+1:]
+		head = head[:semi]
+	}
+	return strings.ToUpper(strings.TrimSpace(head)), params, value, true
+}
+
+func parseICalDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
+
+func unescapeICalText(value string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
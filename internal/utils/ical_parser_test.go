@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func icalDate(value string) time.Time {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return date
+}
+
+func TestParseICalendar(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []ICalEvent
+		wantErr bool
+	}{
+		{
+			name: "all-day event ends the day before DTEND",
+			input: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:idul-fitri\r\nSUMMARY:Hari Raya Idul Fitri\r\n" +
+				"DTSTART;VALUE=DATE:20260320\r\nDTEND;VALUE=DATE:20260322\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			want: []ICalEvent{{UID: "idul-fitri", Summary: "Hari Raya Idul Fitri", StartDate: icalDate("2026-03-20"), EndDate: icalDate("2026-03-21")}},
+		},
+		{
+			name: "single all-day event",
+			input: "BEGIN:VEVENT\nSUMMARY:Hari Kemerdekaan\nDTSTART;VALUE=DATE:20260817\nDTEND;VALUE=DATE:20260818\n" +
+				"RRULE:FREQ=YEARLY\nEND:VEVENT\n",
+			want: []ICalEvent{{Summary: "Hari Kemerdekaan", StartDate: icalDate("2026-08-17"), EndDate: icalDate("2026-08-17"), Yearly: true}},
+		},
+		{
+			name:  "timed event covers the day it starts on",
+			input: "BEGIN:VEVENT\nSUMMARY:Town hall\nDTSTART:20260305T090000Z\nDTEND:20260305T110000Z\nEND:VEVENT\n",
+			want:  []ICalEvent{{Summary: "Town hall", StartDate: icalDate("2026-03-05"), EndDate: icalDate("2026-03-05")}},
+		},
+		{
+			name:  "event without DTEND lasts one day",
+			input: "BEGIN:VEVENT\nSUMMARY:Nyepi\nDTSTART;VALUE=DATE:20260319\nEND:VEVENT\n",
+			want:  []ICalEvent{{Summary: "Nyepi", StartDate: icalDate("2026-03-19"), EndDate: icalDate("2026-03-19")}},
+		},
+		{
+			name: "folded lines, escapes and cancellation",
+			input: "BEGIN:VEVENT\nSUMMARY:Cuti bersama\\, \n Natal\nDTSTART;VALUE=DATE:20261224\n" +
+				"STATUS:CANCELLED\nEND:VEVENT\n",
+			want: []ICalEvent{{Summary: "Cuti bersama, Natal", StartDate: icalDate("2026-12-24"), EndDate: icalDate("2026-12-24"), Cancelled: true}},
+		},
+		{
+			name:  "properties outside events are ignored",
+			input: "BEGIN:VCALENDAR\nSUMMARY:Calendar name\nDTSTART:garbage\nEND:VCALENDAR\n",
+			want:  nil,
+		},
+		{
+			name:    "event without DTSTART",
+			input:   "BEGIN:VEVENT\nSUMMARY:Broken\nEND:VEVENT\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid date",
+			input:   "BEGIN:VEVENT\nDTSTART;VALUE=DATE:2026-03\nEND:VEVENT\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseICalendar(strings.NewReader(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseICalendar() = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseICalendar() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseICalendar() returned %d events, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("event %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -104,8 +104,14 @@ func GeneratePayrollPDF(payroll *models.Payroll) ([]byte, error) {
 	pdf.CellFormat(100, 8, "Tunjangan", "1", 0, "L", false, 0, "")
 	pdf.CellFormat(70, 8, formatCurrency(payroll.Allowances), "1", 1, "R", false, 0, "")
 
+	// Overtime
+	if payroll.OvertimePay > 0 {
+		pdf.CellFormat(100, 8, fmt.Sprintf("Lembur (%.2f jam)", payroll.OvertimeHours), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(70, 8, formatCurrency(payroll.OvertimePay), "1", 1, "R", false, 0, "")
+	}
+
 	// Gross Salary
-	grossSalary := payroll.BasicSalary + payroll.Allowances
+	grossSalary := payroll.BasicSalary + payroll.Allowances + payroll.OvertimePay
 	pdf.SetFont("Arial", "B", 10)
 	pdf.SetFillColor(249, 250, 251)
 	pdf.CellFormat(100, 8, "Total Gaji Kotor", "1", 0, "L", true, 0, "")
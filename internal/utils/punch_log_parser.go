@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+)
+
+// punchTimeLayouts are the timestamp formats written by common fingerprint terminals
+var punchTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+	"02-01-2006 15:04:05",
+	"2006-01-02T15:04:05",
+}
+
+// DevicePunch is one parsed line of a time clock export
+type DevicePunch struct {
+	Line         int
+	DeviceUserID string
+	Time         time.Time
+}
+
+// DevicePunchError describes a line that could not be read
+type DevicePunchError struct {
+	Line   int    `json:"line"`
+	Text   string `json:"text"`
+	Reason string `json:"reason"`
+}
+
+// ParseDevicePunchLog reads a punch log exported by a time clock: one punch per
+// line with the device user ID (PIN) first and the timestamp next, separated by
+// tabs, commas or semicolons. The timestamp may also be split into a date and a
+// time column. Further columns such as verify mode are ignored. Timestamps are
+// interpreted in loc. A leading header line is skipped.
+func ParseDevicePunchLog(r io.Reader, loc *time.Location) ([]DevicePunch, []DevicePunchError, error) {
+	var punches []DevicePunch
+	var errs []DevicePunchError
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		text := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if text == "" {
+			continue
+		}
+
+		fields := splitPunchLine(text)
+		if len(fields) < 2 {
+			errs = append(errs, DevicePunchError{Line: lineNo, Text: text, Reason: "expected a user ID and a timestamp"})
+			continue
+		}
+
+		punchTime, ok := parsePunchTime(fields[1], loc)
+		if !ok && len(fields) >= 3 {
+			punchTime, ok = parsePunchTime(fields[1]+" "+fields[2], loc)
+		}
+		if !ok {
+			if len(punches) == 0 && len(errs) == 0 {
+				continue // header
+			}
+			errs = append(errs, DevicePunchError{Line: lineNo, Text: text, Reason: "unrecognized timestamp"})
+			continue
+		}
+
+		punches = append(punches, DevicePunch{
+			Line:         lineNo,
+			DeviceUserID: NormalizeDeviceUserID(fields[0]),
+			Time:         punchTime,
+		})
+	}
+
+	return punches, errs, scanner.Err()
+}
+
+// NormalizeDeviceUserID drops the zero padding some devices add to user IDs
+func NormalizeDeviceUserID(id string) string {
+	id = strings.TrimSpace(id)
+	if trimmed := strings.TrimLeft(id, "0"); trimmed != "" {
+		return trimmed
+	}
+	return id
+}
+
+func splitPunchLine(text string) []string {
+	var raw []string
+	switch {
+	case strings.Contains(text, "\t"):
+		raw = strings.Split(text, "\t")
+	case strings.Contains(text, ";"):
+		raw = strings.Split(text, ";")
+	default:
+		raw = strings.Split(text, ",")
+	}
+
+	fields := make([]string, 0, len(raw))
+	for _, field := range raw {
+		fields = append(fields, strings.Trim(strings.TrimSpace(field), `"`))
+	}
+	return fields
+}
+
+func parsePunchTime(value string, loc *time.Location) (time.Time, bool) {
+	for _, layout := range punchTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
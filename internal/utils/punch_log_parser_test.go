@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDevicePunchLog(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*3600)
+	at := func(value string) time.Time {
+		parsed, err := time.ParseInLocation("2006-01-02 15:04:05", value, jakarta)
+		if err != nil {
+			panic(err)
+		}
+		return parsed
+	}
+
+	tests := []struct {
+		name       string
+		input      string
+		want       []DevicePunch
+		wantErrors []int
+	}{
+		{
+			name:  "tab separated with seconds",
+			input: "00012\t2026-03-02 07:58:31\t1\t0\n12\t2026-03-02 17:03:02\t1\t1\n",
+			want: []DevicePunch{
+				{Line: 1, DeviceUserID: "12", Time: at("2026-03-02 07:58:31")},
+				{Line: 2, DeviceUserID: "12", Time: at("2026-03-02 17:03:02")},
+			},
+		},
+		{
+			name:  "header, quotes and minute resolution",
+			input: "\ufeff\"No\",\"DateTime\"\n\"7\",\"2026/03/02 08:00\"\n",
+			want:  []DevicePunch{{Line: 2, DeviceUserID: "7", Time: at("2026-03-02 08:00:00")}},
+		},
+		{
+			name:  "date and time in separate columns",
+			input: "7;02/03/2026;08:01:15\n",
+			want:  []DevicePunch{{Line: 1, DeviceUserID: "7", Time: at("2026-03-02 08:01:15")}},
+		},
+		{
+			name:  "ISO timestamps and blank lines",
+			input: "7,2026-03-02T08:01:15\n\n8,02-03-2026 08:02:10\n",
+			want: []DevicePunch{
+				{Line: 1, DeviceUserID: "7", Time: at("2026-03-02 08:01:15")},
+				{Line: 3, DeviceUserID: "8", Time: at("2026-03-02 08:02:10")},
+			},
+		},
+		{
+			name:  "user ID of zeros is kept",
+			input: "000,2026-03-02 08:00:00\n",
+			want:  []DevicePunch{{Line: 1, DeviceUserID: "000", Time: at("2026-03-02 08:00:00")}},
+		},
+		{
+			name:       "bad lines are reported after the first punch",
+			input:      "7,2026-03-02 08:00:00\nonly-one-field\n7,yesterday\n",
+			want:       []DevicePunch{{Line: 1, DeviceUserID: "7", Time: at("2026-03-02 08:00:00")}},
+			wantErrors: []int{2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			punches, errs, err := ParseDevicePunchLog(strings.NewReader(tt.input), jakarta)
+			if err != nil {
+				t.Fatalf("ParseDevicePunchLog() error = %v", err)
+			}
+
+			if len(punches) != len(tt.want) {
+				t.Fatalf("got %d punches, want %d: %+v", len(punches), len(tt.want), punches)
+			}
+			for i := range punches {
+				got, want := punches[i], tt.want[i]
+				if got.Line != want.Line || got.DeviceUserID != want.DeviceUserID || !got.Time.Equal(want.Time) {
+					t.Errorf("punch %d = %+v, want %+v", i, got, want)
+				}
+			}
+
+			if len(errs) != len(tt.wantErrors) {
+				t.Fatalf("got %d line errors, want %d: %+v", len(errs), len(tt.wantErrors), errs)
+			}
+			for i := range errs {
+				if errs[i].Line != tt.wantErrors[i] {
+					t.Errorf("error %d is on line %d, want %d", i, errs[i].Line, tt.wantErrors[i])
+				}
+			}
+		})
+	}
+}